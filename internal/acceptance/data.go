@@ -85,6 +85,8 @@ func BuildTestData(t *testing.T, resourceType string, resourceLabel string) Test
 		Secondary: os.Getenv("ARM_TEST_SUBSCRIPTION_ID_ALT"),
 	}
 
+	applyRecordedTestData(t, &testData)
+
 	return testData
 }
 
@@ -0,0 +1,138 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package acceptance
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
+)
+
+var _ plancheck.PlanCheck = expectDriftedAttributes{}
+
+// expectDriftedAttributes is a PlanCheck which asserts that the changes planned for a resource are exactly the
+// expected attributes - where an expected attribute of `tags` matches changes to both `tags` and `tags.env`
+type expectDriftedAttributes struct {
+	resourceAddress string
+	attributes      []string
+}
+
+func (e expectDriftedAttributes) CheckPlan(_ context.Context, req plancheck.CheckPlanRequest, resp *plancheck.CheckPlanResponse) {
+	for _, rc := range req.Plan.ResourceChanges {
+		if rc.Address != e.resourceAddress || rc.Change == nil {
+			continue
+		}
+
+		changed := changedAttributes(rc.Change.Before, rc.Change.After, rc.Change.AfterUnknown)
+		if err := compareDriftedAttributes(e.attributes, changed); err != nil {
+			resp.Error = fmt.Errorf("'%s' - %+v", rc.Address, err)
+		}
+		return
+	}
+
+	resp.Error = fmt.Errorf("%s - Resource not found in plan ResourceChanges", e.resourceAddress)
+}
+
+// compareDriftedAttributes returns an error when the changed attributes don't exactly match the expected attributes
+func compareDriftedAttributes(expected []string, changed []string) error {
+	unexpected := make([]string, 0)
+	matched := make(map[string]bool, len(expected))
+	for _, path := range changed {
+		found := false
+		for _, attribute := range expected {
+			if path == attribute || strings.HasPrefix(path, attribute+".") {
+				matched[attribute] = true
+				found = true
+			}
+		}
+		if !found {
+			unexpected = append(unexpected, path)
+		}
+	}
+
+	missing := make([]string, 0)
+	for _, attribute := range expected {
+		if !matched[attribute] {
+			missing = append(missing, attribute)
+		}
+	}
+
+	if len(unexpected) > 0 || len(missing) > 0 {
+		return fmt.Errorf("expected changes to exactly %v but got %v (unexpected: %v, missing: %v)", expected, changed, unexpected, missing)
+	}
+	return nil
+}
+
+// changedAttributes returns the sorted paths (e.g. `tags.env` or `network_rules.0.default_action`) of the values which
+// differ between before and after, ignoring any values which are unknown until apply
+func changedAttributes(before, after, afterUnknown interface{}) []string {
+	beforeValues := make(map[string]interface{})
+	flattenAttributes("", before, beforeValues)
+	afterValues := make(map[string]interface{})
+	flattenAttributes("", after, afterValues)
+	unknownValues := make(map[string]interface{})
+	flattenAttributes("", afterUnknown, unknownValues)
+
+	paths := make(map[string]struct{})
+	for path := range beforeValues {
+		paths[path] = struct{}{}
+	}
+	for path := range afterValues {
+		paths[path] = struct{}{}
+	}
+
+	output := make([]string, 0)
+	for path := range paths {
+		if isUnknownAttribute(path, unknownValues) {
+			continue
+		}
+		if !reflect.DeepEqual(beforeValues[path], afterValues[path]) {
+			output = append(output, path)
+		}
+	}
+	sort.Strings(output)
+	return output
+}
+
+func isUnknownAttribute(path string, unknownValues map[string]interface{}) bool {
+	for unknownPath, v := range unknownValues {
+		if unknown, ok := v.(bool); !ok || !unknown {
+			continue
+		}
+		if path == unknownPath || strings.HasPrefix(path, unknownPath+".") {
+			return true
+		}
+	}
+	return false
+}
+
+// flattenAttributes flattens the nested maps and lists within input into output, keyed by their path
+func flattenAttributes(prefix string, input interface{}, output map[string]interface{}) {
+	join := func(key string) string {
+		if prefix == "" {
+			return key
+		}
+		return prefix + "." + key
+	}
+
+	switch v := input.(type) {
+	case map[string]interface{}:
+		for key, val := range v {
+			flattenAttributes(join(key), val, output)
+		}
+	case []interface{}:
+		for i, val := range v {
+			flattenAttributes(join(strconv.Itoa(i)), val, output)
+		}
+	default:
+		if prefix != "" && v != nil {
+			output[prefix] = v
+		}
+	}
+}
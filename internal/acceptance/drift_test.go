@@ -0,0 +1,98 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package acceptance
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestChangedAttributes(t *testing.T) {
+	before := map[string]interface{}{
+		"name":     "example",
+		"location": "westeurope",
+		"tags": map[string]interface{}{
+			"environment": "Drifted",
+			"cost_center": "MSFT",
+		},
+		"network_rules": []interface{}{
+			map[string]interface{}{
+				"default_action": "Deny",
+				"ip_rules":       []interface{}{"10.0.0.1"},
+			},
+		},
+		"etag": "abc",
+	}
+	after := map[string]interface{}{
+		"name":     "example",
+		"location": "westeurope",
+		"tags": map[string]interface{}{
+			"environment": "Production",
+			"cost_center": "MSFT",
+		},
+		"network_rules": []interface{}{
+			map[string]interface{}{
+				"default_action": "Allow",
+				"ip_rules":       []interface{}{"10.0.0.1"},
+			},
+		},
+	}
+	afterUnknown := map[string]interface{}{
+		"etag": true,
+	}
+
+	actual := changedAttributes(before, after, afterUnknown)
+	expected := []string{"network_rules.0.default_action", "tags.environment"}
+	if !reflect.DeepEqual(actual, expected) {
+		t.Fatalf("expected %v but got %v", expected, actual)
+	}
+}
+
+func TestCompareDriftedAttributes(t *testing.T) {
+	changed := []string{"network_rules.0.default_action", "tags.environment"}
+
+	testData := []struct {
+		name     string
+		expected []string
+		valid    bool
+	}{
+		{
+			name:     "exact",
+			expected: []string{"network_rules.0.default_action", "tags.environment"},
+			valid:    true,
+		},
+		{
+			name:     "parent attributes",
+			expected: []string{"network_rules", "tags"},
+			valid:    true,
+		},
+		{
+			name:     "unexpected change",
+			expected: []string{"tags"},
+			valid:    false,
+		},
+		{
+			name:     "missing change",
+			expected: []string{"network_rules", "tags", "sku_name"},
+			valid:    false,
+		},
+		{
+			name:     "prefix isn't a parent",
+			expected: []string{"network_rules", "tag"},
+			valid:    false,
+		},
+	}
+
+	for _, v := range testData {
+		t.Run(v.name, func(t *testing.T) {
+			err := compareDriftedAttributes(v.expected, changed)
+			if v.valid && err != nil {
+				t.Fatalf("expected no error but got %+v", err)
+			}
+			if !v.valid && err == nil {
+				t.Fatalf("expected an error but didn't get one")
+			}
+		})
+	}
+}
@@ -0,0 +1,285 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package acceptance
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"testing"
+
+	"github.com/hashicorp/go-azure-sdk/sdk/environments"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov5"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/clients"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/features"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/sdk"
+)
+
+// UnknownValue is the value returned within a planned state for values which are unknown until apply
+const UnknownValue = "<unknown>"
+
+// PlanOnlyHarness runs the validation, plan (including CustomizeDiff) and state upgrade logic of a typed Resource
+// in-process and against a fake `clients.Client`, so that schema logic can be tested in seconds without credentials,
+// Azure or the Terraform CLI.
+//
+// NOTE: the fake client contains no API clients, so any logic which calls Azure must be tested using an Acceptance Test.
+type PlanOnlyHarness struct {
+	t            *testing.T
+	resourceType string
+	server       *schema.GRPCProviderServer
+	schema       *tfprotov5.Schema
+}
+
+// PlanResult is the outcome of planning a Resource using the PlanOnlyHarness
+type PlanResult struct {
+	// PlannedState is the planned state of the Resource, where any values which are unknown until apply are UnknownValue
+	PlannedState map[string]interface{}
+
+	// RequiresReplace is the paths of the attributes which require the Resource to be replaced
+	RequiresReplace []string
+
+	// Diagnostics are any errors or warnings raised whilst planning
+	Diagnostics []*tfprotov5.Diagnostic
+}
+
+// NewPlanOnlyHarness returns a PlanOnlyHarness for the typed Resource, configured using FakeClient
+func NewPlanOnlyHarness(t *testing.T, resource sdk.Resource) PlanOnlyHarness {
+	wrapper := sdk.NewResourceWrapper(resource)
+	res, err := wrapper.Resource()
+	if err != nil {
+		t.Fatalf("building Resource %q: %+v", resource.ResourceType(), err)
+	}
+
+	provider := &schema.Provider{
+		ResourcesMap: map[string]*schema.Resource{
+			resource.ResourceType(): res,
+		},
+	}
+	if err := provider.InternalValidate(); err != nil {
+		t.Fatalf("validating Resource %q: %+v", resource.ResourceType(), err)
+	}
+	provider.SetMeta(FakeClient())
+
+	server := schema.NewGRPCProviderServer(provider)
+	schemas, err := server.GetProviderSchema(context.Background(), &tfprotov5.GetProviderSchemaRequest{})
+	if err != nil {
+		t.Fatalf("retrieving the schema for %q: %+v", resource.ResourceType(), err)
+	}
+
+	return PlanOnlyHarness{
+		t:            t,
+		resourceType: resource.ResourceType(),
+		server:       server,
+		schema:       schemas.ResourceSchemas[resource.ResourceType()],
+	}
+}
+
+// FakeClient returns a `clients.Client` for use in unit tests, which has an Account within the Public cloud and the
+// default Features - but no API clients
+func FakeClient() *clients.Client {
+	return &clients.Client{
+		StopContext: context.Background(),
+		Account: &clients.ResourceManagerAccount{
+			Environment:    *environments.AzurePublic(),
+			ClientId:       "00000000-0000-0000-0000-000000000000",
+			ObjectId:       "00000000-0000-0000-0000-000000000000",
+			SubscriptionId: "00000000-0000-0000-0000-000000000000",
+			TenantId:       "00000000-0000-0000-0000-000000000000",
+		},
+		Features: features.Default(),
+	}
+}
+
+// Validate validates the configuration for the Resource, returning any Diagnostics
+func (h PlanOnlyHarness) Validate(config map[string]interface{}) []*tfprotov5.Diagnostic {
+	resp, err := h.server.ValidateResourceTypeConfig(context.Background(), &tfprotov5.ValidateResourceTypeConfigRequest{
+		TypeName: h.resourceType,
+		Config:   h.dynamicValue(config),
+	})
+	if err != nil {
+		h.t.Fatalf("validating the configuration for %q: %+v", h.resourceType, err)
+	}
+	return resp.Diagnostics
+}
+
+// Plan plans the Resource using the configuration, creating the Resource when priorState is nil
+func (h PlanOnlyHarness) Plan(priorState map[string]interface{}, config map[string]interface{}) PlanResult {
+	// mirror Terraform Core, which proposes retaining the prior value of Computed attributes which aren't configured
+	proposed := make(map[string]interface{}, len(config))
+	for k, v := range config {
+		proposed[k] = v
+	}
+	for _, attribute := range h.schema.Block.Attributes {
+		if _, ok := proposed[attribute.Name]; !ok && attribute.Computed {
+			proposed[attribute.Name] = priorState[attribute.Name]
+		}
+	}
+
+	prior := h.nullValue()
+	if priorState != nil {
+		prior = h.dynamicValue(priorState)
+	}
+
+	resp, err := h.server.PlanResourceChange(context.Background(), &tfprotov5.PlanResourceChangeRequest{
+		TypeName:         h.resourceType,
+		PriorState:       prior,
+		ProposedNewState: h.dynamicValue(proposed),
+		Config:           h.dynamicValue(config),
+	})
+	if err != nil {
+		h.t.Fatalf("planning %q: %+v", h.resourceType, err)
+	}
+
+	result := PlanResult{
+		Diagnostics: resp.Diagnostics,
+	}
+	for _, path := range resp.RequiresReplace {
+		result.RequiresReplace = append(result.RequiresReplace, path.String())
+	}
+	if resp.PlannedState != nil {
+		if planned, _ := h.fromDynamicValue(resp.PlannedState).(map[string]interface{}); planned != nil {
+			result.PlannedState = planned
+		}
+	}
+
+	return result
+}
+
+// UpgradeState runs the state upgraders for the Resource against rawState, which was written by the specified
+// schema version, returning the upgraded state
+func (h PlanOnlyHarness) UpgradeState(version int, rawState map[string]interface{}) (map[string]interface{}, []*tfprotov5.Diagnostic) {
+	raw, err := json.Marshal(rawState)
+	if err != nil {
+		h.t.Fatalf("marshalling the raw state: %+v", err)
+	}
+
+	resp, err := h.server.UpgradeResourceState(context.Background(), &tfprotov5.UpgradeResourceStateRequest{
+		TypeName: h.resourceType,
+		Version:  int64(version),
+		RawState: &tfprotov5.RawState{
+			JSON: raw,
+		},
+	})
+	if err != nil {
+		h.t.Fatalf("upgrading the state for %q: %+v", h.resourceType, err)
+	}
+	if resp.UpgradedState == nil {
+		return nil, resp.Diagnostics
+	}
+
+	upgraded, _ := h.fromDynamicValue(resp.UpgradedState).(map[string]interface{})
+	return upgraded, resp.Diagnostics
+}
+
+func (h PlanOnlyHarness) dynamicValue(input map[string]interface{}) *tfprotov5.DynamicValue {
+	raw, err := json.Marshal(input)
+	if err != nil {
+		h.t.Fatalf("marshalling %+v: %+v", input, err)
+	}
+
+	typ := h.schema.ValueType()
+	val, err := tftypes.ValueFromJSON(raw, typ)
+	if err != nil {
+		h.t.Fatalf("converting %s to a value matching the schema for %q: %+v", raw, h.resourceType, err)
+	}
+
+	dv, err := tfprotov5.NewDynamicValue(typ, val)
+	if err != nil {
+		h.t.Fatalf("encoding %s: %+v", raw, err)
+	}
+	return &dv
+}
+
+func (h PlanOnlyHarness) nullValue() *tfprotov5.DynamicValue {
+	typ := h.schema.ValueType()
+	dv, err := tfprotov5.NewDynamicValue(typ, tftypes.NewValue(typ, nil))
+	if err != nil {
+		h.t.Fatalf("encoding a null value: %+v", err)
+	}
+	return &dv
+}
+
+func (h PlanOnlyHarness) fromDynamicValue(input *tfprotov5.DynamicValue) interface{} {
+	val, err := input.Unmarshal(h.schema.ValueType())
+	if err != nil {
+		h.t.Fatalf("decoding the value returned for %q: %+v", h.resourceType, err)
+	}
+
+	output, err := terraformValueToInterface(val)
+	if err != nil {
+		h.t.Fatalf("converting the value returned for %q: %+v", h.resourceType, err)
+	}
+	return output
+}
+
+// terraformValueToInterface converts a tftypes.Value into the equivalent Go types (as returned by `json.Unmarshal`),
+// with whole numbers returned as an int64 and unknown values returned as UnknownValue
+func terraformValueToInterface(input tftypes.Value) (interface{}, error) {
+	if !input.IsKnown() {
+		return UnknownValue, nil
+	}
+	if input.IsNull() {
+		return nil, nil
+	}
+
+	typ := input.Type()
+	switch {
+	case typ.Is(tftypes.List{}), typ.Is(tftypes.Set{}), typ.Is(tftypes.Tuple{}):
+		var elements []tftypes.Value
+		if err := input.As(&elements); err != nil {
+			return nil, err
+		}
+		output := make([]interface{}, 0, len(elements))
+		for _, element := range elements {
+			v, err := terraformValueToInterface(element)
+			if err != nil {
+				return nil, err
+			}
+			output = append(output, v)
+		}
+		return output, nil
+
+	case typ.Is(tftypes.Map{}), typ.Is(tftypes.Object{}):
+		var elements map[string]tftypes.Value
+		if err := input.As(&elements); err != nil {
+			return nil, err
+		}
+		output := make(map[string]interface{}, len(elements))
+		for k, element := range elements {
+			v, err := terraformValueToInterface(element)
+			if err != nil {
+				return nil, err
+			}
+			output[k] = v
+		}
+		return output, nil
+
+	case typ.Is(tftypes.String):
+		var v string
+		err := input.As(&v)
+		return v, err
+
+	case typ.Is(tftypes.Bool):
+		var v bool
+		err := input.As(&v)
+		return v, err
+
+	case typ.Is(tftypes.Number):
+		v := new(big.Float)
+		if err := input.As(&v); err != nil {
+			return nil, err
+		}
+		if v.IsInt() {
+			i, _ := v.Int64()
+			return i, nil
+		}
+		f, _ := v.Float64()
+		return f, nil
+	}
+
+	return nil, fmt.Errorf("unsupported type %s", typ)
+}
@@ -0,0 +1,249 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package acceptance
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-go/tfprotov5"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/sdk"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/validation"
+)
+
+type planOnlyExampleModel struct {
+	Name     string `tfschema:"name"`
+	Sku      string `tfschema:"sku"`
+	Capacity int64  `tfschema:"capacity"`
+	Endpoint string `tfschema:"endpoint"`
+}
+
+type planOnlyExampleResource struct{}
+
+var (
+	_ sdk.ResourceWithCustomizeDiff  = planOnlyExampleResource{}
+	_ sdk.ResourceWithStateMigration = planOnlyExampleResource{}
+	_ sdk.ResourceWithUpdate         = planOnlyExampleResource{}
+)
+
+func (planOnlyExampleResource) ResourceType() string {
+	return "azurerm_plan_only_example"
+}
+
+func (planOnlyExampleResource) ModelObject() interface{} {
+	return &planOnlyExampleModel{}
+}
+
+func (planOnlyExampleResource) IDValidationFunc() pluginsdk.SchemaValidateFunc {
+	return validation.StringIsNotEmpty
+}
+
+func (planOnlyExampleResource) Arguments() map[string]*pluginsdk.Schema {
+	return map[string]*pluginsdk.Schema{
+		"name": {
+			Type:         pluginsdk.TypeString,
+			Required:     true,
+			ForceNew:     true,
+			ValidateFunc: validation.StringLenBetween(3, 24),
+		},
+
+		"sku": {
+			Type:         pluginsdk.TypeString,
+			Optional:     true,
+			Default:      "Standard",
+			ValidateFunc: validation.StringInSlice([]string{"Standard", "Premium"}, false),
+		},
+
+		"capacity": {
+			Type:     pluginsdk.TypeInt,
+			Optional: true,
+		},
+	}
+}
+
+func (planOnlyExampleResource) Attributes() map[string]*pluginsdk.Schema {
+	return map[string]*pluginsdk.Schema{
+		"endpoint": {
+			Type:     pluginsdk.TypeString,
+			Computed: true,
+		},
+	}
+}
+
+func (planOnlyExampleResource) CustomizeDiff() sdk.ResourceFunc {
+	return sdk.ResourceFunc{
+		Timeout: 5 * time.Minute,
+		Func: func(ctx context.Context, metadata sdk.ResourceMetaData) error {
+			var model planOnlyExampleModel
+			if err := metadata.DecodeDiff(&model); err != nil {
+				return err
+			}
+
+			if model.Sku == "Premium" && model.Capacity == 0 {
+				return fmt.Errorf("`capacity` must be specified when `sku` is `Premium`")
+			}
+
+			return nil
+		},
+	}
+}
+
+func (planOnlyExampleResource) StateUpgraders() sdk.StateUpgradeData {
+	return sdk.StateUpgradeData{
+		SchemaVersion: 1,
+		Upgraders: map[int]pluginsdk.StateUpgrade{
+			0: planOnlyExampleV0ToV1{},
+		},
+	}
+}
+
+func (planOnlyExampleResource) Create() sdk.ResourceFunc {
+	return sdk.ResourceFunc{
+		Timeout: 5 * time.Minute,
+		Func: func(ctx context.Context, metadata sdk.ResourceMetaData) error {
+			return fmt.Errorf("not expected to be called")
+		},
+	}
+}
+
+func (planOnlyExampleResource) Read() sdk.ResourceFunc {
+	return planOnlyExampleResource{}.Create()
+}
+
+func (planOnlyExampleResource) Update() sdk.ResourceFunc {
+	return planOnlyExampleResource{}.Create()
+}
+
+func (planOnlyExampleResource) Delete() sdk.ResourceFunc {
+	return planOnlyExampleResource{}.Create()
+}
+
+type planOnlyExampleV0ToV1 struct{}
+
+func (planOnlyExampleV0ToV1) Schema() map[string]*pluginsdk.Schema {
+	return map[string]*pluginsdk.Schema{
+		"name": {
+			Type:     pluginsdk.TypeString,
+			Required: true,
+			ForceNew: true,
+		},
+		"sku_name": {
+			Type:     pluginsdk.TypeString,
+			Optional: true,
+		},
+		"capacity": {
+			Type:     pluginsdk.TypeInt,
+			Optional: true,
+		},
+		"endpoint": {
+			Type:     pluginsdk.TypeString,
+			Computed: true,
+		},
+	}
+}
+
+func (planOnlyExampleV0ToV1) UpgradeFunc() pluginsdk.StateUpgraderFunc {
+	return func(ctx context.Context, rawState map[string]interface{}, meta interface{}) (map[string]interface{}, error) {
+		rawState["sku"] = rawState["sku_name"]
+		delete(rawState, "sku_name")
+		return rawState, nil
+	}
+}
+
+func TestPlanOnlyHarnessValidate(t *testing.T) {
+	harness := NewPlanOnlyHarness(t, planOnlyExampleResource{})
+
+	if diags := harness.Validate(map[string]interface{}{"name": "example"}); hasPlanOnlyErrors(diags) {
+		t.Fatalf("expected no errors but got %s", planOnlyDiagnosticsString(diags))
+	}
+
+	diags := harness.Validate(map[string]interface{}{"name": "ex", "sku": "Basic"})
+	for _, expected := range []string{"name", "sku"} {
+		if !strings.Contains(planOnlyDiagnosticsString(diags), expected) {
+			t.Fatalf("expected an error for %q but got %s", expected, planOnlyDiagnosticsString(diags))
+		}
+	}
+}
+
+func TestPlanOnlyHarnessPlan(t *testing.T) {
+	harness := NewPlanOnlyHarness(t, planOnlyExampleResource{})
+
+	created := harness.Plan(nil, map[string]interface{}{"name": "example"})
+	if hasPlanOnlyErrors(created.Diagnostics) {
+		t.Fatalf("expected no errors but got %s", planOnlyDiagnosticsString(created.Diagnostics))
+	}
+	if v := created.PlannedState["sku"]; v != "Standard" {
+		t.Fatalf("expected the default `sku` to be planned but got %+v", v)
+	}
+	if v := created.PlannedState["endpoint"]; v != UnknownValue {
+		t.Fatalf("expected `endpoint` to be unknown but got %+v", v)
+	}
+
+	// the CustomizeDiff should reject this
+	invalid := harness.Plan(nil, map[string]interface{}{"name": "example", "sku": "Premium"})
+	if !strings.Contains(planOnlyDiagnosticsString(invalid.Diagnostics), "`capacity` must be specified") {
+		t.Fatalf("expected the CustomizeDiff to return an error but got %s", planOnlyDiagnosticsString(invalid.Diagnostics))
+	}
+
+	priorState := map[string]interface{}{
+		"id":       "example",
+		"name":     "example",
+		"sku":      "Standard",
+		"capacity": 0,
+		"endpoint": "https://example.com",
+		"timeouts": nil,
+	}
+	updated := harness.Plan(priorState, map[string]interface{}{"name": "renamed", "sku": "Premium", "capacity": 2})
+	if hasPlanOnlyErrors(updated.Diagnostics) {
+		t.Fatalf("expected no errors but got %s", planOnlyDiagnosticsString(updated.Diagnostics))
+	}
+	if !strings.Contains(strings.Join(updated.RequiresReplace, ","), `AttributeName("name")`) {
+		t.Fatalf("expected `name` to require replacement but got %+v", updated.RequiresReplace)
+	}
+	if v := updated.PlannedState["capacity"]; v != int64(2) {
+		t.Fatalf("expected `capacity` to be planned as 2 but got %+v", v)
+	}
+}
+
+func TestPlanOnlyHarnessUpgradeState(t *testing.T) {
+	harness := NewPlanOnlyHarness(t, planOnlyExampleResource{})
+
+	upgraded, diags := harness.UpgradeState(0, map[string]interface{}{
+		"id":       "example",
+		"name":     "example",
+		"sku_name": "Premium",
+		"capacity": 2,
+		"endpoint": "https://example.com",
+	})
+	if hasPlanOnlyErrors(diags) {
+		t.Fatalf("expected no errors but got %s", planOnlyDiagnosticsString(diags))
+	}
+	if v := upgraded["sku"]; v != "Premium" {
+		t.Fatalf("expected `sku_name` to be upgraded to `sku` but got %+v", upgraded)
+	}
+	if _, ok := upgraded["sku_name"]; ok {
+		t.Fatalf("expected `sku_name` to be removed but got %+v", upgraded)
+	}
+}
+
+func hasPlanOnlyErrors(diags []*tfprotov5.Diagnostic) bool {
+	for _, diag := range diags {
+		if diag.Severity == tfprotov5.DiagnosticSeverityError {
+			return true
+		}
+	}
+	return false
+}
+
+func planOnlyDiagnosticsString(diags []*tfprotov5.Diagnostic) string {
+	out := make([]string, 0, len(diags))
+	for _, diag := range diags {
+		out = append(out, fmt.Sprintf("%s: %s (%s)", diag.Attribute, diag.Summary, diag.Detail))
+	}
+	return strings.Join(out, "\n")
+}
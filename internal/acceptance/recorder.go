@@ -0,0 +1,197 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package acceptance
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/hashicorp/terraform-provider-azurerm/internal/acceptance/testclient"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/common"
+)
+
+// The Acceptance Tests can be run in one of two additional modes, controlled by the `ARM_TEST_RECORDER_MODE`
+// environment variable:
+//
+//   - `record` - the tests run against Azure as usual, with the sanitized requests/responses for each test
+//     being written into a cassette at `{ARM_TEST_CASSETTE_DIR}/{TestName}.json`
+//   - `replay` - the tests run against the recorded cassettes, without requiring an Azure Subscription
+//
+// `ARM_TEST_CASSETTE_DIR` defaults to `testdata/recordings` within the package containing the tests.
+const (
+	recorderModeEnvVar        = "ARM_TEST_RECORDER_MODE"
+	recorderCassetteDirEnvVar = "ARM_TEST_CASSETTE_DIR"
+)
+
+type testRecorder struct {
+	recorder *common.Recorder
+
+	// testDataCount is the number of times BuildTestData has been called within this test, so that each
+	// TestData in a test is given distinct values
+	testDataCount int
+}
+
+var (
+	testRecorders     = map[string]*testRecorder{}
+	testRecordersLock = &sync.Mutex{}
+
+	testClientRecorderOnce = &sync.Once{}
+)
+
+// recorderMode returns the mode the recorder should run in, or nil when the recorder is disabled
+func recorderMode() *common.RecorderMode {
+	value := strings.TrimSpace(os.Getenv(recorderModeEnvVar))
+	if value == "" {
+		return nil
+	}
+
+	mode := common.RecorderMode(strings.ToLower(value))
+	return &mode
+}
+
+func cassettePath(t *testing.T) string {
+	dir := os.Getenv(recorderCassetteDirEnvVar)
+	if dir == "" {
+		dir = filepath.Join("testdata", "recordings")
+	}
+
+	name := strings.NewReplacer("/", "_", " ", "_").Replace(t.Name())
+	return filepath.Join(dir, fmt.Sprintf("%s.json", name))
+}
+
+// recorderForTest returns the Recorder for the current test, or nil when the recorder is disabled
+func recorderForTest(t *testing.T) *testRecorder {
+	mode := recorderMode()
+	if mode == nil {
+		return nil
+	}
+
+	testRecordersLock.Lock()
+	defer testRecordersLock.Unlock()
+
+	if existing, ok := testRecorders[t.Name()]; ok {
+		return existing
+	}
+
+	path := cassettePath(t)
+	if *mode == common.RecorderModeReplay {
+		if _, err := os.Stat(path); os.IsNotExist(err) {
+			t.Skipf("Skipping since no cassette has been recorded at %q", path)
+		}
+	}
+
+	recorder, err := common.NewRecorder(*mode, path)
+	if err != nil {
+		t.Fatalf("building recorder: %+v", err)
+	}
+
+	testClientRecorderOnce.Do(func() {
+		testclient.UseRecorder(common.NewRoutingRecorder(*mode, routeTestClientRequest))
+	})
+
+	item := &testRecorder{
+		recorder: recorder,
+	}
+	testRecorders[t.Name()] = item
+
+	t.Cleanup(func() {
+		testRecordersLock.Lock()
+		delete(testRecorders, t.Name())
+		testRecordersLock.Unlock()
+
+		if err := recorder.Close(); err != nil {
+			t.Errorf("closing recorder: %+v", err)
+		}
+
+		if t.Failed() && *mode == common.RecorderModeRecord {
+			t.Logf("Not writing cassette %q since the test failed", path)
+			return
+		}
+		if err := recorder.Save(); err != nil {
+			t.Errorf("saving cassette: %+v", err)
+		}
+	})
+
+	return item
+}
+
+// routeTestClientRequest determines which test a request from the shared test client belongs to, since
+// the test client is used for checks (e.g. CheckDestroy) which aren't aware of the test they're running in.
+// This is the test whose recorded interactions most closely match the URL being requested - which works
+// since each test uses unique random names for the resources it provisions.
+func routeTestClientRequest(request *http.Request) *common.Recorder {
+	testRecordersLock.Lock()
+	defer testRecordersLock.Unlock()
+
+	var target *common.Recorder
+	longest := -1
+	for _, item := range testRecorders {
+		if affinity := item.recorder.Affinity(request); affinity > longest {
+			target = item.recorder
+			longest = affinity
+		}
+	}
+
+	return target
+}
+
+// applyRecordedTestData ensures the random values and locations used in the TestData are consistent
+// between recording and replaying a test
+func applyRecordedTestData(t *testing.T, data *TestData) {
+	item := recorderForTest(t)
+	if item == nil {
+		return
+	}
+
+	testRecordersLock.Lock()
+	prefix := fmt.Sprintf("test_data_%d", item.testDataCount)
+	item.testDataCount++
+	testRecordersLock.Unlock()
+
+	recorder := item.recorder
+	values := map[string]*string{
+		"random_string":      &data.RandomString,
+		"location_primary":   &data.Locations.Primary,
+		"location_secondary": &data.Locations.Secondary,
+		"location_ternary":   &data.Locations.Ternary,
+	}
+	randomInteger := strconv.Itoa(data.RandomInteger)
+	values["random_integer"] = &randomInteger
+
+	for name, value := range values {
+		key := fmt.Sprintf("%s.%s", prefix, name)
+		if recorder.Mode() == common.RecorderModeRecord {
+			recorder.SetVariable(key, *value)
+			continue
+		}
+
+		recorded, ok := recorder.Variable(key)
+		if !ok {
+			t.Fatalf("the cassette for this test doesn't contain the variable %q - re-record the cassette", key)
+		}
+		*value = recorded
+	}
+
+	v, err := strconv.Atoi(randomInteger)
+	if err != nil {
+		t.Fatalf("parsing recorded random integer %q: %+v", randomInteger, err)
+	}
+	data.RandomInteger = v
+}
+
+// contextForTest returns a context which carries the Recorder for the current test, when enabled
+func contextForTest(t *testing.T) context.Context {
+	ctx := context.Background()
+	if item := recorderForTest(t); item != nil {
+		ctx = common.ContextWithRecorder(ctx, item.recorder)
+	}
+	return ctx
+}
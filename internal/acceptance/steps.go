@@ -9,6 +9,7 @@ import (
 	"strings"
 
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
 	"github.com/hashicorp/terraform-plugin-testing/terraform"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/acceptance/check"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/acceptance/helpers"
@@ -65,6 +66,51 @@ func (td TestData) DisappearsStep(data DisappearsStepData) resource.TestStep {
 	}
 }
 
+type DriftStepData struct {
+	// Config is a function which returns the Terraform Configuration which should be used for this step
+	Config func(data TestData) string
+
+	// Mutation changes the resource outside of Terraform, for example by updating the tags or a property using the SDK
+	Mutation ClientCheckFunc
+
+	// ExpectedChanges are the attributes which the subsequent plan is expected to change, and must be exhaustive.
+	// Nested attributes are specified using their path (e.g. `network_rules.0.default_action`) and an attribute
+	// matches any changes nested within it (e.g. `tags` matches a change to `tags.env`). Values which are unknown
+	// until apply are ignored.
+	ExpectedChanges []string
+
+	// ExpectedAction (optional) is the action the subsequent plan is expected to take, defaults to an Update
+	ExpectedAction plancheck.ResourceActionType
+}
+
+// DriftStep returns a Test Step which applies the Configuration, changes the resource outside of Terraform using the
+// Mutation and then expects that the plan at the end of this step detects the change - and shows exactly the expected
+// attribute changes (since the Read function should have noticed the drift)
+func (td TestData) DriftStep(data DriftStepData) resource.TestStep {
+	action := data.ExpectedAction
+	if action == "" {
+		action = plancheck.ResourceActionUpdate
+	}
+
+	return resource.TestStep{
+		Config: data.Config(td),
+		Check: resource.ComposeTestCheckFunc(
+			td.CheckWithClient(data.Mutation),
+		),
+		ConfigPlanChecks: resource.ConfigPlanChecks{
+			PostApplyPostRefresh: []plancheck.PlanCheck{
+				plancheck.ExpectNonEmptyPlan(),
+				plancheck.ExpectResourceAction(td.ResourceName, action),
+				expectDriftedAttributes{
+					resourceAddress: td.ResourceName,
+					attributes:      data.ExpectedChanges,
+				},
+			},
+		},
+		ExpectNonEmptyPlan: true,
+	}
+}
+
 type ClientCheckFunc func(ctx context.Context, clients *clients.Client, state *terraform.InstanceState) error
 
 // CheckWithClient returns a TestCheckFunc which will call a ClientCheckFunc
@@ -4,7 +4,6 @@
 package acceptance
 
 import (
-	"context"
 	"fmt"
 	"testing"
 
@@ -168,14 +167,14 @@ func RunTestsInSequence(t *testing.T, tests map[string]map[string]func(t *testin
 
 func (td TestData) runAcceptanceTest(t *testing.T, testCase resource.TestCase) {
 	testCase.ExternalProviders = td.externalProviders()
-	testCase.ProtoV5ProviderFactories = framework.ProtoV5ProviderFactoriesInit(context.Background(), "azurerm", "azurerm-alt")
+	testCase.ProtoV5ProviderFactories = framework.ProtoV5ProviderFactoriesInit(contextForTest(t), "azurerm", "azurerm-alt")
 
 	resource.ParallelTest(t, testCase)
 }
 
 func (td TestData) runAcceptanceSequentialTest(t *testing.T, testCase resource.TestCase) {
 	testCase.ExternalProviders = td.externalProviders()
-	testCase.ProtoV5ProviderFactories = framework.ProtoV5ProviderFactoriesInit(context.Background(), "azurerm")
+	testCase.ProtoV5ProviderFactories = framework.ProtoV5ProviderFactoriesInit(contextForTest(t), "azurerm")
 
 	resource.Test(t, testCase)
 }
@@ -12,14 +12,25 @@ import (
 	"github.com/hashicorp/go-azure-sdk/sdk/auth"
 	"github.com/hashicorp/go-azure-sdk/sdk/environments"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/clients"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/common"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/features"
 )
 
 var (
 	_client    *clients.Client
+	_recorder  *common.Recorder
 	clientLock = &sync.Mutex{}
 )
 
+// UseRecorder configures the test client to record/replay its requests using the specified Recorder
+func UseRecorder(recorder *common.Recorder) {
+	clientLock.Lock()
+	defer clientLock.Unlock()
+
+	_recorder = recorder
+	_client = nil
+}
+
 func Build() (*clients.Client, error) {
 	clientLock.Lock()
 	defer clientLock.Unlock()
@@ -70,6 +81,7 @@ func Build() (*clients.Client, error) {
 			Features:          features.Default(),
 			StorageUseAzureAD: false,
 			SubscriptionID:    os.Getenv("ARM_SUBSCRIPTION_ID"),
+			Recorder:          _recorder,
 		}
 
 		client, err := clients.Build(ctx, clientBuilder)
@@ -13,9 +13,15 @@ import (
 	"github.com/hashicorp/go-azure-sdk/sdk/auth"
 	"github.com/hashicorp/go-azure-sdk/sdk/environments"
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/common"
 )
 
 func PreCheck(t *testing.T) {
+	if mode := recorderMode(); mode != nil && *mode == common.RecorderModeReplay {
+		// the credentials and locations aren't required when replaying, since these come from the cassette
+		return
+	}
+
 	variables := []string{
 		"ARM_CLIENT_ID",
 		"ARM_CLIENT_SECRET",
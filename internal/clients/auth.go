@@ -16,6 +16,7 @@ import (
 	"github.com/hashicorp/go-azure-sdk/sdk/environments"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/clients/graph"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/resourceproviders"
+	"golang.org/x/oauth2"
 )
 
 type ResourceManagerAccount struct {
@@ -36,6 +37,10 @@ func NewResourceManagerAccount(ctx context.Context, config auth.Credentials, sub
 		return nil, fmt.Errorf("unable to build authorizer for Microsoft Graph API: %+v", err)
 	}
 
+	return newResourceManagerAccountFromAuthorizer(ctx, config, authorizer, subscriptionId, registeredResourceProviders)
+}
+
+func newResourceManagerAccountFromAuthorizer(ctx context.Context, config auth.Credentials, authorizer auth.Authorizer, subscriptionId string, registeredResourceProviders resourceproviders.ResourceProviders) (*ResourceManagerAccount, error) {
 	// Acquire an access token so we can inspect the claims
 	token, err := authorizer.Token(ctx, &http.Request{})
 	if err != nil {
@@ -129,3 +134,29 @@ func NewResourceManagerAccount(ctx context.Context, config auth.Credentials, sub
 
 	return &account, nil
 }
+
+// AccessToken acquires an access token for the specified scope (e.g. `https://management.azure.com/.default`)
+// using the credentials which the Provider has been configured with
+func (client *Client) AccessToken(ctx context.Context, scope string) (*oauth2.Token, error) {
+	if client.authorizers == nil || client.authorizers.AuthorizerFunc == nil {
+		return nil, errors.New("the Provider has not been configured with any credentials")
+	}
+
+	resource := strings.TrimSuffix(scope, "/.default")
+	if resource == "" {
+		return nil, fmt.Errorf("unable to determine the resource for the scope %q", scope)
+	}
+
+	api := environments.NewApiEndpoint("AccessToken", resource, nil).WithResourceIdentifier(resource)
+	authorizer, err := client.authorizers.AuthorizerFunc(api)
+	if err != nil {
+		return nil, fmt.Errorf("building authorizer for %q: %+v", scope, err)
+	}
+
+	token, err := authorizer.Token(ctx, &http.Request{})
+	if err != nil {
+		return nil, fmt.Errorf("acquiring access token for %q: %+v", scope, err)
+	}
+
+	return token, nil
+}
@@ -27,12 +27,17 @@ type ClientBuilder struct {
 	CustomCorrelationRequestID  string
 	DisableCorrelationRequestID bool
 	DisableTerraformPartnerID   bool
+	LogRedactionPatterns        []string
+	MaxConcurrentRequests       int
 	MetadataHost                string
 	PartnerID                   string
 	RegisteredResourceProviders resourceproviders.ResourceProviders
 	StorageUseAzureAD           bool
 	SubscriptionID              string
 	TerraformVersion            string
+
+	// Recorder (optional) records or replays the requests made by the clients, used for acceptance testing
+	Recorder *common.Recorder
 }
 
 const azureStackEnvironmentError = `
@@ -52,25 +57,34 @@ func Build(ctx context.Context, builder ClientBuilder) (*Client, error) {
 		return nil, errors.New(azureStackEnvironmentError)
 	}
 
+	replaying := builder.Recorder != nil && builder.Recorder.Mode() == common.RecorderModeReplay
+	newAuthorizer := func(api environments.Api) (auth.Authorizer, error) {
+		if replaying {
+			// there's no need to authenticate with Azure when replaying
+			return builder.Recorder.Authorizer(), nil
+		}
+		return auth.NewAuthorizerFromCredentials(ctx, *builder.AuthConfig, api)
+	}
+
 	var resourceManagerAuth, storageAuth, synapseAuth, batchManagementAuth, keyVaultAuth auth.Authorizer
 
-	resourceManagerAuth, err = auth.NewAuthorizerFromCredentials(ctx, *builder.AuthConfig, builder.AuthConfig.Environment.ResourceManager)
+	resourceManagerAuth, err = newAuthorizer(builder.AuthConfig.Environment.ResourceManager)
 	if err != nil {
 		return nil, fmt.Errorf("unable to build authorizer for Resource Manager API: %+v", err)
 	}
 
-	storageAuth, err = auth.NewAuthorizerFromCredentials(ctx, *builder.AuthConfig, builder.AuthConfig.Environment.Storage)
+	storageAuth, err = newAuthorizer(builder.AuthConfig.Environment.Storage)
 	if err != nil {
 		return nil, fmt.Errorf("unable to build authorizer for Storage API: %+v", err)
 	}
 
-	keyVaultAuth, err = auth.NewAuthorizerFromCredentials(ctx, *builder.AuthConfig, builder.AuthConfig.Environment.KeyVault)
+	keyVaultAuth, err = newAuthorizer(builder.AuthConfig.Environment.KeyVault)
 	if err != nil {
 		return nil, fmt.Errorf("unable to build authorizer for Key Vault API: %+v", err)
 	}
 
 	if builder.AuthConfig.Environment.Synapse.Available() {
-		synapseAuth, err = auth.NewAuthorizerFromCredentials(ctx, *builder.AuthConfig, builder.AuthConfig.Environment.Synapse)
+		synapseAuth, err = newAuthorizer(builder.AuthConfig.Environment.Synapse)
 		if err != nil {
 			return nil, fmt.Errorf("unable to build authorizer for Synapse API: %+v", err)
 		}
@@ -79,7 +93,7 @@ func Build(ctx context.Context, builder ClientBuilder) (*Client, error) {
 	}
 
 	if builder.AuthConfig.Environment.Batch.Available() {
-		batchManagementAuth, err = auth.NewAuthorizerFromCredentials(ctx, *builder.AuthConfig, builder.AuthConfig.Environment.Batch)
+		batchManagementAuth, err = newAuthorizer(builder.AuthConfig.Environment.Batch)
 		if err != nil {
 			return nil, fmt.Errorf("unable to build authorizer for Batch Management API: %+v", err)
 		}
@@ -89,7 +103,7 @@ func Build(ctx context.Context, builder ClientBuilder) (*Client, error) {
 
 	// Helper for obtaining endpoint-specific tokens
 	authorizerFunc := common.ApiAuthorizerFunc(func(api environments.Api) (auth.Authorizer, error) {
-		authorizer, err := auth.NewAuthorizerFromCredentials(ctx, *builder.AuthConfig, api)
+		authorizer, err := newAuthorizer(api)
 		if err != nil {
 			return nil, fmt.Errorf("building custom authorizer for API %q: %+v", api.Name(), err)
 		}
@@ -97,14 +111,27 @@ func Build(ctx context.Context, builder ClientBuilder) (*Client, error) {
 		return authorizer, nil
 	})
 
-	account, err := NewResourceManagerAccount(ctx, *builder.AuthConfig, builder.SubscriptionID, builder.RegisteredResourceProviders)
+	var account *ResourceManagerAccount
+	if replaying {
+		subscriptionId := builder.SubscriptionID
+		if subscriptionId == "" {
+			subscriptionId = common.RecorderSubscriptionId()
+		}
+		account, err = newResourceManagerAccountFromAuthorizer(ctx, *builder.AuthConfig, builder.Recorder.Authorizer(), subscriptionId, builder.RegisteredResourceProviders)
+	} else {
+		account, err = NewResourceManagerAccount(ctx, *builder.AuthConfig, builder.SubscriptionID, builder.RegisteredResourceProviders)
+	}
 	if err != nil {
 		return nil, fmt.Errorf("building account: %+v", err)
 	}
 
+	if builder.Recorder != nil {
+		builder.Recorder.UseAccount(account.SubscriptionId, account.TenantId, account.ClientId, account.ObjectId)
+	}
+
 	var managedHSMAuth auth.Authorizer
 	if builder.AuthConfig.Environment.ManagedHSM.Available() {
-		managedHSMAuth, err = auth.NewAuthorizerFromCredentials(ctx, *builder.AuthConfig, builder.AuthConfig.Environment.ManagedHSM)
+		managedHSMAuth, err = newAuthorizer(builder.AuthConfig.Environment.ManagedHSM)
 		if err != nil {
 			return nil, fmt.Errorf("unable to build authorizer for Managed HSM API: %+v", err)
 		}
@@ -117,6 +144,11 @@ func Build(ctx context.Context, builder ClientBuilder) (*Client, error) {
 		return nil, errors.New("unable to determine resource manager endpoint for the current environment")
 	}
 
+	logRedactor, err := common.NewLogRedactor(builder.LogRedactionPatterns)
+	if err != nil {
+		return nil, fmt.Errorf("building Log Redactor: %+v", err)
+	}
+
 	client := Client{
 		Account: account,
 	}
@@ -154,6 +186,10 @@ func Build(ctx context.Context, builder ClientBuilder) (*Client, error) {
 		StorageUseAzureAD:           builder.StorageUseAzureAD,
 
 		ResourceManagerEndpoint: *resourceManagerEndpoint,
+
+		LogRedactor: logRedactor,
+		RateLimiter: common.SharedRateLimiter(*resourceManagerEndpoint, account.TenantId, builder.MaxConcurrentRequests),
+		Recorder:    builder.Recorder,
 	}
 
 	if err := client.Build(ctx, o); err != nil {
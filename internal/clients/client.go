@@ -6,6 +6,7 @@ package clients
 import (
 	"context"
 	"fmt"
+	"net/http"
 
 	"github.com/Azure/go-autorest/autorest"
 	"github.com/Azure/go-autorest/autorest/validation"
@@ -159,6 +160,12 @@ type Client struct {
 	Account  *ResourceManagerAccount
 	Features features.UserFeatures
 
+	// authorizers are retained so that access tokens can be acquired for arbitrary scopes
+	authorizers *common.Authorizers
+
+	// httpClient is used for the data-plane APIs which don't have a dedicated client
+	httpClient *http.Client
+
 	AadB2c                            *aadb2c_v2021_04_01_preview.Client
 	Advisor                           *advisor.Client
 	AnalysisServices                  *analysisservices_v2017_08_01.Client
@@ -298,6 +305,8 @@ func (client *Client) Build(ctx context.Context, o *common.ClientOptions) error
 
 	client.Features = o.Features
 	client.StopContext = ctx
+	client.authorizers = o.Authorizers
+	client.httpClient = o.HttpClient()
 
 	var err error
 
@@ -674,3 +683,14 @@ func (client *Client) Build(ctx context.Context, o *common.ClientOptions) error
 
 	return nil
 }
+
+// HttpClient returns an *http.Client for the data-plane APIs which don't have a dedicated client, which logs, records,
+// rate limits and traces the requests in the same way as the other clients. Any authentication is the responsibility
+// of the caller.
+func (client *Client) HttpClient() *http.Client {
+	if client.httpClient == nil {
+		return &http.Client{}
+	}
+
+	return client.httpClient
+}
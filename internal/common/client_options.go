@@ -5,11 +5,11 @@ package common
 
 import (
 	"fmt"
+	"net/http"
 	"os"
 	"strings"
 
 	"github.com/Azure/go-autorest/autorest"
-	"github.com/hashicorp/go-azure-helpers/sender"
 	"github.com/hashicorp/go-azure-sdk/sdk/auth"
 	"github.com/hashicorp/go-azure-sdk/sdk/client"
 	"github.com/hashicorp/go-azure-sdk/sdk/environments"
@@ -50,6 +50,16 @@ type ClientOptions struct {
 
 	ResourceManagerEndpoint string
 
+	// LogRedactor (optional) redacts sensitive values from the requests and responses which are logged,
+	// the built-in rules are used when this is nil
+	LogRedactor *LogRedactor
+
+	// RateLimiter (optional) paces the requests made by the clients, and is shared between all clients
+	RateLimiter *RateLimiter
+
+	// Recorder (optional) records or replays the requests made by the clients, used for acceptance testing
+	Recorder *Recorder
+
 	// Legacy authorizers for go-autorest
 	BatchManagementAuthorizer autorest.Authorizer
 	KeyVaultAuthorizer        autorest.Authorizer
@@ -74,8 +84,26 @@ func (o ClientOptions) Configure(c client.BaseClient, authorizer auth.Authorizer
 		c.AppendRequestMiddleware(correlationRequestIDMiddleware(id))
 	}
 
-	c.AppendRequestMiddleware(requestLoggerMiddleware("AzureRM"))
-	c.AppendResponseMiddleware(responseLoggerMiddleware("AzureRM"))
+	// the response middleware releases the slot held by the request, so this must run before any which can fail
+	if o.RateLimiter != nil {
+		c.AppendResponseMiddleware(rateLimiterResponseMiddleware(o.RateLimiter))
+	}
+
+	c.AppendRequestMiddleware(tracingRequestMiddleware())
+	c.AppendResponseMiddleware(tracingResponseMiddleware())
+
+	c.AppendRequestMiddleware(requestLoggerMiddleware("AzureRM", o.logRedactor()))
+	c.AppendResponseMiddleware(responseLoggerMiddleware("AzureRM", o.logRedactor()))
+
+	if o.Recorder != nil {
+		c.AppendRequestMiddleware(recorderRequestMiddleware(o.Recorder))
+		c.AppendResponseMiddleware(recorderResponseMiddleware(o.Recorder))
+	}
+
+	// the slot is acquired last, so that no subsequent request middleware can fail whilst it's held
+	if o.RateLimiter != nil {
+		c.AppendRequestMiddleware(rateLimiterRequestMiddleware(o.RateLimiter))
+	}
 }
 
 // ConfigureClient sets up an autorest.Client using an autorest.Authorizer
@@ -83,7 +111,10 @@ func (o ClientOptions) ConfigureClient(c *autorest.Client, authorizer autorest.A
 	c.UserAgent = userAgent(c.UserAgent, o.TerraformVersion, o.PartnerId, o.DisableTerraformPartnerID)
 
 	c.Authorizer = authorizer
-	c.Sender = sender.BuildSender("AzureRM")
+	c.Sender = buildSender("AzureRM", o.logRedactor(), o.transport())
+	if o.Recorder != nil {
+		c.Sender = o.Recorder.Sender(c.Sender)
+	}
 	c.SkipResourceProviderRegistration = o.SkipProviderReg
 	if !o.DisableCorrelationRequestID {
 		id := o.CustomCorrelationRequestID
@@ -94,6 +125,69 @@ func (o ClientOptions) ConfigureClient(c *autorest.Client, authorizer autorest.A
 	}
 }
 
+func (o ClientOptions) logRedactor() *LogRedactor {
+	if o.LogRedactor != nil {
+		return o.LogRedactor
+	}
+	return &LogRedactor{}
+}
+
+// transport returns the http.RoundTripper used by the go-autorest clients, which is wrapped so that each attempt
+// at sending a request (including retries) is rate limited and traced
+func (o ClientOptions) transport() http.RoundTripper {
+	var transport http.RoundTripper = &http.Transport{
+		Proxy: http.ProxyFromEnvironment,
+	}
+	if o.RateLimiter != nil {
+		transport = o.RateLimiter.RoundTripper(transport)
+	}
+
+	return tracingRoundTripper(transport)
+}
+
+// HttpClient returns an *http.Client for the data-plane APIs which don't have a go-azure-sdk or go-autorest client, which
+// (as with those clients) logs the requests and responses, sets the User Agent and Correlation Request ID - and where
+// configured records, rate limits and traces the requests
+func (o ClientOptions) HttpClient() *http.Client {
+	sender := buildSender("AzureRM", o.logRedactor(), o.transport())
+	if o.Recorder != nil {
+		sender = o.Recorder.Sender(sender)
+	}
+
+	transport := &senderRoundTripper{
+		sender:    sender,
+		userAgent: userAgent("", o.TerraformVersion, o.PartnerId, o.DisableTerraformPartnerID),
+	}
+	if !o.DisableCorrelationRequestID {
+		transport.correlationRequestID = o.CustomCorrelationRequestID
+		if transport.correlationRequestID == "" {
+			transport.correlationRequestID = correlationRequestID()
+		}
+	}
+
+	return &http.Client{
+		Transport: transport,
+	}
+}
+
+// senderRoundTripper is an http.RoundTripper which sends requests using an autorest.Sender
+type senderRoundTripper struct {
+	sender               autorest.Sender
+	userAgent            string
+	correlationRequestID string
+}
+
+func (t *senderRoundTripper) RoundTrip(request *http.Request) (*http.Response, error) {
+	// a RoundTripper mustn't modify the request, so the headers are set on a copy
+	request = request.Clone(request.Context())
+	request.Header.Set("User-Agent", t.userAgent)
+	if t.correlationRequestID != "" {
+		request.Header.Set(HeaderCorrelationRequestID, operationCorrelationRequestID(request.Context(), t.correlationRequestID))
+	}
+
+	return t.sender.Do(request)
+}
+
 func userAgent(userAgent, tfVersion, partnerID string, disableTerraformPartnerID bool) string {
 	tfUserAgent := fmt.Sprintf("HashiCorp Terraform/%s (+https://www.terraform.io)", tfVersion)
 
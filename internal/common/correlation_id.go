@@ -4,7 +4,9 @@
 package common
 
 import (
+	"context"
 	"log"
+	"net/http"
 	"sync"
 
 	"github.com/Azure/go-autorest/autorest"
@@ -22,9 +24,21 @@ var (
 )
 
 // withCorrelationRequestID returns a PrepareDecorator that adds an HTTP extension header of
-// `x-ms-correlation-request-id` whose value is passed, undecorated UUID (e.g.,7F5A6223-F475-4A9C-B9D5-12575AA6B11B`).
+// `x-ms-correlation-request-id` whose value is passed, undecorated UUID (e.g.,7F5A6223-F475-4A9C-B9D5-12575AA6B11B`),
+// or the ID of the operation derived from it when the request is made within an operation (see WithOperationCorrelationRequestID).
 func withCorrelationRequestID(uuid string) autorest.PrepareDecorator {
-	return autorest.WithHeader(HeaderCorrelationRequestID, uuid)
+	return func(p autorest.Preparer) autorest.Preparer {
+		return autorest.PreparerFunc(func(r *http.Request) (*http.Request, error) {
+			r, err := p.Prepare(r)
+			if err == nil {
+				if r.Header == nil {
+					r.Header = make(http.Header)
+				}
+				r.Header.Set(HeaderCorrelationRequestID, operationCorrelationRequestID(r.Context(), uuid))
+			}
+			return r, err
+		})
+	}
 }
 
 // correlationRequestID generates an UUID to pass through `x-ms-correlation-request-id` header.
@@ -40,3 +54,72 @@ func correlationRequestID() string {
 
 	return msCorrelationRequestID
 }
+
+type operationCorrelationKey struct{}
+
+// operationCorrelation holds the Correlation Request ID for a single resource operation (e.g. a Create)
+type operationCorrelation struct {
+	// suffix is the unique portion of the ID, which is appended to the provider-level prefix
+	suffix string
+
+	mu sync.Mutex
+	// id is the Correlation Request ID sent with the requests made during the operation, if any
+	id string
+}
+
+// WithOperationCorrelationRequestID returns a Context within which the requests made by the clients are sent with a
+// Correlation Request ID unique to this operation, retaining the prefix of the provider-level ID so that all of the
+// requests made by the provider can still be grouped. Nested operations reuse the ID of the outer operation.
+func WithOperationCorrelationRequestID(ctx context.Context) context.Context {
+	if _, ok := ctx.Value(operationCorrelationKey{}).(*operationCorrelation); ok {
+		return ctx
+	}
+
+	id, err := uuid.GenerateUUID()
+	if err != nil {
+		log.Printf("[WARN] Failed to generate uuid for the operation Correlation Request ID: %+v", err)
+		return ctx
+	}
+
+	return context.WithValue(ctx, operationCorrelationKey{}, &operationCorrelation{
+		suffix: id[operationCorrelationPrefixLength:],
+	})
+}
+
+// OperationCorrelationRequestID returns the Correlation Request ID sent with the requests made within the operation
+// in ctx, or an empty string if no requests have been made
+func OperationCorrelationRequestID(ctx context.Context) string {
+	v, ok := ctx.Value(operationCorrelationKey{}).(*operationCorrelation)
+	if !ok {
+		return ""
+	}
+
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	return v.id
+}
+
+// operationCorrelationPrefixLength is the length of the portion of a UUID (the first two groups) which is retained
+// from the provider-level ID, e.g. `7F5A6223-F475` - the remainder of the UUID is unique to the operation
+const operationCorrelationPrefixLength = 13
+
+// operationCorrelationRequestID returns the Correlation Request ID for a request made within ctx, which is derived
+// from the provider-level ID when the request is made within an operation. IDs which aren't a UUID (for example
+// those specified using `ARM_CORRELATION_REQUEST_ID`) are returned as-is.
+func operationCorrelationRequestID(ctx context.Context, providerID string) string {
+	v, ok := ctx.Value(operationCorrelationKey{}).(*operationCorrelation)
+	if !ok {
+		return providerID
+	}
+
+	id := providerID
+	if _, err := uuid.ParseUUID(providerID); err == nil {
+		id = providerID[:operationCorrelationPrefixLength] + v.suffix
+	}
+
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	v.id = id
+
+	return id
+}
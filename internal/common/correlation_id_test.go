@@ -4,7 +4,9 @@
 package common
 
 import (
+	"context"
 	"net/http"
+	"strings"
 	"testing"
 
 	"github.com/Azure/go-autorest/autorest"
@@ -32,3 +34,62 @@ func TestWithCorrelationRequestID(t *testing.T) {
 			HeaderCorrelationRequestID, uuid, req.Header.Get(HeaderCorrelationRequestID))
 	}
 }
+
+func TestOperationCorrelationRequestID(t *testing.T) {
+	providerID := correlationRequestID()
+
+	if v := operationCorrelationRequestID(context.Background(), providerID); v != providerID {
+		t.Fatalf("expected the provider-level ID %q outside of an operation but got %q", providerID, v)
+	}
+
+	first := WithOperationCorrelationRequestID(context.Background())
+	second := WithOperationCorrelationRequestID(context.Background())
+	if v := OperationCorrelationRequestID(first); v != "" {
+		t.Fatalf("expected no ID before a request has been made but got %q", v)
+	}
+
+	firstID := operationCorrelationRequestID(first, providerID)
+	secondID := operationCorrelationRequestID(second, providerID)
+	if firstID == secondID || firstID == providerID {
+		t.Fatalf("expected unique IDs for each operation but got %q and %q", firstID, secondID)
+	}
+	for _, id := range []string{firstID, secondID} {
+		if len(id) != len(providerID) || !strings.HasPrefix(id, providerID[:operationCorrelationPrefixLength]) {
+			t.Fatalf("expected %q to retain the prefix of the provider-level ID %q", id, providerID)
+		}
+	}
+	if v := OperationCorrelationRequestID(first); v != firstID {
+		t.Fatalf("expected the ID used for the operation to be %q but got %q", firstID, v)
+	}
+
+	// nested operations use the ID of the outer operation
+	if v := operationCorrelationRequestID(WithOperationCorrelationRequestID(first), providerID); v != firstID {
+		t.Fatalf("expected the nested operation to use %q but got %q", firstID, v)
+	}
+
+	// custom IDs which aren't a UUID are used as-is
+	if v := operationCorrelationRequestID(first, "custom-id"); v != "custom-id" {
+		t.Fatalf("expected the custom ID to be used as-is but got %q", v)
+	}
+}
+
+func TestCorrelationRequestIDMiddleware(t *testing.T) {
+	providerID := correlationRequestID()
+	ctx := WithOperationCorrelationRequestID(context.Background())
+
+	request, _ := http.NewRequestWithContext(ctx, http.MethodGet, "https://management.azure.com", nil)
+	request, err := correlationRequestIDMiddleware(providerID)(request)
+	if err != nil {
+		t.Fatalf("running middleware: %+v", err)
+	}
+
+	autorestRequest, _ := http.NewRequestWithContext(ctx, http.MethodGet, "https://management.azure.com", nil)
+	autorestRequest, _ = autorest.Prepare(autorestRequest, withCorrelationRequestID(providerID))
+
+	expected := OperationCorrelationRequestID(ctx)
+	for _, r := range []*http.Request{request, autorestRequest} {
+		if v := r.Header.Get(HeaderCorrelationRequestID); v != expected || v == providerID {
+			t.Fatalf("expected the header %s to be the operation ID %q but got %q", HeaderCorrelationRequestID, expected, v)
+		}
+	}
+}
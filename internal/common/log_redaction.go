@@ -0,0 +1,230 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package common
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+)
+
+// LogRedactedValue is the value which sensitive values are replaced with when logged
+const LogRedactedValue = "[REDACTED]"
+
+var (
+	// sensitiveLogHeaders are the request and response headers whose values are redacted when logged
+	sensitiveLogHeaders = []string{
+		"Authorization",
+		"Cookie",
+		"Ocp-Apim-Subscription-Key",
+		"Proxy-Authorization",
+		"Set-Cookie",
+		"X-Functions-Key",
+		"X-Ms-Authorization-Auxiliary",
+		"X-Ms-Copy-Source-Authorization",
+		"X-Ms-Encryption-Key",
+		"X-Ms-Source-Encryption-Key",
+	}
+
+	// secretValuePaths matches the paths of requests whose JSON `value` properties contain a secret, such as the
+	// `listKeys`/`listConnectionStrings` actions in Resource Manager, or Key Vault Secrets and Key operations
+	secretValuePaths = regexp.MustCompile(`(?i)(/list[a-z]*(keys|secrets|credentials?|connectionstrings?)$|/regenerate[a-z]*keys?$|^/secrets/|^/deletedsecrets/|/(decrypt|unwrapkey)$)`)
+)
+
+// LogRedactor redacts sensitive values (such as Storage Account Keys, Connection Strings, SAS Tokens and Key Vault
+// Secret values) from the requests and responses which are written to the debug log
+type LogRedactor struct {
+	// extraPatterns matches the names of additional headers and JSON properties whose values are redacted
+	extraPatterns []*regexp.Regexp
+}
+
+// NewLogRedactor returns a LogRedactor which, in addition to the built-in rules, redacts the values of the headers
+// and JSON properties whose names match any of the regular expressions in extraPatterns
+func NewLogRedactor(extraPatterns []string) (*LogRedactor, error) {
+	redactor := &LogRedactor{}
+	for _, pattern := range extraPatterns {
+		pattern = strings.TrimSpace(pattern)
+		if pattern == "" {
+			continue
+		}
+
+		expr, err := regexp.Compile(`(?i)` + pattern)
+		if err != nil {
+			return nil, fmt.Errorf("parsing log redaction pattern %q: %+v", pattern, err)
+		}
+		redactor.extraPatterns = append(redactor.extraPatterns, expr)
+	}
+
+	return redactor, nil
+}
+
+// RedactRequest returns a copy of the request which is safe to log, leaving the original request intact
+func (r *LogRedactor) RedactRequest(request *http.Request) (*http.Request, error) {
+	body, err := readRequestBody(request)
+	if err != nil {
+		return nil, err
+	}
+
+	redacted := request.Clone(request.Context())
+	redacted.Header = r.redactHeaders(request.Header)
+	redacted.URL = redactURL(request.URL)
+
+	body = redactFormBody(request.Header, body, LogRedactedValue, func(key string) bool {
+		return isSensitiveJsonKey(key) || r.matchesExtraPattern(key)
+	})
+	body = r.redactBody(request.URL, body)
+	redacted.Body = io.NopCloser(bytes.NewReader(body))
+	redacted.ContentLength = int64(len(body))
+
+	return redacted, nil
+}
+
+// RedactResponse returns a copy of the response which is safe to log, leaving the original response intact
+func (r *LogRedactor) RedactResponse(request *http.Request, response *http.Response) (*http.Response, error) {
+	var body []byte
+	if response.Body != nil && response.Body != http.NoBody {
+		var err error
+		body, err = io.ReadAll(response.Body)
+		if err != nil {
+			return nil, fmt.Errorf("reading response body: %+v", err)
+		}
+		response.Body.Close()
+		response.Body = io.NopCloser(bytes.NewReader(body))
+	}
+
+	redacted := *response
+	redacted.Header = r.redactHeaders(response.Header)
+
+	var requestUrl *url.URL
+	if request != nil {
+		requestUrl = request.URL
+	}
+	body = r.redactBody(requestUrl, body)
+	redacted.Body = io.NopCloser(bytes.NewReader(body))
+	redacted.ContentLength = int64(len(body))
+
+	return &redacted, nil
+}
+
+func (r *LogRedactor) redactHeaders(input http.Header) http.Header {
+	output := input.Clone()
+	for name := range output {
+		if r.isSensitiveHeader(name) {
+			output.Set(name, LogRedactedValue)
+			continue
+		}
+
+		// e.g. a `Location` header containing a SAS Token
+		values := output.Values(name)
+		for i, v := range values {
+			if u, err := url.Parse(v); err == nil && u.IsAbs() {
+				values[i] = redactURL(u).String()
+			}
+		}
+	}
+	return output
+}
+
+func (r *LogRedactor) isSensitiveHeader(name string) bool {
+	for _, v := range sensitiveLogHeaders {
+		if strings.EqualFold(v, name) {
+			return true
+		}
+	}
+	return r.matchesExtraPattern(name)
+}
+
+func (r *LogRedactor) matchesExtraPattern(name string) bool {
+	for _, pattern := range r.extraPatterns {
+		if pattern.MatchString(name) {
+			return true
+		}
+	}
+	return false
+}
+
+// redactBody redacts the values of any sensitive properties within a JSON body, other bodies are returned as-is
+func (r *LogRedactor) redactBody(requestUrl *url.URL, body []byte) []byte {
+	if len(bytes.TrimSpace(body)) == 0 {
+		return body
+	}
+
+	var v interface{}
+	if err := json.Unmarshal(body, &v); err != nil {
+		return body
+	}
+
+	secretValues := requestUrl != nil && secretValuePaths.MatchString(requestUrl.Path)
+	v, changed := r.redactJson(v, secretValues)
+	if !changed {
+		return body
+	}
+
+	output, err := json.Marshal(v)
+	if err != nil {
+		return body
+	}
+	return output
+}
+
+// redactJson replaces the values of any sensitive properties within the JSON value, returning whether any were
+// redacted. When secretValues is true the `value` properties are also redacted, which is also the case within
+// a `keys` array (as returned by the `listKeys` APIs)
+func (r *LogRedactor) redactJson(input interface{}, secretValues bool) (interface{}, bool) {
+	changed := false
+
+	switch v := input.(type) {
+	case map[string]interface{}:
+		for key, val := range v {
+			sensitive := isSensitiveJsonKey(key) || r.matchesExtraPattern(key)
+			if _, isString := val.(string); isString && (sensitive || (secretValues && strings.EqualFold(key, "value"))) {
+				v[key] = LogRedactedValue
+				changed = true
+				continue
+			}
+
+			redacted, c := r.redactJson(val, secretValues || strings.EqualFold(key, "keys"))
+			v[key] = redacted
+			changed = changed || c
+		}
+		return v, changed
+
+	case []interface{}:
+		for i, val := range v {
+			redacted, c := r.redactJson(val, secretValues)
+			v[i] = redacted
+			changed = changed || c
+		}
+		return v, changed
+	}
+
+	return input, false
+}
+
+// redactURL returns a copy of the URL with the values of any sensitive query string parameters (e.g. the signature
+// of a SAS Token) redacted
+func redactURL(input *url.URL) *url.URL {
+	if input == nil {
+		return nil
+	}
+
+	output := *input
+	query := output.Query()
+	redacted := false
+	for _, name := range sensitiveQueryParameters {
+		if query.Has(name) {
+			query.Set(name, LogRedactedValue)
+			redacted = true
+		}
+	}
+	if redacted {
+		output.RawQuery = query.Encode()
+	}
+	return &output
+}
@@ -0,0 +1,211 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package common
+
+import (
+	"bytes"
+	"io"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+)
+
+func TestLogRedactorRequest(t *testing.T) {
+	redactor, err := NewLogRedactor([]string{"customToken"})
+	if err != nil {
+		t.Fatalf("building redactor: %+v", err)
+	}
+
+	body := `{"properties": {"adminPassword": "hunter2", "customToken": "abc123", "name": "example", "keyVaultSecretId": "https://example.vault.azure.net/secrets/example"}}`
+	request := httptest.NewRequest(http.MethodPut, "https://example.blob.core.windows.net/container/blob?sv=2022-11-02&sig=c2lnbmF0dXJl", strings.NewReader(body))
+	request.Header.Set("Authorization", "Bearer some-token")
+	request.Header.Set("X-Ms-Encryption-Key", "ZW5jcnlwdGlvbi1rZXk=")
+
+	redacted, err := redactor.RedactRequest(request)
+	if err != nil {
+		t.Fatalf("redacting request: %+v", err)
+	}
+
+	redactedBody, _ := io.ReadAll(redacted.Body)
+	for _, value := range []string{"hunter2", "abc123", "c2lnbmF0dXJl"} {
+		if strings.Contains(string(redactedBody), value) || strings.Contains(redacted.URL.String(), value) {
+			t.Fatalf("expected %q to be redacted from the request", value)
+		}
+	}
+	for _, value := range []string{"example", "https://example.vault.azure.net/secrets/example"} {
+		if !strings.Contains(string(redactedBody), value) {
+			t.Fatalf("expected %q to be retained in the request body %s", value, redactedBody)
+		}
+	}
+	for _, header := range []string{"Authorization", "X-Ms-Encryption-Key"} {
+		if v := redacted.Header.Get(header); v != LogRedactedValue {
+			t.Fatalf("expected the %q header to be redacted but got %q", header, v)
+		}
+	}
+
+	// the original request must be left intact so that it can be sent
+	originalBody, _ := io.ReadAll(request.Body)
+	if string(originalBody) != body {
+		t.Fatalf("expected the original request body to be unmodified but got %s", originalBody)
+	}
+	if v := request.Header.Get("Authorization"); v != "Bearer some-token" {
+		t.Fatalf("expected the original Authorization header to be unmodified but got %q", v)
+	}
+	if !strings.Contains(request.URL.RawQuery, "c2lnbmF0dXJl") {
+		t.Fatalf("expected the original URL to be unmodified but got %q", request.URL)
+	}
+}
+
+func TestLogRedactorFormRequest(t *testing.T) {
+	redactor, err := NewLogRedactor(nil)
+	if err != nil {
+		t.Fatalf("building redactor: %+v", err)
+	}
+
+	body := "grant_type=access_token&service=example.azurecr.io&access_token=hunter2"
+	request := httptest.NewRequest(http.MethodPost, "https://example.azurecr.io/oauth2/exchange", strings.NewReader(body))
+	request.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	redacted, err := redactor.RedactRequest(request)
+	if err != nil {
+		t.Fatalf("redacting request: %+v", err)
+	}
+
+	redactedBody, _ := io.ReadAll(redacted.Body)
+	if strings.Contains(string(redactedBody), "hunter2") {
+		t.Fatalf("expected the access token to be redacted from the request body %s", redactedBody)
+	}
+	if !strings.Contains(string(redactedBody), "service=example.azurecr.io") {
+		t.Fatalf("expected the service to be retained in the request body %s", redactedBody)
+	}
+
+	originalBody, _ := io.ReadAll(request.Body)
+	if string(originalBody) != body {
+		t.Fatalf("expected the original request body to be unmodified but got %s", originalBody)
+	}
+}
+
+func TestLogRedactorResponse(t *testing.T) {
+	testData := []struct {
+		name      string
+		url       string
+		body      string
+		redacted  []string
+		preserved []string
+	}{
+		{
+			name:      "listKeys",
+			url:       "https://management.azure.com/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/example/providers/Microsoft.Storage/storageAccounts/example/listKeys?api-version=2023-01-01",
+			body:      `{"keys": [{"keyName": "key1", "value": "c3RvcmFnZS1rZXk=", "permissions": "FULL"}]}`,
+			redacted:  []string{"c3RvcmFnZS1rZXk="},
+			preserved: []string{"key1", "FULL"},
+		},
+		{
+			name:      "connection strings",
+			url:       "https://management.azure.com/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/example/providers/Microsoft.DocumentDB/databaseAccounts/example/listConnectionStrings?api-version=2024-08-15",
+			body:      `{"connectionStrings": [{"connectionString": "AccountEndpoint=https://example/;AccountKey=a2V5", "description": "Primary SQL Connection String"}]}`,
+			redacted:  []string{"AccountKey=a2V5"},
+			preserved: []string{"Primary SQL Connection String"},
+		},
+		{
+			name:      "resource properties",
+			url:       "https://management.azure.com/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/example/providers/Microsoft.Web/sites/example?api-version=2023-01-01",
+			body:      `{"name": "example", "properties": {"primaryConnectionString": "Endpoint=sb://example/;SharedAccessKey=a2V5", "state": "Running"}}`,
+			redacted:  []string{"SharedAccessKey=a2V5"},
+			preserved: []string{"Running"},
+		},
+		{
+			name:      "key vault secret",
+			url:       "https://example.vault.azure.net/secrets/example/?api-version=7.4",
+			body:      `{"value": "s3cr3t-value", "id": "https://example.vault.azure.net/secrets/example/abc", "attributes": {"enabled": true}}`,
+			redacted:  []string{"s3cr3t-value"},
+			preserved: []string{"https://example.vault.azure.net/secrets/example/abc"},
+		},
+		{
+			name:      "unrelated value",
+			url:       "https://management.azure.com/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups?api-version=2020-06-01",
+			body:      `{"value": [{"name": "example"}]}`,
+			preserved: []string{`{"value": [{"name": "example"}]}`},
+		},
+	}
+
+	redactor, err := NewLogRedactor(nil)
+	if err != nil {
+		t.Fatalf("building redactor: %+v", err)
+	}
+
+	for _, v := range testData {
+		t.Run(v.name, func(t *testing.T) {
+			request := httptest.NewRequest(http.MethodPost, v.url, nil)
+			response := &http.Response{
+				StatusCode: http.StatusOK,
+				Header:     http.Header{},
+				Body:       io.NopCloser(strings.NewReader(v.body)),
+			}
+
+			redacted, err := redactor.RedactResponse(request, response)
+			if err != nil {
+				t.Fatalf("redacting response: %+v", err)
+			}
+
+			redactedBody, _ := io.ReadAll(redacted.Body)
+			for _, value := range v.redacted {
+				if strings.Contains(string(redactedBody), value) {
+					t.Fatalf("expected %q to be redacted from %s", value, redactedBody)
+				}
+			}
+			for _, value := range v.preserved {
+				if !strings.Contains(string(redactedBody), value) {
+					t.Fatalf("expected %q to be retained in %s", value, redactedBody)
+				}
+			}
+
+			originalBody, _ := io.ReadAll(response.Body)
+			if string(originalBody) != v.body {
+				t.Fatalf("expected the original response body to be unmodified but got %s", originalBody)
+			}
+		})
+	}
+}
+
+func TestLogRedactorInvalidPattern(t *testing.T) {
+	if _, err := NewLogRedactor([]string{"("}); err == nil {
+		t.Fatalf("expected an error for an invalid pattern")
+	}
+}
+
+func TestLoggerMiddleware(t *testing.T) {
+	output := &bytes.Buffer{}
+	log.SetOutput(output)
+	defer log.SetOutput(os.Stderr)
+
+	redactor := &LogRedactor{}
+	request := httptest.NewRequest(http.MethodPost, "https://management.azure.com/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/example/providers/Microsoft.Storage/storageAccounts/example/listKeys?api-version=2023-01-01", nil)
+	request.Header.Set("Authorization", "Bearer some-token")
+
+	if _, err := requestLoggerMiddleware("AzureRM", redactor)(request); err != nil {
+		t.Fatalf("logging request: %+v", err)
+	}
+
+	response := &http.Response{
+		StatusCode: http.StatusOK,
+		Header:     http.Header{},
+		Body:       io.NopCloser(strings.NewReader(`{"keys": [{"keyName": "key1", "value": "c3RvcmFnZS1rZXk="}]}`)),
+	}
+	if _, err := responseLoggerMiddleware("AzureRM", redactor)(request, response); err != nil {
+		t.Fatalf("logging response: %+v", err)
+	}
+
+	for _, value := range []string{"some-token", "c3RvcmFnZS1rZXk="} {
+		if strings.Contains(output.String(), value) {
+			t.Fatalf("expected %q to be redacted from the log:\n%s", value, output.String())
+		}
+	}
+	if request.Header.Get("Authorization") != "Bearer some-token" {
+		t.Fatalf("expected the Authorization header to be retained on the request")
+	}
+}
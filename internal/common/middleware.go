@@ -4,56 +4,138 @@
 package common
 
 import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
 	"log"
 	"net/http"
 	"net/http/httputil"
 
+	"github.com/Azure/go-autorest/autorest"
 	"github.com/hashicorp/go-azure-sdk/sdk/client"
 )
 
 func correlationRequestIDMiddleware(id string) client.RequestMiddleware {
 	return func(request *http.Request) (*http.Request, error) {
-		// ensure the `X-Correlation-ID` field is set
-		request.Header.Add(HeaderCorrelationRequestID, id)
+		// ensure the `X-Correlation-ID` field is set, using the ID of the resource operation the request is made within (if any)
+		request.Header.Set(HeaderCorrelationRequestID, operationCorrelationRequestID(request.Context(), id))
 		return request, nil
 	}
 }
 
-func requestLoggerMiddleware(providerName string) client.RequestMiddleware {
+func requestLoggerMiddleware(providerName string, redactor *LogRedactor) client.RequestMiddleware {
 	return func(request *http.Request) (*http.Request, error) {
-		// strip the authorization header prior to printing
-		authHeaderName := "Authorization"
-		auth := request.Header.Get(authHeaderName)
-		if auth != "" {
-			request.Header.Del(authHeaderName)
+		// redact any sensitive values (e.g. the authorization header) prior to printing
+		redacted, err := redactor.RedactRequest(request)
+		if err != nil {
+			log.Printf("[DEBUG] %s Request: %s to %s\n", providerName, request.Method, redactURL(request.URL))
+			return request, nil
 		}
 
 		// dump request to wire format
-		if dump, err := httputil.DumpRequestOut(request, true); err == nil {
+		if dump, err := httputil.DumpRequestOut(redacted, true); err == nil {
 			log.Printf("[DEBUG] %s Request: \n%s\n", providerName, dump)
 		} else {
 			// fallback to basic message
-			log.Printf("[DEBUG] %s Request: %s to %s\n", providerName, request.Method, request.URL)
-		}
-
-		// add the auth header back
-		if auth != "" {
-			request.Header.Add(authHeaderName, auth)
+			log.Printf("[DEBUG] %s Request: %s to %s\n", providerName, request.Method, redacted.URL)
 		}
 
 		return request, nil
 	}
 }
 
-func responseLoggerMiddleware(providerName string) client.ResponseMiddleware {
+func responseLoggerMiddleware(providerName string, redactor *LogRedactor) client.ResponseMiddleware {
 	return func(request *http.Request, response *http.Response) (*http.Response, error) {
+		// redact any sensitive values (e.g. keys, connection strings or secrets) prior to printing
+		redacted, err := redactor.RedactResponse(request, response)
+		if err != nil {
+			log.Printf("[DEBUG] %s Response: %s for %s\n", providerName, response.Status, redactURL(request.URL))
+			return response, nil
+		}
+
 		// dump response to wire format
-		if dump, err2 := httputil.DumpResponse(response, true); err2 == nil {
-			log.Printf("[DEBUG] %s Response for %s: \n%s\n", providerName, request.URL, dump)
+		if dump, err2 := httputil.DumpResponse(redacted, true); err2 == nil {
+			log.Printf("[DEBUG] %s Response for %s: \n%s\n", providerName, redactURL(request.URL), dump)
 		} else {
 			// fallback to basic message
-			log.Printf("[DEBUG] %s Response: %s for %s\n", providerName, response.Status, request.URL)
+			log.Printf("[DEBUG] %s Response: %s for %s\n", providerName, response.Status, redactURL(request.URL))
+		}
+		return response, nil
+	}
+}
+
+// withRequestLogging is an autorest.SendDecorator which logs the requests and responses, redacting any sensitive values
+func withRequestLogging(providerName string, redactor *LogRedactor) autorest.SendDecorator {
+	logRequest := requestLoggerMiddleware(providerName, redactor)
+	logResponse := responseLoggerMiddleware(providerName, redactor)
+
+	return func(s autorest.Sender) autorest.Sender {
+		return autorest.SenderFunc(func(r *http.Request) (*http.Response, error) {
+			r, _ = logRequest(r)
+
+			resp, err := s.Do(r)
+			if resp != nil {
+				resp, _ = logResponse(r, resp)
+			} else if err != nil {
+				log.Printf("[DEBUG] %s Response Error: %s for %s\n", providerName, err, redactURL(r.URL))
+			} else {
+				log.Printf("[DEBUG] Request to %s completed with no response", redactURL(r.URL))
+			}
+			return resp, err
+		})
+	}
+}
+
+// buildSender returns the autorest.Sender used by the go-autorest clients
+func buildSender(providerName string, redactor *LogRedactor, transport http.RoundTripper) autorest.Sender {
+	return autorest.DecorateSender(&http.Client{
+		Transport: transport,
+	}, withRequestLogging(providerName, redactor))
+}
+
+type recordedRequestBodyKey struct{}
+
+func recorderRequestMiddleware(recorder *Recorder) client.RequestMiddleware {
+	return func(request *http.Request) (*http.Request, error) {
+		body, err := readRequestBody(request)
+		if err != nil {
+			return nil, err
+		}
+
+		if recorder.Mode() == RecorderModeRecord {
+			// retain the body so that it can be recorded alongside the response
+			return request.WithContext(context.WithValue(request.Context(), recordedRequestBodyKey{}, body)), nil
+		}
+
+		// otherwise redirect the request to the replay server, retaining the original URL for matching
+		serverURL, err := recorder.replayServer()
+		if err != nil {
+			return nil, err
+		}
+
+		replayRequest := request.Clone(request.Context())
+		replayRequest.Body = io.NopCloser(bytes.NewReader(body))
+		replayRequest.Header.Set(headerRecorderOriginalURL, request.URL.String())
+		replayRequest.URL.Scheme = serverURL.Scheme
+		replayRequest.URL.Host = serverURL.Host
+		replayRequest.Host = ""
+
+		return replayRequest, nil
+	}
+}
+
+func recorderResponseMiddleware(recorder *Recorder) client.ResponseMiddleware {
+	return func(request *http.Request, response *http.Response) (*http.Response, error) {
+		if recorder.Mode() != RecorderModeRecord {
+			return response, nil
+		}
+
+		body, _ := request.Context().Value(recordedRequestBodyKey{}).([]byte)
+		if err := recorder.record(request, body, response); err != nil {
+			return nil, fmt.Errorf("recording response: %+v", err)
 		}
+
 		return response, nil
 	}
 }
@@ -0,0 +1,413 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package common
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/go-azure-sdk/sdk/client"
+)
+
+const (
+	// rateLimitLowWatermark is the number of remaining requests reported by Resource Manager below which
+	// requests are paced, so that the remaining quota is spread out rather than exhausted
+	rateLimitLowWatermark = 100
+
+	// rateLimitMaxInterval is the interval between requests once the remaining quota has been exhausted
+	rateLimitMaxInterval = 5 * time.Second
+
+	// rateLimitLeaseTimeout is the duration after which a concurrency slot held by a go-azure-sdk request is
+	// released, should neither a response be received nor the context for the request be done. This is kept short
+	// since a request which fails to send holds the slot until then - and a request which takes longer than this
+	// only means the limit on concurrent requests is briefly exceeded.
+	rateLimitLeaseTimeout = time.Minute
+
+	headerRateLimitRemainingPrefix = "X-Ms-Ratelimit-Remaining-"
+	headerRetryAfter               = "Retry-After"
+)
+
+var rateLimitSubscriptionPattern = regexp.MustCompile(`(?i)^/subscriptions/([^/]+)`)
+
+var (
+	sharedRateLimiters     = make(map[string]*RateLimiter)
+	sharedRateLimitersLock sync.Mutex
+)
+
+// RateLimiter paces the requests made to Resource Manager based on the remaining quota reported in the
+// `x-ms-ratelimit-remaining-*` response headers, ensures that a `Retry-After` returned for a subscription
+// or tenant is honoured by all requests to it, and optionally limits the number of concurrent requests.
+//
+// A single RateLimiter is shared by all of the clients (both go-azure-sdk and go-autorest) for each Resource Manager
+// endpoint and tenant within the process, see SharedRateLimiter.
+type RateLimiter struct {
+	resourceManagerHost string
+	tenantId            string
+
+	// slots limits the number of concurrent requests, this is nil when the number is unlimited
+	slots chan struct{}
+
+	// leaseTimeout is the duration after which a slot held by a go-azure-sdk request is released
+	leaseTimeout time.Duration
+
+	mu      sync.Mutex
+	buckets map[string]*rateLimitBucket
+
+	// now and sleep are overridden in tests
+	now   func() time.Time
+	sleep func(ctx context.Context, d time.Duration) error
+}
+
+type rateLimitBucket struct {
+	// remaining is the remaining quota last reported by Resource Manager, or -1 when unknown
+	remaining int
+
+	// next is the earliest time at which the next request can be sent
+	next time.Time
+
+	// blockedUntil is the time until which requests are held following a `Retry-After`
+	blockedUntil time.Time
+}
+
+// NewRateLimiter returns a RateLimiter for requests to the Resource Manager endpoint, allowing at most
+// maxConcurrentRequests requests to be in-flight at once - or an unlimited number when this is 0.
+func NewRateLimiter(resourceManagerEndpoint, tenantId string, maxConcurrentRequests int) *RateLimiter {
+	limiter := &RateLimiter{
+		tenantId:     tenantId,
+		leaseTimeout: rateLimitLeaseTimeout,
+		buckets:      make(map[string]*rateLimitBucket),
+		now:          time.Now,
+		sleep:        sleepWithContext,
+	}
+
+	if u, err := url.Parse(resourceManagerEndpoint); err == nil {
+		limiter.resourceManagerHost = strings.ToLower(u.Host)
+	}
+
+	if maxConcurrentRequests > 0 {
+		limiter.slots = make(chan struct{}, maxConcurrentRequests)
+	}
+
+	return limiter
+}
+
+// SharedRateLimiter returns the RateLimiter for the Resource Manager endpoint and tenant, which is created on first use
+// and then shared by each client built within the process. Both the Plugin SDK and the Plugin Framework providers build
+// their own clients, so sharing the RateLimiter ensures that `max_concurrent_requests` applies to them in total, and
+// that a `Retry-After` returned to either is honoured by both.
+func SharedRateLimiter(resourceManagerEndpoint, tenantId string, maxConcurrentRequests int) *RateLimiter {
+	key := strings.ToLower(fmt.Sprintf("%s|%s", strings.TrimSuffix(resourceManagerEndpoint, "/"), tenantId))
+
+	sharedRateLimitersLock.Lock()
+	defer sharedRateLimitersLock.Unlock()
+
+	if limiter, ok := sharedRateLimiters[key]; ok {
+		if limiter.maxConcurrentRequests() != maxConcurrentRequests {
+			log.Printf("[DEBUG] Rate Limiter: ignoring a limit of %d concurrent requests since a limit of %d is already in use", maxConcurrentRequests, limiter.maxConcurrentRequests())
+		}
+		return limiter
+	}
+
+	limiter := NewRateLimiter(resourceManagerEndpoint, tenantId, maxConcurrentRequests)
+	sharedRateLimiters[key] = limiter
+	return limiter
+}
+
+// maxConcurrentRequests returns the maximum number of concurrent requests, or 0 when this is unlimited
+func (r *RateLimiter) maxConcurrentRequests() int {
+	return cap(r.slots)
+}
+
+// RoundTripper wraps the specified http.RoundTripper so that each request sent through it is rate limited,
+// since retries are sent through the same RoundTripper each attempt is paced and observed individually.
+func (r *RateLimiter) RoundTripper(base http.RoundTripper) http.RoundTripper {
+	return &rateLimiterRoundTripper{
+		limiter: r,
+		base:    base,
+	}
+}
+
+type rateLimiterRoundTripper struct {
+	limiter *RateLimiter
+	base    http.RoundTripper
+}
+
+func (t *rateLimiterRoundTripper) RoundTrip(request *http.Request) (*http.Response, error) {
+	release, err := t.limiter.acquire(request)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+
+	response, err := t.base.RoundTrip(request)
+	t.limiter.observe(request, response)
+	return response, err
+}
+
+type rateLimiterReleaseKey struct{}
+
+// rateLimiterRequestMiddleware rate limits the requests made by the go-azure-sdk clients. These clients build their
+// own transport for each request which can't be wrapped, so the rate limiting applies to the initial attempt only -
+// with any retries paced by the `Retry-After` header within the client. Since only the final response is observed,
+// a `Retry-After` from an attempt which is retried isn't applied to other requests, which is noted in the documentation.
+//
+// The response middleware isn't called where a subsequent request middleware returns an error, as such this must
+// be the last request middleware configured for the client.
+func rateLimiterRequestMiddleware(limiter *RateLimiter) client.RequestMiddleware {
+	return func(request *http.Request) (*http.Request, error) {
+		release, err := limiter.acquire(request)
+		if err != nil {
+			return nil, err
+		}
+
+		// the slot is released by the response middleware, however this isn't called when sending the request fails
+		// (e.g. the connection is reset) - so the slot is also released once the context for the request is done,
+		// or once the lease expires, whichever happens first
+		lease := time.AfterFunc(limiter.leaseTimeout, release)
+		stop := context.AfterFunc(request.Context(), release)
+		releaseLease := func() {
+			lease.Stop()
+			stop()
+			release()
+		}
+
+		return request.WithContext(context.WithValue(request.Context(), rateLimiterReleaseKey{}, releaseLease)), nil
+	}
+}
+
+func rateLimiterResponseMiddleware(limiter *RateLimiter) client.ResponseMiddleware {
+	return func(request *http.Request, response *http.Response) (*http.Response, error) {
+		if release, ok := request.Context().Value(rateLimiterReleaseKey{}).(func()); ok {
+			release()
+		}
+
+		limiter.observe(request, response)
+		return response, nil
+	}
+}
+
+// acquire waits until the request can be sent, returning a function which must be called once it completes
+func (r *RateLimiter) acquire(request *http.Request) (func(), error) {
+	ctx := request.Context()
+
+	if delay := r.reserve(request); delay > 0 {
+		log.Printf("[DEBUG] Rate Limiter: delaying %s %s by %s", request.Method, request.URL, delay)
+		if err := r.sleep(ctx, delay); err != nil {
+			return nil, fmt.Errorf("waiting to send request: %+v", err)
+		}
+	}
+
+	if r.slots == nil {
+		return func() {}, nil
+	}
+
+	select {
+	case r.slots <- struct{}{}:
+	case <-ctx.Done():
+		return nil, fmt.Errorf("waiting for a concurrent request slot: %+v", ctx.Err())
+	}
+
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			<-r.slots
+		})
+	}, nil
+}
+
+// reserve reserves the next available time for the request, returning how long to wait until it can be sent
+func (r *RateLimiter) reserve(request *http.Request) time.Duration {
+	keys := r.keys(request)
+	if len(keys) == 0 {
+		return 0
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	now := r.now()
+	sendAt := now
+	for _, key := range keys {
+		bucket := r.bucket(key)
+		if bucket.blockedUntil.After(sendAt) {
+			sendAt = bucket.blockedUntil
+		}
+		if bucket.next.After(sendAt) {
+			sendAt = bucket.next
+		}
+	}
+
+	for _, key := range keys {
+		bucket := r.bucket(key)
+		bucket.next = sendAt.Add(rateLimitInterval(bucket.remaining))
+	}
+
+	return sendAt.Sub(now)
+}
+
+// observe updates the remaining quota and any `Retry-After` from the response
+func (r *RateLimiter) observe(request *http.Request, response *http.Response) {
+	if response == nil {
+		return
+	}
+
+	keys := r.keys(request)
+	if len(keys) == 0 {
+		return
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	// where multiple headers apply to the same bucket (e.g. `-Reads` and `-Global-Reads`) the lowest is used
+	remainingByKey := make(map[string]int)
+	subscriptionId, category := r.scope(request)
+	for name, values := range response.Header {
+		name = http.CanonicalHeaderKey(name)
+		if !strings.HasPrefix(name, headerRateLimitRemainingPrefix) || len(values) == 0 {
+			continue
+		}
+
+		remaining, err := strconv.Atoi(values[0])
+		if err != nil {
+			continue
+		}
+
+		// e.g. `X-Ms-Ratelimit-Remaining-Subscription-Reads` or `X-Ms-Ratelimit-Remaining-Tenant-Writes`
+		scope := strings.ToLower(strings.TrimPrefix(name, headerRateLimitRemainingPrefix))
+		if !strings.HasSuffix(scope, category) {
+			continue
+		}
+		var key string
+		switch {
+		case strings.HasPrefix(scope, "subscription-") && subscriptionId != "":
+			key = rateLimitKey("subscription", subscriptionId, category)
+		case strings.HasPrefix(scope, "tenant-"):
+			key = rateLimitKey("tenant", r.tenantId, category)
+		default:
+			continue
+		}
+		if existing, ok := remainingByKey[key]; !ok || remaining < existing {
+			remainingByKey[key] = remaining
+		}
+	}
+	for key, remaining := range remainingByKey {
+		r.bucket(key).remaining = remaining
+	}
+
+	if response.StatusCode != http.StatusTooManyRequests {
+		return
+	}
+
+	retryAfter := parseRetryAfter(response.Header.Get(headerRetryAfter), r.now())
+	if retryAfter <= 0 {
+		retryAfter = rateLimitMaxInterval
+	}
+
+	log.Printf("[DEBUG] Rate Limiter: throttled by Resource Manager, holding requests for %s", retryAfter)
+	blockedUntil := r.now().Add(retryAfter)
+	for _, key := range keys {
+		bucket := r.bucket(key)
+		if blockedUntil.After(bucket.blockedUntil) {
+			bucket.blockedUntil = blockedUntil
+		}
+	}
+}
+
+// keys returns the keys of the buckets which apply to the request, which is empty when the request isn't to Resource Manager
+func (r *RateLimiter) keys(request *http.Request) []string {
+	if request == nil || request.URL == nil || !strings.EqualFold(request.URL.Host, r.resourceManagerHost) {
+		return nil
+	}
+
+	subscriptionId, category := r.scope(request)
+	keys := []string{
+		rateLimitKey("tenant", r.tenantId, category),
+	}
+	if subscriptionId != "" {
+		keys = append(keys, rateLimitKey("subscription", subscriptionId, category))
+	}
+
+	return keys
+}
+
+// scope returns the Subscription ID (if any) and the category of quota which the request counts against
+func (r *RateLimiter) scope(request *http.Request) (subscriptionId string, category string) {
+	if match := rateLimitSubscriptionPattern.FindStringSubmatch(request.URL.Path); len(match) == 2 {
+		subscriptionId = strings.ToLower(match[1])
+	}
+
+	switch request.Method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions:
+		category = "reads"
+	case http.MethodDelete:
+		category = "deletes"
+	default:
+		category = "writes"
+	}
+
+	return subscriptionId, category
+}
+
+func (r *RateLimiter) bucket(key string) *rateLimitBucket {
+	bucket, ok := r.buckets[key]
+	if !ok {
+		bucket = &rateLimitBucket{
+			remaining: -1,
+		}
+		r.buckets[key] = bucket
+	}
+	return bucket
+}
+
+func rateLimitKey(scope, id, category string) string {
+	return fmt.Sprintf("%s/%s/%s", scope, id, category)
+}
+
+// rateLimitInterval returns the interval between requests given the remaining quota, which increases as
+// the remaining quota decreases below the low watermark
+func rateLimitInterval(remaining int) time.Duration {
+	if remaining < 0 || remaining >= rateLimitLowWatermark {
+		return 0
+	}
+
+	return rateLimitMaxInterval * time.Duration(rateLimitLowWatermark-remaining) / rateLimitLowWatermark
+}
+
+// parseRetryAfter parses the value of a `Retry-After` header, which is either a number of seconds or an HTTP date
+func parseRetryAfter(input string, now time.Time) time.Duration {
+	if input == "" {
+		return 0
+	}
+
+	if seconds, err := strconv.Atoi(input); err == nil {
+		return time.Duration(seconds) * time.Second
+	}
+
+	if t, err := http.ParseTime(input); err == nil {
+		return t.Sub(now)
+	}
+
+	return 0
+}
+
+func sleepWithContext(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
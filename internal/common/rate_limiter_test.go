@@ -0,0 +1,322 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package common
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+const testRateLimiterSubscriptionId = "11111111-1111-1111-1111-111111111111"
+
+func testRateLimiter(maxConcurrentRequests int) (*RateLimiter, *time.Time, *[]time.Duration) {
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	delays := make([]time.Duration, 0)
+
+	limiter := NewRateLimiter("https://management.azure.com/", "tenant", maxConcurrentRequests)
+	limiter.now = func() time.Time {
+		return now
+	}
+	limiter.sleep = func(_ context.Context, d time.Duration) error {
+		delays = append(delays, d)
+		return nil
+	}
+
+	return limiter, &now, &delays
+}
+
+func testRateLimiterRequest(method, uri string) *http.Request {
+	return httptest.NewRequest(method, uri, nil)
+}
+
+func TestRateLimitInterval(t *testing.T) {
+	testData := []struct {
+		remaining int
+		expected  time.Duration
+	}{
+		{remaining: -1, expected: 0},
+		{remaining: 12000, expected: 0},
+		{remaining: rateLimitLowWatermark, expected: 0},
+		{remaining: rateLimitLowWatermark / 2, expected: rateLimitMaxInterval / 2},
+		{remaining: 0, expected: rateLimitMaxInterval},
+	}
+
+	for _, v := range testData {
+		if actual := rateLimitInterval(v.remaining); actual != v.expected {
+			t.Fatalf("expected %s for %d remaining but got %s", v.expected, v.remaining, actual)
+		}
+	}
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	testData := []struct {
+		input    string
+		expected time.Duration
+	}{
+		{input: "", expected: 0},
+		{input: "invalid", expected: 0},
+		{input: "17", expected: 17 * time.Second},
+		{input: now.Add(time.Minute).Format(http.TimeFormat), expected: time.Minute},
+	}
+
+	for _, v := range testData {
+		if actual := parseRetryAfter(v.input, now); actual != v.expected {
+			t.Fatalf("expected %s for %q but got %s", v.expected, v.input, actual)
+		}
+	}
+}
+
+func TestRateLimiter_pacesWhenQuotaIsLow(t *testing.T) {
+	limiter, _, delays := testRateLimiter(0)
+	uri := "https://management.azure.com/subscriptions/" + testRateLimiterSubscriptionId + "/resourceGroups/example?api-version=2020-06-01"
+
+	request := testRateLimiterRequest(http.MethodGet, uri)
+	response := &http.Response{
+		StatusCode: http.StatusOK,
+		Header: http.Header{
+			"X-Ms-Ratelimit-Remaining-Subscription-Reads": []string{"0"},
+		},
+	}
+	limiter.observe(request, response)
+
+	for i := 0; i < 3; i++ {
+		release, err := limiter.acquire(testRateLimiterRequest(http.MethodGet, uri))
+		if err != nil {
+			t.Fatalf("acquiring: %+v", err)
+		}
+		release()
+	}
+
+	expected := []time.Duration{rateLimitMaxInterval, 2 * rateLimitMaxInterval}
+	if len(*delays) != len(expected) {
+		t.Fatalf("expected %d delays but got %d: %v", len(expected), len(*delays), *delays)
+	}
+	for i, v := range expected {
+		if (*delays)[i] != v {
+			t.Fatalf("expected delay %d to be %s but got %s", i, v, (*delays)[i])
+		}
+	}
+
+	// writes are tracked separately to reads, so shouldn't be delayed
+	*delays = (*delays)[:0]
+	release, err := limiter.acquire(testRateLimiterRequest(http.MethodPut, uri))
+	if err != nil {
+		t.Fatalf("acquiring: %+v", err)
+	}
+	release()
+	if len(*delays) != 0 {
+		t.Fatalf("expected no delays for a write but got %v", *delays)
+	}
+}
+
+func TestRateLimiter_honoursRetryAfterAcrossRequests(t *testing.T) {
+	limiter, _, delays := testRateLimiter(0)
+	uri := "https://management.azure.com/subscriptions/" + testRateLimiterSubscriptionId + "/providers/Microsoft.Compute/virtualMachines?api-version=2024-03-01"
+
+	limiter.observe(testRateLimiterRequest(http.MethodGet, uri), &http.Response{
+		StatusCode: http.StatusTooManyRequests,
+		Header: http.Header{
+			"Retry-After": []string{"30"},
+		},
+	})
+
+	// a different request to the same subscription must also wait
+	other := "https://management.azure.com/subscriptions/" + testRateLimiterSubscriptionId + "/resourceGroups?api-version=2020-06-01"
+	release, err := limiter.acquire(testRateLimiterRequest(http.MethodGet, other))
+	if err != nil {
+		t.Fatalf("acquiring: %+v", err)
+	}
+	release()
+
+	if len(*delays) != 1 || (*delays)[0] != 30*time.Second {
+		t.Fatalf("expected a single delay of 30s but got %v", *delays)
+	}
+}
+
+func TestRateLimiter_ignoresOtherHosts(t *testing.T) {
+	limiter, _, delays := testRateLimiter(0)
+	uri := "https://example.vault.azure.net/secrets/example?api-version=7.4"
+
+	limiter.observe(testRateLimiterRequest(http.MethodGet, uri), &http.Response{
+		StatusCode: http.StatusTooManyRequests,
+		Header: http.Header{
+			"Retry-After": []string{"30"},
+		},
+	})
+
+	release, err := limiter.acquire(testRateLimiterRequest(http.MethodGet, uri))
+	if err != nil {
+		t.Fatalf("acquiring: %+v", err)
+	}
+	release()
+
+	if len(*delays) != 0 {
+		t.Fatalf("expected no delays but got %v", *delays)
+	}
+}
+
+func TestSharedRateLimiter(t *testing.T) {
+	tenantId := "00000000-0000-0000-0000-000000000001"
+	first := SharedRateLimiter("https://management.example.com/", tenantId, 4)
+
+	// e.g. the Plugin SDK and Plugin Framework providers within the same process
+	if second := SharedRateLimiter("https://MANAGEMENT.example.com", tenantId, 4); second != first {
+		t.Fatalf("expected the RateLimiter to be shared for the same endpoint and tenant")
+	}
+
+	if other := SharedRateLimiter("https://management.example.com/", "00000000-0000-0000-0000-000000000002", 4); other == first {
+		t.Fatalf("expected a separate RateLimiter for a different tenant")
+	}
+
+	if other := SharedRateLimiter("https://management.other.example.com/", tenantId, 4); other == first {
+		t.Fatalf("expected a separate RateLimiter for a different endpoint")
+	}
+
+	if first.maxConcurrentRequests() != 4 {
+		t.Fatalf("expected a limit of 4 concurrent requests but got %d", first.maxConcurrentRequests())
+	}
+}
+
+func TestRateLimiter_maxConcurrentRequests(t *testing.T) {
+	limiter, _, _ := testRateLimiter(1)
+	uri := "https://management.azure.com/subscriptions/" + testRateLimiterSubscriptionId + "?api-version=2020-01-01"
+
+	release, err := limiter.acquire(testRateLimiterRequest(http.MethodGet, uri))
+	if err != nil {
+		t.Fatalf("acquiring: %+v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	if _, err := limiter.acquire(testRateLimiterRequest(http.MethodGet, uri).WithContext(ctx)); err == nil {
+		t.Fatalf("expected an error acquiring a second slot")
+	}
+
+	// releasing more than once mustn't free up additional slots
+	release()
+	release()
+
+	release, err = limiter.acquire(testRateLimiterRequest(http.MethodGet, uri))
+	if err != nil {
+		t.Fatalf("acquiring after release: %+v", err)
+	}
+	release()
+}
+
+func TestRateLimiter_middleware(t *testing.T) {
+	limiter, _, _ := testRateLimiter(1)
+	uri := "https://management.azure.com/subscriptions/" + testRateLimiterSubscriptionId + "?api-version=2020-01-01"
+
+	request, err := rateLimiterRequestMiddleware(limiter)(testRateLimiterRequest(http.MethodGet, uri))
+	if err != nil {
+		t.Fatalf("request middleware: %+v", err)
+	}
+	if len(limiter.slots) != 1 {
+		t.Fatalf("expected the slot to be held whilst the request is in-flight")
+	}
+
+	if _, err := rateLimiterResponseMiddleware(limiter)(request, &http.Response{StatusCode: http.StatusOK, Header: http.Header{}}); err != nil {
+		t.Fatalf("response middleware: %+v", err)
+	}
+	if len(limiter.slots) != 0 {
+		t.Fatalf("expected the slot to be released once the response was received")
+	}
+}
+
+func TestRateLimiter_middlewareReleasesWhenContextIsDone(t *testing.T) {
+	limiter, _, _ := testRateLimiter(1)
+	uri := "https://management.azure.com/subscriptions/" + testRateLimiterSubscriptionId + "?api-version=2020-01-01"
+
+	// the response middleware isn't called when sending the request fails, so the slot is released with the context
+	ctx, cancel := context.WithCancel(context.Background())
+	if _, err := rateLimiterRequestMiddleware(limiter)(testRateLimiterRequest(http.MethodGet, uri).WithContext(ctx)); err != nil {
+		t.Fatalf("request middleware: %+v", err)
+	}
+	cancel()
+
+	acquireCtx, acquireCancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer acquireCancel()
+	release, err := limiter.acquire(testRateLimiterRequest(http.MethodGet, uri).WithContext(acquireCtx))
+	if err != nil {
+		t.Fatalf("expected the slot to be released once the context was done but got: %+v", err)
+	}
+	release()
+}
+
+func TestRateLimiter_middlewareReleasesWhenLeaseExpires(t *testing.T) {
+	limiter, _, _ := testRateLimiter(1)
+	limiter.leaseTimeout = 10 * time.Millisecond
+	uri := "https://management.azure.com/subscriptions/" + testRateLimiterSubscriptionId + "?api-version=2020-01-01"
+
+	// where the context for the failed request is never done, the slot is released once the lease expires
+	if _, err := rateLimiterRequestMiddleware(limiter)(testRateLimiterRequest(http.MethodGet, uri)); err != nil {
+		t.Fatalf("request middleware: %+v", err)
+	}
+
+	acquireCtx, acquireCancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer acquireCancel()
+	release, err := limiter.acquire(testRateLimiterRequest(http.MethodGet, uri).WithContext(acquireCtx))
+	if err != nil {
+		t.Fatalf("expected the slot to be released once the lease expired but got: %+v", err)
+	}
+	release()
+}
+
+type rateLimiterTestRoundTripper func(request *http.Request) (*http.Response, error)
+
+func (f rateLimiterTestRoundTripper) RoundTrip(request *http.Request) (*http.Response, error) {
+	return f(request)
+}
+
+func TestRateLimiter_roundTripper(t *testing.T) {
+	limiter, now, delays := testRateLimiter(1)
+	uri := "https://management.azure.com/subscriptions/" + testRateLimiterSubscriptionId + "?api-version=2020-01-01"
+
+	attempt := 0
+	roundTripper := limiter.RoundTripper(rateLimiterTestRoundTripper(func(request *http.Request) (*http.Response, error) {
+		if len(limiter.slots) != 1 {
+			t.Fatalf("expected the slot to be held whilst the request is in-flight")
+		}
+
+		attempt++
+		switch attempt {
+		case 1:
+			return &http.Response{
+				StatusCode: http.StatusTooManyRequests,
+				Header: http.Header{
+					"Retry-After": []string{"5"},
+				},
+			}, nil
+		case 2:
+			return nil, fmt.Errorf("connection reset")
+		default:
+			return &http.Response{StatusCode: http.StatusOK, Header: http.Header{}}, nil
+		}
+	}))
+
+	// each attempt (as made when retrying) is paced and releases the slot regardless of the outcome
+	if _, err := roundTripper.RoundTrip(testRateLimiterRequest(http.MethodGet, uri)); err != nil {
+		t.Fatalf("sending: %+v", err)
+	}
+	if _, err := roundTripper.RoundTrip(testRateLimiterRequest(http.MethodGet, uri)); err == nil {
+		t.Fatalf("expected an error from the second attempt")
+	}
+	*now = now.Add(5 * time.Second)
+	if _, err := roundTripper.RoundTrip(testRateLimiterRequest(http.MethodGet, uri)); err != nil {
+		t.Fatalf("sending: %+v", err)
+	}
+
+	if len(limiter.slots) != 0 {
+		t.Fatalf("expected the slot to be released")
+	}
+	if len(*delays) != 1 || (*delays)[0] != 5*time.Second {
+		t.Fatalf("expected a single delay of 5s but got %v", *delays)
+	}
+}
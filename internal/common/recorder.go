@@ -0,0 +1,724 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package common
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"mime"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Azure/go-autorest/autorest"
+	"golang.org/x/oauth2"
+)
+
+type RecorderMode string
+
+const (
+	// RecorderModeRecord sends requests to Azure as usual, recording the sanitized interactions into a cassette
+	RecorderModeRecord RecorderMode = "record"
+
+	// RecorderModeReplay serves responses from a previously recorded cassette, without sending any requests to Azure
+	RecorderModeReplay RecorderMode = "replay"
+)
+
+const (
+	// headerRecorderOriginalURL is used to pass the original URL of a request through to the replay server
+	headerRecorderOriginalURL = "X-Azurerm-Recorder-Original-Url"
+
+	cassetteVersion = 1
+
+	// RecorderRedactedValue is the value which sensitive values are replaced with when recorded
+	RecorderRedactedValue = "REDACTED"
+
+	// the placeholders used in place of the identifiers of the account which recorded a cassette
+	recorderSubscriptionIdPlaceholder = "00000000-0000-0000-0000-000000000000"
+	recorderTenantIdPlaceholder       = "00000000-0000-0000-0000-000000000001"
+	recorderClientIdPlaceholder       = "00000000-0000-0000-0000-000000000002"
+	recorderObjectIdPlaceholder       = "00000000-0000-0000-0000-000000000003"
+)
+
+var (
+	// recordedResponseHeaders are the response headers which are retained in a cassette, all others are discarded
+	recordedResponseHeaders = []string{
+		"Azure-AsyncOperation",
+		"Content-Type",
+		"Location",
+		"Retry-After",
+		"X-Ms-Error-Code",
+	}
+
+	// sensitiveJsonKeys matches the names of JSON properties (and URL-encoded form fields) whose values are redacted
+	// when recorded
+	sensitiveJsonKeys = regexp.MustCompile(`(?i)(password|secret|connectionstring|accesskey|accountkey|primarykey|secondarykey|sharedkey|sastoken|access_?token|refresh_?token|^token$|kubeconfig)`)
+
+	// nonSensitiveJsonKeys matches the names of JSON properties which reference a sensitive value rather than
+	// containing one (e.g. `keyVaultSecretId`) and so are retained
+	nonSensitiveJsonKeys = regexp.MustCompile(`(?i)(id|ids|uri|url|name|type|version|enabled|expiry|expiration)$`)
+
+	// sensitiveQueryParameters are the query string parameters whose values are redacted when recorded, e.g. SAS Tokens
+	sensitiveQueryParameters = []string{"sig", "skoid", "sktid", "code"}
+)
+
+// RecordedInteraction is a single sanitized request and the response which was returned for it
+type RecordedInteraction struct {
+	Request  RecordedRequest  `json:"request"`
+	Response RecordedResponse `json:"response"`
+}
+
+type RecordedRequest struct {
+	Method string `json:"method"`
+	URL    string `json:"url"`
+	Body   string `json:"body,omitempty"`
+}
+
+type RecordedResponse struct {
+	StatusCode int                 `json:"status_code"`
+	Headers    map[string][]string `json:"headers,omitempty"`
+	Body       string              `json:"body,omitempty"`
+}
+
+// Cassette is the on-disk format of a recording
+type Cassette struct {
+	Version int `json:"version"`
+
+	// Variables are arbitrary values which must be the same when replaying, e.g. the random values used in a test
+	Variables map[string]string `json:"variables,omitempty"`
+
+	Interactions []RecordedInteraction `json:"interactions"`
+}
+
+// Recorder records the interactions with Azure into a Cassette, or replays the interactions from a Cassette.
+//
+// Requests are matched on the HTTP Method, the normalized URL and the normalized body. Where a request is made
+// multiple times (for example when polling a long-running operation) the recorded responses are served in the
+// order they were recorded, with the last response being re-used once the recorded responses are exhausted.
+type Recorder struct {
+	mode RecorderMode
+	path string
+
+	lock         sync.Mutex
+	cassette     Cassette
+	used         []bool
+	replacements []recorderReplacement
+
+	// route (optional) returns the Recorder which a request should be recorded into/replayed from
+	route func(*http.Request) *Recorder
+
+	serverOnce sync.Once
+	server     *http.Server
+	serverURL  *url.URL
+	serverErr  error
+}
+
+type recorderReplacement struct {
+	value       string
+	placeholder string
+}
+
+// NewRecorder returns a Recorder for the cassette at the specified path - when replaying the cassette
+// must already exist, when recording the cassette is written when Save is called.
+func NewRecorder(mode RecorderMode, path string) (*Recorder, error) {
+	r := &Recorder{
+		mode: mode,
+		path: path,
+		cassette: Cassette{
+			Version:   cassetteVersion,
+			Variables: map[string]string{},
+		},
+	}
+
+	switch mode {
+	case RecorderModeRecord:
+		return r, nil
+
+	case RecorderModeReplay:
+		contents, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("reading cassette %q: %+v", path, err)
+		}
+		if err := json.Unmarshal(contents, &r.cassette); err != nil {
+			return nil, fmt.Errorf("parsing cassette %q: %+v", path, err)
+		}
+		if r.cassette.Version != cassetteVersion {
+			return nil, fmt.Errorf("cassette %q has version %d but only version %d is supported", path, r.cassette.Version, cassetteVersion)
+		}
+		if r.cassette.Variables == nil {
+			r.cassette.Variables = map[string]string{}
+		}
+		r.used = make([]bool, len(r.cassette.Interactions))
+		return r, nil
+	}
+
+	return nil, fmt.Errorf("unsupported recorder mode %q", string(mode))
+}
+
+// NewRoutingRecorder returns a Recorder which delegates each request to the Recorder returned by `route`,
+// which allows a single client to be shared across multiple cassettes.
+func NewRoutingRecorder(mode RecorderMode, route func(*http.Request) *Recorder) *Recorder {
+	return &Recorder{
+		mode:  mode,
+		route: route,
+	}
+}
+
+func (r *Recorder) Mode() RecorderMode {
+	return r.mode
+}
+
+// Variable returns the value of the named variable from the cassette
+func (r *Recorder) Variable(name string) (string, bool) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	v, ok := r.cassette.Variables[name]
+	return v, ok
+}
+
+// SetVariable sets the value of the named variable in the cassette
+func (r *Recorder) SetVariable(name, value string) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	r.cassette.Variables[name] = value
+}
+
+// UseAccount configures the identifiers of the authenticated account, which are replaced with placeholders
+// when recording and are substituted back in when replaying - so that cassettes can be replayed regardless
+// of the Subscription/Tenant they were recorded in.
+func (r *Recorder) UseAccount(subscriptionId, tenantId, clientId, objectId string) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	r.replacements = make([]recorderReplacement, 0)
+	for _, replacement := range []recorderReplacement{
+		{value: subscriptionId, placeholder: recorderSubscriptionIdPlaceholder},
+		{value: tenantId, placeholder: recorderTenantIdPlaceholder},
+		{value: clientId, placeholder: recorderClientIdPlaceholder},
+		{value: objectId, placeholder: recorderObjectIdPlaceholder},
+	} {
+		if replacement.value == "" || strings.EqualFold(replacement.value, replacement.placeholder) {
+			continue
+		}
+		r.replacements = append(r.replacements, replacement)
+	}
+}
+
+// Save writes the recorded interactions to the cassette, this is a no-op when replaying
+func (r *Recorder) Save() error {
+	if r.mode != RecorderModeRecord || r.route != nil {
+		return nil
+	}
+
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	contents, err := json.MarshalIndent(r.cassette, "", "  ")
+	if err != nil {
+		return fmt.Errorf("serializing cassette: %+v", err)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(r.path), 0o755); err != nil {
+		return fmt.Errorf("creating directory for cassette %q: %+v", r.path, err)
+	}
+
+	if err := os.WriteFile(r.path, contents, 0o644); err != nil {
+		return fmt.Errorf("writing cassette %q: %+v", r.path, err)
+	}
+
+	log.Printf("[DEBUG] Recorder: wrote %d interaction(s) to %q", len(r.cassette.Interactions), r.path)
+	return nil
+}
+
+// Authorizer returns an auth.Authorizer which issues placeholder access tokens, for use when replaying
+func (r *Recorder) Authorizer() *RecorderAuthorizer {
+	return &RecorderAuthorizer{}
+}
+
+// Sender returns an autorest.Sender which records/replays the requests made by a go-autorest client
+func (r *Recorder) Sender(sender autorest.Sender) autorest.Sender {
+	return autorest.SenderFunc(func(request *http.Request) (*http.Response, error) {
+		body, err := readRequestBody(request)
+		if err != nil {
+			return nil, err
+		}
+
+		if r.mode == RecorderModeReplay {
+			return r.replayResponse(request, request.Method, request.URL, body)
+		}
+
+		resp, err := sender.Do(request)
+		if err != nil {
+			return resp, err
+		}
+		if err := r.record(request, body, resp); err != nil {
+			return nil, err
+		}
+		return resp, nil
+	})
+}
+
+// Affinity returns the length of the longest common prefix between the normalized URL of the request and
+// the URLs of the interactions within this Recorder, which allows a routing Recorder to determine which
+// cassette a request most likely belongs to.
+func (r *Recorder) Affinity(request *http.Request) int {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	uri := request.URL
+	if original := request.Header.Get(headerRecorderOriginalURL); original != "" {
+		if u, err := url.Parse(original); err == nil {
+			uri = u
+		}
+	}
+	normalized := r.normalizeURL(uri)
+
+	longest := 0
+	for _, interaction := range r.cassette.Interactions {
+		length := 0
+		for length < len(normalized) && length < len(interaction.Request.URL) && normalized[length] == interaction.Request.URL[length] {
+			length++
+		}
+		if length > longest {
+			longest = length
+		}
+	}
+
+	return longest
+}
+
+func (r *Recorder) target(request *http.Request) (*Recorder, error) {
+	if r.route == nil {
+		return r, nil
+	}
+
+	if target := r.route(request); target != nil {
+		// the routed Recorder may not have been configured with the details of the account yet
+		r.lock.Lock()
+		replacements := r.replacements
+		r.lock.Unlock()
+		target.lock.Lock()
+		if len(target.replacements) == 0 {
+			target.replacements = replacements
+		}
+		target.lock.Unlock()
+
+		return target, nil
+	}
+
+	return nil, fmt.Errorf("no cassette is available for %s %s", request.Method, request.URL)
+}
+
+// record adds the request and response to the cassette, the body of the response is replaced
+// so that it can continue to be consumed by the caller
+func (r *Recorder) record(request *http.Request, requestBody []byte, response *http.Response) error {
+	target, err := r.target(request)
+	if err != nil {
+		return err
+	}
+
+	var responseBody []byte
+	if response.Body != nil {
+		responseBody, err = io.ReadAll(response.Body)
+		if err != nil {
+			return fmt.Errorf("reading response body: %+v", err)
+		}
+		response.Body.Close()
+		response.Body = io.NopCloser(bytes.NewReader(responseBody))
+	}
+
+	headers := make(map[string][]string)
+	for _, name := range recordedResponseHeaders {
+		if values := response.Header.Values(name); len(values) > 0 {
+			headers[name] = target.sanitizeValues(values)
+		}
+	}
+
+	target.lock.Lock()
+	defer target.lock.Unlock()
+
+	target.cassette.Interactions = append(target.cassette.Interactions, RecordedInteraction{
+		Request: RecordedRequest{
+			Method: request.Method,
+			URL:    target.normalizeURL(request.URL),
+			Body:   target.normalizeBody(redactFormBody(request.Header, requestBody, RecorderRedactedValue, isSensitiveJsonKey)),
+		},
+		Response: RecordedResponse{
+			StatusCode: response.StatusCode,
+			Headers:    headers,
+			Body:       target.normalizeBody(responseBody),
+		},
+	})
+
+	return nil
+}
+
+// replayResponse returns the recorded response matching the specified request
+func (r *Recorder) replayResponse(request *http.Request, method string, uri *url.URL, body []byte) (*http.Response, error) {
+	target, err := r.target(request)
+	if err != nil {
+		return nil, err
+	}
+
+	target.lock.Lock()
+	defer target.lock.Unlock()
+
+	normalizedURL := target.normalizeURL(uri)
+	normalizedBody := target.normalizeBody(redactFormBody(request.Header, body, RecorderRedactedValue, isSensitiveJsonKey))
+
+	match := -1
+	for i, interaction := range target.cassette.Interactions {
+		if interaction.Request.Method != method || interaction.Request.URL != normalizedURL || interaction.Request.Body != normalizedBody {
+			continue
+		}
+
+		// the recorded interactions are served in order, re-using the last one once they've been exhausted
+		match = i
+		if !target.used[i] {
+			break
+		}
+	}
+
+	if match == -1 {
+		return nil, fmt.Errorf("no recorded interaction in %q matches %s %s", target.path, method, normalizedURL)
+	}
+	target.used[match] = true
+
+	recorded := target.cassette.Interactions[match].Response
+	responseBody := target.desanitize(recorded.Body)
+	response := &http.Response{
+		Status:        fmt.Sprintf("%d %s", recorded.StatusCode, http.StatusText(recorded.StatusCode)),
+		StatusCode:    recorded.StatusCode,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        http.Header{},
+		Body:          io.NopCloser(strings.NewReader(responseBody)),
+		ContentLength: int64(len(responseBody)),
+		Request:       request,
+	}
+	for name, values := range recorded.Headers {
+		for _, v := range values {
+			response.Header.Add(name, target.desanitize(v))
+		}
+	}
+	if response.Header.Get("Retry-After") != "" {
+		// there's nothing to wait for when replaying
+		response.Header.Set("Retry-After", "1")
+	}
+
+	return response, nil
+}
+
+// replayServer returns the URL of a local server which serves the recorded responses. The go-azure-sdk
+// base layer doesn't allow the Transport to be replaced, so requests are instead redirected to this server
+// by a Request Middleware.
+func (r *Recorder) replayServer() (*url.URL, error) {
+	r.serverOnce.Do(func() {
+		listener, err := net.Listen("tcp", "127.0.0.1:0")
+		if err != nil {
+			r.serverErr = fmt.Errorf("starting replay server: %+v", err)
+			return
+		}
+
+		server := &http.Server{
+			Handler: http.HandlerFunc(r.serveReplay),
+		}
+		r.lock.Lock()
+		r.server = server
+		r.lock.Unlock()
+		go func() {
+			if err := server.Serve(listener); err != nil && !errors.Is(err, http.ErrServerClosed) {
+				log.Printf("[DEBUG] Recorder: replay server stopped: %+v", err)
+			}
+		}()
+
+		r.serverURL = &url.URL{
+			Scheme: "http",
+			Host:   listener.Addr().String(),
+		}
+	})
+
+	return r.serverURL, r.serverErr
+}
+
+// Close shuts down the replay server (if it was started), which must be called once the Recorder is no longer used
+func (r *Recorder) Close() error {
+	// ensure the replay server isn't started once the Recorder has been closed
+	r.serverOnce.Do(func() {
+		r.serverErr = fmt.Errorf("the recorder has been closed")
+	})
+
+	r.lock.Lock()
+	server := r.server
+	r.server = nil
+	r.lock.Unlock()
+
+	if server == nil {
+		return nil
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+	if err := server.Shutdown(ctx); err != nil {
+		return fmt.Errorf("shutting down replay server: %+v", err)
+	}
+
+	return nil
+}
+
+func (r *Recorder) serveReplay(w http.ResponseWriter, request *http.Request) {
+	originalURL, err := url.Parse(request.Header.Get(headerRecorderOriginalURL))
+	if err != nil {
+		http.Error(w, fmt.Sprintf("parsing original url: %+v", err), http.StatusBadRequest)
+		return
+	}
+	request.URL = originalURL
+
+	body, err := readRequestBody(request)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	response, err := r.replayResponse(request, request.Method, originalURL, body)
+	if err != nil {
+		log.Printf("[DEBUG] Recorder: %+v", err)
+		// a non-retryable status code is used so that the error is surfaced immediately
+		http.Error(w, fmt.Sprintf("recorder: %+v", err), http.StatusNotImplemented)
+		return
+	}
+
+	for name, values := range response.Header {
+		for _, v := range values {
+			w.Header().Add(name, v)
+		}
+	}
+	w.WriteHeader(response.StatusCode)
+	_, _ = io.Copy(w, response.Body)
+}
+
+// normalizeURL returns the sanitized URL with a lower-cased host and path and sorted query string
+func (r *Recorder) normalizeURL(input *url.URL) string {
+	query := input.Query()
+	for _, name := range sensitiveQueryParameters {
+		if query.Has(name) {
+			query.Set(name, RecorderRedactedValue)
+		}
+	}
+
+	keys := make([]string, 0, len(query))
+	for k := range query {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	params := make([]string, 0)
+	for _, k := range keys {
+		values := query[k]
+		sort.Strings(values)
+		for _, v := range values {
+			params = append(params, fmt.Sprintf("%s=%s", url.QueryEscape(k), url.QueryEscape(v)))
+		}
+	}
+
+	output := fmt.Sprintf("%s://%s%s", strings.ToLower(input.Scheme), strings.ToLower(input.Host), strings.ToLower(input.EscapedPath()))
+	if len(params) > 0 {
+		output = fmt.Sprintf("%s?%s", output, strings.Join(params, "&"))
+	}
+
+	return r.sanitize(output)
+}
+
+// normalizeBody returns the sanitized body, where JSON bodies are re-serialized so that the ordering
+// of keys is consistent and the values of sensitive keys are redacted
+func (r *Recorder) normalizeBody(input []byte) string {
+	if len(bytes.TrimSpace(input)) == 0 {
+		return ""
+	}
+
+	var v interface{}
+	if err := json.Unmarshal(input, &v); err != nil {
+		return r.sanitize(string(input))
+	}
+
+	v = redactJson(v, false)
+	output, err := json.Marshal(v)
+	if err != nil {
+		return r.sanitize(string(input))
+	}
+
+	return r.sanitize(string(output))
+}
+
+func (r *Recorder) sanitizeValues(input []string) []string {
+	output := make([]string, 0, len(input))
+	for _, v := range input {
+		if u, err := url.Parse(v); err == nil && u.IsAbs() {
+			query := u.Query()
+			redacted := false
+			for _, name := range sensitiveQueryParameters {
+				if query.Has(name) {
+					query.Set(name, RecorderRedactedValue)
+					redacted = true
+				}
+			}
+			if redacted {
+				u.RawQuery = query.Encode()
+				v = u.String()
+			}
+		}
+		output = append(output, r.sanitize(v))
+	}
+	return output
+}
+
+func (r *Recorder) sanitize(input string) string {
+	for _, replacement := range r.replacements {
+		input = replaceCaseInsensitive(input, replacement.value, replacement.placeholder)
+	}
+	return input
+}
+
+func (r *Recorder) desanitize(input string) string {
+	for _, replacement := range r.replacements {
+		input = strings.ReplaceAll(input, replacement.placeholder, replacement.value)
+	}
+	return input
+}
+
+// redactJson replaces the values of any sensitive keys within the JSON value, the values within a `keys`
+// array (as returned by the `listKeys` APIs) are also redacted
+func redactJson(input interface{}, withinKeys bool) interface{} {
+	switch v := input.(type) {
+	case map[string]interface{}:
+		for key, val := range v {
+			if _, isString := val.(string); isString && (isSensitiveJsonKey(key) || (withinKeys && strings.EqualFold(key, "value"))) {
+				v[key] = RecorderRedactedValue
+				continue
+			}
+			v[key] = redactJson(val, strings.EqualFold(key, "keys"))
+		}
+		return v
+
+	case []interface{}:
+		for i, val := range v {
+			v[i] = redactJson(val, withinKeys)
+		}
+		return v
+	}
+
+	return input
+}
+
+func isSensitiveJsonKey(key string) bool {
+	return sensitiveJsonKeys.MatchString(key) && !nonSensitiveJsonKeys.MatchString(key)
+}
+
+func replaceCaseInsensitive(input, old, replacement string) string {
+	return regexp.MustCompile(`(?i)`+regexp.QuoteMeta(old)).ReplaceAllLiteralString(input, replacement)
+}
+
+func readRequestBody(request *http.Request) ([]byte, error) {
+	if request.Body == nil || request.Body == http.NoBody {
+		return nil, nil
+	}
+
+	body, err := io.ReadAll(request.Body)
+	if err != nil {
+		return nil, fmt.Errorf("reading request body: %+v", err)
+	}
+	request.Body.Close()
+	request.Body = io.NopCloser(bytes.NewReader(body))
+	return body, nil
+}
+
+// redactFormBody redacts the values of the sensitive fields within a URL-encoded form body (such as the
+// `access_token` exchanged for an Azure Container Registry refresh token), other bodies are returned as-is
+func redactFormBody(header http.Header, body []byte, redactedValue string, sensitive func(key string) bool) []byte {
+	if len(body) == 0 {
+		return body
+	}
+	if mediaType, _, err := mime.ParseMediaType(header.Get("Content-Type")); err != nil || mediaType != "application/x-www-form-urlencoded" {
+		return body
+	}
+
+	values, err := url.ParseQuery(string(body))
+	if err != nil {
+		return body
+	}
+
+	redacted := false
+	for key := range values {
+		if sensitive(key) {
+			values.Set(key, redactedValue)
+			redacted = true
+		}
+	}
+	if !redacted {
+		return body
+	}
+
+	return []byte(values.Encode())
+}
+
+// RecorderAuthorizer is an auth.Authorizer which issues an unsigned access token containing the placeholder
+// identifiers, which allows the provider to be configured when replaying without authenticating with Azure.
+type RecorderAuthorizer struct{}
+
+func (a *RecorderAuthorizer) Token(_ context.Context, _ *http.Request) (*oauth2.Token, error) {
+	header := base64.RawURLEncoding.EncodeToString([]byte(`{"alg":"none","typ":"JWT"}`))
+	claims, err := json.Marshal(map[string]string{
+		"appid": recorderClientIdPlaceholder,
+		"oid":   recorderObjectIdPlaceholder,
+		"tid":   recorderTenantIdPlaceholder,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &oauth2.Token{
+		AccessToken: fmt.Sprintf("%s.%s.%s", header, base64.RawURLEncoding.EncodeToString(claims), "recorder"),
+		TokenType:   "Bearer",
+	}, nil
+}
+
+func (a *RecorderAuthorizer) AuxiliaryTokens(_ context.Context, _ *http.Request) ([]*oauth2.Token, error) {
+	return nil, nil
+}
+
+// RecorderSubscriptionId returns the Subscription ID which is used when replaying and one hasn't been specified
+func RecorderSubscriptionId() string {
+	return recorderSubscriptionIdPlaceholder
+}
+
+type recorderContextKey struct{}
+
+// ContextWithRecorder returns a copy of ctx which carries the specified Recorder
+func ContextWithRecorder(ctx context.Context, recorder *Recorder) context.Context {
+	return context.WithValue(ctx, recorderContextKey{}, recorder)
+}
+
+// RecorderFromContext returns the Recorder carried by ctx, if any
+func RecorderFromContext(ctx context.Context) *Recorder {
+	if recorder, ok := ctx.Value(recorderContextKey{}).(*Recorder); ok {
+		return recorder
+	}
+	return nil
+}
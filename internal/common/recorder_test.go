@@ -0,0 +1,211 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package common
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/go-azure-sdk/sdk/client"
+)
+
+const recorderTestSubscriptionId = "11111111-2222-3333-4444-555555555555"
+
+func TestRecorderRecordAndReplay(t *testing.T) {
+	polls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodPut:
+			body, _ := io.ReadAll(r.Body)
+			if !strings.Contains(string(body), "hunter2") {
+				t.Errorf("expected the request body to be sent to the API unmodified but got %q", string(body))
+			}
+			w.Header().Set("Location", fmt.Sprintf("http://%s/subscriptions/%s/operations/abc", r.Host, recorderTestSubscriptionId))
+			w.Header().Set("Set-Cookie", "session=secret")
+			w.WriteHeader(http.StatusAccepted)
+
+		case strings.HasSuffix(r.URL.Path, "/operations/abc"):
+			polls++
+			if polls < 3 {
+				w.WriteHeader(http.StatusAccepted)
+				return
+			}
+			w.WriteHeader(http.StatusOK)
+			fmt.Fprintf(w, `{"id": "/subscriptions/%s/resourceGroups/example", "properties": {"primaryKey": "super-secret"}}`, recorderTestSubscriptionId)
+
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+
+	path := filepath.Join(t.TempDir(), "cassette.json")
+
+	recorder, err := NewRecorder(RecorderModeRecord, path)
+	if err != nil {
+		t.Fatalf("building recorder: %+v", err)
+	}
+	recorder.UseAccount(recorderTestSubscriptionId, "", "", "")
+	recorder.SetVariable("random_integer", "1234")
+
+	statuses := runRecorderTestRequests(t, server.URL, recorder)
+	if expected := []int{202, 202, 202, 200}; fmt.Sprint(statuses) != fmt.Sprint(expected) {
+		t.Fatalf("expected the statuses %v when recording but got %v", expected, statuses)
+	}
+
+	if err := recorder.Save(); err != nil {
+		t.Fatalf("saving cassette: %+v", err)
+	}
+	server.Close()
+
+	contents, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("reading cassette: %+v", err)
+	}
+	for _, value := range []string{"hunter2", "super-secret", recorderTestSubscriptionId, "Bearer", "session"} {
+		if strings.Contains(string(contents), value) {
+			t.Fatalf("expected the cassette not to contain %q but it did:\n%s", value, string(contents))
+		}
+	}
+
+	// the server is now closed, so the responses must come from the cassette
+	replayer, err := NewRecorder(RecorderModeReplay, path)
+	if err != nil {
+		t.Fatalf("building replayer: %+v", err)
+	}
+	defer replayer.Close()
+	replayer.UseAccount(recorderTestSubscriptionId, "", "", "")
+
+	if v, ok := replayer.Variable("random_integer"); !ok || v != "1234" {
+		t.Fatalf("expected the variable `random_integer` to be `1234` but got %q", v)
+	}
+
+	statuses = runRecorderTestRequests(t, server.URL, replayer)
+	if expected := []int{202, 202, 202, 200}; fmt.Sprint(statuses) != fmt.Sprint(expected) {
+		t.Fatalf("expected the statuses %v when replaying but got %v", expected, statuses)
+	}
+}
+
+func TestRecorderReplayUnmatchedRequest(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "cassette.json")
+	if err := os.WriteFile(path, []byte(`{"version": 1, "interactions": []}`), 0o644); err != nil {
+		t.Fatalf("writing cassette: %+v", err)
+	}
+
+	replayer, err := NewRecorder(RecorderModeReplay, path)
+	if err != nil {
+		t.Fatalf("building replayer: %+v", err)
+	}
+	defer replayer.Close()
+
+	c := client.NewClient("https://management.azure.com", "Example", "2020-01-01")
+	c.AppendRequestMiddleware(recorderRequestMiddleware(replayer))
+	c.AppendResponseMiddleware(recorderResponseMiddleware(replayer))
+
+	req, err := c.NewRequest(context.TODO(), client.RequestOptions{
+		ContentType:         "application/json; charset=utf-8",
+		ExpectedStatusCodes: []int{http.StatusOK},
+		HttpMethod:          http.MethodGet,
+		Path:                "/subscriptions/12345",
+	})
+	if err != nil {
+		t.Fatalf("building request: %+v", err)
+	}
+
+	if _, err := c.Execute(context.TODO(), req); err == nil || !strings.Contains(err.Error(), "no recorded interaction") {
+		t.Fatalf("expected an error about the missing interaction but got: %+v", err)
+	}
+}
+
+func TestRecorderCloseShutsDownReplayServer(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "cassette.json")
+	if err := os.WriteFile(path, []byte(`{"version": 1, "interactions": []}`), 0o644); err != nil {
+		t.Fatalf("writing cassette: %+v", err)
+	}
+
+	replayer, err := NewRecorder(RecorderModeReplay, path)
+	if err != nil {
+		t.Fatalf("building replayer: %+v", err)
+	}
+
+	serverURL, err := replayer.replayServer()
+	if err != nil {
+		t.Fatalf("starting replay server: %+v", err)
+	}
+
+	if err := replayer.Close(); err != nil {
+		t.Fatalf("closing replayer: %+v", err)
+	}
+
+	if conn, err := net.Dial("tcp", serverURL.Host); err == nil {
+		conn.Close()
+		t.Fatalf("expected the replay server at %q to have been shut down", serverURL.Host)
+	}
+}
+
+func TestRecorderNormalizeBody(t *testing.T) {
+	r := &Recorder{}
+	r.UseAccount(recorderTestSubscriptionId, "", "", "")
+
+	first := r.normalizeBody([]byte(fmt.Sprintf(`{"b": "/subscriptions/%s", "a": {"adminPassword": "abc", "keyVaultSecretId": "https://example"}}`, strings.ToUpper(recorderTestSubscriptionId))))
+	second := r.normalizeBody([]byte(`{"a": {"keyVaultSecretId": "https://example", "adminPassword": "def"}, "b": "/subscriptions/00000000-0000-0000-0000-000000000000"}`))
+	if first != second {
+		t.Fatalf("expected the normalized bodies to match but got %q and %q", first, second)
+	}
+
+	keys := r.normalizeBody([]byte(`{"keys": [{"keyName": "key1", "value": "abc"}]}`))
+	if expected := `{"keys":[{"keyName":"key1","value":"REDACTED"}]}`; keys != expected {
+		t.Fatalf("expected %q but got %q", expected, keys)
+	}
+}
+
+func runRecorderTestRequests(t *testing.T, baseUri string, recorder *Recorder) []int {
+	c := client.NewClient(baseUri, "Example", "2020-01-01")
+	c.AppendRequestMiddleware(recorderRequestMiddleware(recorder))
+	c.AppendResponseMiddleware(recorderResponseMiddleware(recorder))
+
+	execute := func(method, path string, body interface{}) int {
+		req, err := c.NewRequest(context.TODO(), client.RequestOptions{
+			ContentType:         "application/json; charset=utf-8",
+			ExpectedStatusCodes: []int{http.StatusOK, http.StatusAccepted},
+			HttpMethod:          method,
+			Path:                path,
+		})
+		if err != nil {
+			t.Fatalf("building request: %+v", err)
+		}
+		req.Header.Set("Authorization", "Bearer some-token")
+		if body != nil {
+			if err := req.Marshal(body); err != nil {
+				t.Fatalf("marshalling body: %+v", err)
+			}
+		}
+
+		resp, err := c.Execute(context.TODO(), req)
+		if err != nil {
+			t.Fatalf("executing request: %+v", err)
+		}
+		return resp.StatusCode
+	}
+
+	statuses := []int{
+		execute(http.MethodPut, fmt.Sprintf("/subscriptions/%s/resourceGroups/example", recorderTestSubscriptionId), map[string]interface{}{
+			"properties": map[string]interface{}{
+				"adminPassword": "hunter2",
+			},
+		}),
+	}
+	for i := 0; i < 3; i++ {
+		statuses = append(statuses, execute(http.MethodGet, fmt.Sprintf("/subscriptions/%s/operations/abc", recorderTestSubscriptionId), nil))
+	}
+
+	return statuses
+}
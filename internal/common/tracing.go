@@ -0,0 +1,132 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package common
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptrace"
+	"regexp"
+	"sync/atomic"
+
+	"github.com/hashicorp/go-azure-sdk/sdk/client"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tracing"
+)
+
+// pollingPaths matches the paths of the requests made when polling a Long Running Operation
+var pollingPaths = regexp.MustCompile(`(?i)/(operationStatuses|operationResults|operations|asyncOperations|azureAsyncOperation)/`)
+
+type httpSpanKey struct{}
+
+// httpSpan tracks the Span for a request made by a go-azure-sdk client, which can be sent multiple times
+type httpSpan struct {
+	span     *tracing.Span
+	attempts int32
+
+	// stop cancels ending the Span once the context for the request is done
+	stop func() bool
+}
+
+// tracingRequestMiddleware records a Span for each request sent by the go-azure-sdk clients. These clients build their
+// own transport for each request which can't be wrapped, so a single Span covers all of the attempts at sending it.
+func tracingRequestMiddleware() client.RequestMiddleware {
+	return func(request *http.Request) (*http.Request, error) {
+		ctx, span := startHttpSpan(request)
+		if span == nil {
+			return request, nil
+		}
+
+		// retries happen within the client, so count the connections obtained to determine how many times this was sent
+		s := &httpSpan{
+			span: span,
+		}
+		ctx = httptrace.WithClientTrace(ctx, &httptrace.ClientTrace{
+			GetConn: func(string) {
+				atomic.AddInt32(&s.attempts, 1)
+			},
+		})
+
+		// the Span is ended by the response middleware, however this isn't called when sending the request fails
+		// (e.g. the connection is reset) - so the Span is also ended once the context for the request is done
+		s.stop = context.AfterFunc(ctx, func() {
+			endHttpSpan(span, nil, fmt.Errorf("no response was received"))
+		})
+
+		return request.WithContext(context.WithValue(ctx, httpSpanKey{}, s)), nil
+	}
+}
+
+func tracingResponseMiddleware() client.ResponseMiddleware {
+	return func(request *http.Request, response *http.Response) (*http.Response, error) {
+		s, ok := request.Context().Value(httpSpanKey{}).(*httpSpan)
+		if !ok {
+			return response, nil
+		}
+
+		if !s.stop() {
+			// the Span has already been ended
+			return response, nil
+		}
+		if attempts := atomic.LoadInt32(&s.attempts); attempts > 1 {
+			s.span.SetAttributes(tracing.Int("http.request.resend_count", int(attempts-1)))
+		}
+		endHttpSpan(s.span, response, nil)
+
+		return response, nil
+	}
+}
+
+// tracingRoundTripper wraps the specified http.RoundTripper so that a Span is recorded for each attempt at sending
+// a request, which is ended regardless of the outcome
+func tracingRoundTripper(base http.RoundTripper) http.RoundTripper {
+	return &tracingTransport{
+		base: base,
+	}
+}
+
+type tracingTransport struct {
+	base http.RoundTripper
+}
+
+func (t *tracingTransport) RoundTrip(request *http.Request) (*http.Response, error) {
+	ctx, span := startHttpSpan(request)
+	if span == nil {
+		return t.base.RoundTrip(request)
+	}
+
+	response, err := t.base.RoundTrip(request.WithContext(ctx))
+	endHttpSpan(span, response, err)
+	return response, err
+}
+
+func startHttpSpan(request *http.Request) (context.Context, *tracing.Span) {
+	name := fmt.Sprintf("HTTP %s", request.Method)
+	poll := pollingPaths.MatchString(request.URL.Path)
+	if poll {
+		name = fmt.Sprintf("LRO Poll %s", request.Method)
+	}
+
+	return tracing.Start(request.Context(), name, tracing.SpanKindClient,
+		tracing.String("http.request.method", request.Method),
+		tracing.String("url.full", redactURL(request.URL).String()),
+		tracing.String("server.address", request.URL.Hostname()),
+		tracing.String("azurerm.correlation_request_id", request.Header.Get(HeaderCorrelationRequestID)),
+		tracing.Bool("azurerm.lro_poll", poll),
+	)
+}
+
+func endHttpSpan(span *tracing.Span, response *http.Response, err error) {
+	if response != nil {
+		span.SetAttributes(tracing.Int("http.response.status_code", response.StatusCode))
+		if v := response.Header.Get("x-ms-request-id"); v != "" {
+			span.SetAttributes(tracing.String("az.service_request_id", v))
+		}
+		if err == nil && response.StatusCode >= http.StatusBadRequest {
+			err = fmt.Errorf("unexpected status %d", response.StatusCode)
+		}
+	}
+	span.RecordError(err)
+	span.End()
+}
@@ -4,6 +4,11 @@
 package features
 
 func Default() UserFeatures {
+	softDelete := make(SoftDeleteFeatures)
+	for _, service := range SoftDeleteServices() {
+		softDelete[service] = DefaultSoftDeleteServiceFeatures()
+	}
+
 	return UserFeatures{
 		// NOTE: ensure all nested objects are fully populated
 		ApiManagement: ApiManagementFeatures{
@@ -82,5 +87,9 @@ func Default() UserFeatures {
 			DeleteBackupsOnBackupVaultDestroy: false,
 			PreventVolumeDestruction:          true,
 		},
+		KubernetesCluster: KubernetesClusterFeatures{
+			PersistKubeConfig: true,
+		},
+		SoftDelete: softDelete,
 	}
 }
@@ -0,0 +1,96 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package features
+
+// SoftDeleteService is the name of a service which supports Soft Delete, which is used as the name
+// of the nested block within the `soft_delete` block in the Provider `features` block
+type SoftDeleteService string
+
+const (
+	SoftDeleteServiceLogAnalyticsWorkspace SoftDeleteService = "log_analytics_workspace"
+	SoftDeleteServiceRecoveryServicesVault SoftDeleteService = "recovery_services_vault"
+	SoftDeleteServiceStorageBlob           SoftDeleteService = "storage_blob"
+	SoftDeleteServiceStorageContainer      SoftDeleteService = "storage_container"
+)
+
+// SoftDeleteServiceToggles specifies which of the Soft Delete lifecycle toggles are available for a SoftDeleteService
+type SoftDeleteServiceToggles struct {
+	PurgeOnDestroy     bool
+	RecoverSoftDeleted bool
+}
+
+// softDeleteServices contains the toggles which have been implemented for each SoftDeleteService, only these
+// toggles are exposed within the `features` block - so a service should only be added here once it's wired up
+var softDeleteServices = map[SoftDeleteService]SoftDeleteServiceToggles{
+	// a soft-deleted Log Analytics Workspace is recovered by Azure when one with the same name is created
+	SoftDeleteServiceLogAnalyticsWorkspace: {
+		PurgeOnDestroy: true,
+	},
+
+	// the items protected by a Recovery Services Vault are soft-deleted, rather than the Vault itself
+	SoftDeleteServiceRecoveryServicesVault: {
+		PurgeOnDestroy:     true,
+		RecoverSoftDeleted: true,
+	},
+
+	// a soft-deleted Blob can only be purged when permanent delete is enabled on the Storage Account
+	SoftDeleteServiceStorageBlob: {
+		PurgeOnDestroy:     true,
+		RecoverSoftDeleted: true,
+	},
+
+	// a soft-deleted Container can't be purged, instead it's removed once the retention period has passed
+	SoftDeleteServiceStorageContainer: {
+		RecoverSoftDeleted: true,
+	},
+}
+
+// SoftDeleteServices returns the services which expose the common Soft Delete lifecycle toggles
+func SoftDeleteServices() []SoftDeleteService {
+	return []SoftDeleteService{
+		SoftDeleteServiceLogAnalyticsWorkspace,
+		SoftDeleteServiceRecoveryServicesVault,
+		SoftDeleteServiceStorageBlob,
+		SoftDeleteServiceStorageContainer,
+	}
+}
+
+// Toggles returns the Soft Delete lifecycle toggles which are available for this service
+func (s SoftDeleteService) Toggles() SoftDeleteServiceToggles {
+	return softDeleteServices[s]
+}
+
+// IsKnown returns whether this is a known SoftDeleteService
+func (s SoftDeleteService) IsKnown() bool {
+	_, ok := softDeleteServices[s]
+	return ok
+}
+
+// SoftDeleteFeatures contains the Soft Delete lifecycle toggles for each SoftDeleteService
+type SoftDeleteFeatures map[SoftDeleteService]SoftDeleteServiceFeatures
+
+type SoftDeleteServiceFeatures struct {
+	// PurgeOnDestroy specifies whether the item should be permanently deleted (e.g. purged) when destroyed
+	PurgeOnDestroy bool
+
+	// RecoverSoftDeleted specifies whether a soft-deleted item should be recovered, rather than a new item created
+	RecoverSoftDeleted bool
+}
+
+// DefaultSoftDeleteServiceFeatures returns the Soft Delete toggles used when these aren't specified
+func DefaultSoftDeleteServiceFeatures() SoftDeleteServiceFeatures {
+	return SoftDeleteServiceFeatures{
+		PurgeOnDestroy:     false,
+		RecoverSoftDeleted: false,
+	}
+}
+
+// For returns the Soft Delete toggles for the specified service, falling back to the defaults
+func (f SoftDeleteFeatures) For(service SoftDeleteService) SoftDeleteServiceFeatures {
+	if v, ok := f[service]; ok {
+		return v
+	}
+
+	return DefaultSoftDeleteServiceFeatures()
+}
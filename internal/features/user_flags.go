@@ -22,6 +22,8 @@ type UserFeatures struct {
 	MachineLearning          MachineLearningFeatures
 	RecoveryService          RecoveryServiceFeatures
 	NetApp                   NetAppFeatures
+	KubernetesCluster        KubernetesClusterFeatures
+	SoftDelete               SoftDeleteFeatures
 }
 
 type CognitiveAccountFeatures struct {
@@ -115,3 +117,7 @@ type NetAppFeatures struct {
 	DeleteBackupsOnBackupVaultDestroy bool
 	PreventVolumeDestruction          bool
 }
+
+type KubernetesClusterFeatures struct {
+	PersistKubeConfig bool
+}
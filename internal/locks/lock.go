@@ -3,6 +3,12 @@
 
 package locks
 
+import (
+	"context"
+	"log"
+	"time"
+)
+
 // armMutexKV is the instance of MutexKV for ARM resources
 var armMutexKV = newMutexKV()
 
@@ -10,12 +16,26 @@ func ByID(id string) {
 	armMutexKV.Lock(id)
 }
 
+// ByIDWithContext locks the specified ID, waiting until either the lock is acquired or the
+// context is done (e.g. the resource timeout has expired) - in which case an error is returned
+// and the lock is not held.
+func ByIDWithContext(ctx context.Context, id string) error {
+	return armMutexKV.LockWithContext(ctx, id)
+}
+
 // handle the case of using the same name for different kinds of resources
 func ByName(name string, resourceType string) {
 	updatedName := resourceType + "." + name
 	armMutexKV.Lock(updatedName)
 }
 
+// ByNameWithContext locks the specified name for the resource type, waiting until either the lock
+// is acquired or the context is done - in which case an error is returned and the lock is not held.
+func ByNameWithContext(ctx context.Context, name string, resourceType string) error {
+	updatedName := resourceType + "." + name
+	return armMutexKV.LockWithContext(ctx, updatedName)
+}
+
 func MultipleByName(names *[]string, resourceType string) {
 	newSlice := removeDuplicatesFromStringArray(*names)
 
@@ -24,6 +44,24 @@ func MultipleByName(names *[]string, resourceType string) {
 	}
 }
 
+// MultipleByNameWithContext locks each of the specified names for the resource type, waiting until
+// either all of the locks are acquired or the context is done - in which case any locks acquired
+// so far are released and an error is returned.
+func MultipleByNameWithContext(ctx context.Context, names *[]string, resourceType string) error {
+	newSlice := removeDuplicatesFromStringArray(*names)
+
+	for i, name := range newSlice {
+		if err := ByNameWithContext(ctx, name, resourceType); err != nil {
+			for _, acquired := range newSlice[:i] {
+				UnlockByName(acquired, resourceType)
+			}
+			return err
+		}
+	}
+
+	return nil
+}
+
 func UnlockByID(id string) {
 	armMutexKV.Unlock(id)
 }
@@ -40,3 +78,39 @@ func UnlockMultipleByName(names *[]string, resourceType string) {
 		UnlockByName(name, resourceType)
 	}
 }
+
+// LogContentionSummary logs a summary of each lock which had to be waited on, ordered by the
+// total time spent waiting - which is visible when TF_LOG is set to DEBUG (or lower)
+func LogContentionSummary() {
+	summary := armMutexKV.contentionSummary()
+	if len(summary) == 0 {
+		log.Printf("[DEBUG] Lock Contention Summary: no locks were contended")
+		return
+	}
+
+	log.Printf("[DEBUG] Lock Contention Summary: %d lock(s) were contended", len(summary))
+	for _, item := range summary {
+		log.Printf("[DEBUG] Lock Contention Summary: %q - acquired %d time(s), waited on %d time(s), timed out %d time(s), total wait %s, max wait %s", item.Key, item.Acquisitions, item.Contended, item.Timeouts, item.TotalWait, item.MaxWait)
+	}
+}
+
+// LogContentionSummaryPeriodically logs the Lock Contention Summary at the specified interval until the context is done,
+// but only when locks have been waited on since the summary was last logged. This is logged whilst the provider is
+// running, since Terraform stops reading the logs from the provider once it's finished with it.
+func LogContentionSummaryPeriodically(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	lastLogged := 0
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if waits := armMutexKV.contentionCount(); waits != lastLogged {
+				lastLogged = waits
+				LogContentionSummary()
+			}
+		}
+	}
+}
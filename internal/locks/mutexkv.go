@@ -4,48 +4,250 @@
 package locks
 
 import (
+	"context"
+	"fmt"
 	"log"
+	"runtime"
+	"sort"
+	"strings"
 	"sync"
+	"time"
 )
 
+// waitLogInterval is how often a debug log is emitted whilst waiting on a contended lock
+var waitLogInterval = 30 * time.Second
+
 // mutexKV is a simple key/value store for arbitrary mutexes. It can be used to
 // serialize changes across arbitrary collaborators that share knowledge of the
 // keys they must serialize on.
 type mutexKV struct {
 	lock  sync.Mutex
-	store map[string]*sync.Mutex
+	store map[string]*keyedMutex
+}
+
+// keyedMutex is a mutex which can be acquired with a context, alongside details of
+// the current holder and statistics on how contended the mutex has been.
+// The fields other than `sem` are protected by the parent mutexKV's lock.
+type keyedMutex struct {
+	sem chan struct{}
+
+	holder     string
+	acquiredAt time.Time
+
+	acquisitions int
+	contended    int
+	timeouts     int
+	totalWait    time.Duration
+	maxWait      time.Duration
 }
 
 // Locks the mutex for the given key. Caller is responsible for calling Unlock
 // for the same key
 func (m *mutexKV) Lock(key string) {
+	// without a deadline this can only return once the lock has been acquired
+	_ = m.LockWithContext(context.Background(), key)
+}
+
+// LockWithContext locks the mutex for the given key, waiting until either the lock
+// is acquired or the context is done - in which case an error is returned and the
+// lock is not held. Caller is responsible for calling Unlock for the same key when
+// no error is returned.
+func (m *mutexKV) LockWithContext(ctx context.Context, key string) error {
+	caller := callerName()
+	mutex := m.get(key)
+
 	log.Printf("[DEBUG] Locking %q", key)
-	m.get(key).Lock()
-	log.Printf("[DEBUG] Locked %q", key)
+
+	start := time.Now()
+	select {
+	case mutex.sem <- struct{}{}:
+		m.acquired(mutex, caller, 0, false)
+		log.Printf("[DEBUG] Locked %q", key)
+		return nil
+	default:
+	}
+
+	holder, heldFor := m.holder(mutex)
+	log.Printf("[DEBUG] Waiting for lock on %q which is held by %s (held for %s)", key, holder, heldFor.Round(time.Millisecond))
+
+	ticker := time.NewTicker(waitLogInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case mutex.sem <- struct{}{}:
+			waited := time.Since(start)
+			m.acquired(mutex, caller, waited, true)
+			log.Printf("[DEBUG] Locked %q after waiting %s", key, waited.Round(time.Millisecond))
+			return nil
+
+		case <-ticker.C:
+			holder, heldFor := m.holder(mutex)
+			log.Printf("[DEBUG] Still waiting for lock on %q after %s, which is held by %s (held for %s)", key, time.Since(start).Round(time.Second), holder, heldFor.Round(time.Second))
+
+		case <-ctx.Done():
+			waited := time.Since(start)
+			holder, heldFor := m.holder(mutex)
+			m.timedOut(mutex, waited)
+			log.Printf("[DEBUG] Gave up waiting for lock on %q after %s, which is held by %s (held for %s)", key, waited.Round(time.Millisecond), holder, heldFor.Round(time.Millisecond))
+			return fmt.Errorf("waiting %s to acquire lock on %q, which is held by %s (held for %s): %+v", waited.Round(time.Millisecond), key, holder, heldFor.Round(time.Millisecond), ctx.Err())
+		}
+	}
 }
 
 // Unlock the mutex for the given key. Caller must have called Lock for the same key first
 func (m *mutexKV) Unlock(key string) {
 	log.Printf("[DEBUG] Unlocking %q", key)
-	m.get(key).Unlock()
-	log.Printf("[DEBUG] Unlocked %q", key)
+	mutex := m.get(key)
+
+	m.lock.Lock()
+	heldFor := time.Since(mutex.acquiredAt)
+	mutex.holder = ""
+	mutex.acquiredAt = time.Time{}
+	m.lock.Unlock()
+
+	select {
+	case <-mutex.sem:
+	default:
+		panic(fmt.Sprintf("unlock of unlocked key %q", key))
+	}
+	log.Printf("[DEBUG] Unlocked %q (held for %s)", key, heldFor.Round(time.Millisecond))
 }
 
 // Returns a mutex for the given key, no guarantee of its lock status
-func (m *mutexKV) get(key string) *sync.Mutex {
+func (m *mutexKV) get(key string) *keyedMutex {
 	m.lock.Lock()
 	defer m.lock.Unlock()
 	mutex, ok := m.store[key]
 	if !ok {
-		mutex = &sync.Mutex{}
+		mutex = &keyedMutex{
+			sem: make(chan struct{}, 1),
+		}
 		m.store[key] = mutex
 	}
 	return mutex
 }
 
+func (m *mutexKV) acquired(mutex *keyedMutex, caller string, waited time.Duration, contended bool) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	mutex.holder = caller
+	mutex.acquiredAt = time.Now()
+	mutex.acquisitions++
+	if contended {
+		mutex.contended++
+	}
+	mutex.totalWait += waited
+	if waited > mutex.maxWait {
+		mutex.maxWait = waited
+	}
+}
+
+func (m *mutexKV) timedOut(mutex *keyedMutex, waited time.Duration) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	mutex.timeouts++
+	mutex.totalWait += waited
+	if waited > mutex.maxWait {
+		mutex.maxWait = waited
+	}
+}
+
+// holder returns the name of the current holder of the mutex and how long it's been held for
+func (m *mutexKV) holder(mutex *keyedMutex) (string, time.Duration) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	if mutex.holder == "" {
+		// the lock has been released but not yet re-acquired
+		return "(unknown)", 0
+	}
+
+	return mutex.holder, time.Since(mutex.acquiredAt)
+}
+
+// contentionStats is a summary of how contended the mutex for a given key has been
+type contentionStats struct {
+	Key          string
+	Acquisitions int
+	Contended    int
+	Timeouts     int
+	TotalWait    time.Duration
+	MaxWait      time.Duration
+}
+
+// contentionSummary returns the statistics for each key which has been waited on,
+// ordered by the total time spent waiting (longest first)
+func (m *mutexKV) contentionSummary() []contentionStats {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	output := make([]contentionStats, 0)
+	for key, mutex := range m.store {
+		if mutex.contended == 0 && mutex.timeouts == 0 {
+			continue
+		}
+
+		output = append(output, contentionStats{
+			Key:          key,
+			Acquisitions: mutex.acquisitions,
+			Contended:    mutex.contended,
+			Timeouts:     mutex.timeouts,
+			TotalWait:    mutex.totalWait,
+			MaxWait:      mutex.maxWait,
+		})
+	}
+
+	sort.Slice(output, func(i, j int) bool {
+		if output[i].TotalWait == output[j].TotalWait {
+			return output[i].Key < output[j].Key
+		}
+		return output[i].TotalWait > output[j].TotalWait
+	})
+
+	return output
+}
+
+// contentionCount returns the total number of times any lock has been waited on (including timeouts)
+func (m *mutexKV) contentionCount() int {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	count := 0
+	for _, mutex := range m.store {
+		count += mutex.contended + mutex.timeouts
+	}
+
+	return count
+}
+
+// callerName returns the name of the first function outside of this package in the call stack,
+// which is used to identify the holder of a lock in the logs
+func callerName() string {
+	pcs := make([]uintptr, 10)
+	// skip runtime.Callers and callerName
+	n := runtime.Callers(2, pcs)
+	frames := runtime.CallersFrames(pcs[:n])
+	for {
+		frame, more := frames.Next()
+		if !strings.Contains(frame.Function, "/internal/locks.") {
+			name := frame.Function
+			if i := strings.LastIndex(name, "/"); i != -1 {
+				name = name[i+1:]
+			}
+			return name
+		}
+		if !more {
+			return "(unknown)"
+		}
+	}
+}
+
 // newMutexKV returns a properly initialized mutexKV
 func newMutexKV() *mutexKV {
 	return &mutexKV{
-		store: make(map[string]*sync.Mutex),
+		store: make(map[string]*keyedMutex),
 	}
 }
@@ -0,0 +1,84 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package locks
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestMutexKVLockWithContext(t *testing.T) {
+	m := newMutexKV()
+
+	if err := m.LockWithContext(context.Background(), "example"); err != nil {
+		t.Fatalf("expected no error acquiring an uncontended lock but got: %+v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	err := m.LockWithContext(ctx, "example")
+	if err == nil {
+		t.Fatalf("expected an error acquiring a held lock but didn't get one")
+	}
+	// functions within this package are skipped when determining the holder, so this is the test runner
+	if !strings.Contains(err.Error(), "held by testing.tRunner") {
+		t.Fatalf("expected the error to contain the name of the holder but got: %+v", err)
+	}
+
+	// other keys are unaffected
+	if err := m.LockWithContext(context.Background(), "other"); err != nil {
+		t.Fatalf("expected no error acquiring a different key but got: %+v", err)
+	}
+	m.Unlock("other")
+
+	acquired := make(chan error)
+	go func() {
+		acquired <- m.LockWithContext(context.Background(), "example")
+	}()
+
+	time.Sleep(10 * time.Millisecond)
+	m.Unlock("example")
+
+	select {
+	case err := <-acquired:
+		if err != nil {
+			t.Fatalf("expected no error acquiring a released lock but got: %+v", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatalf("timed out waiting for the released lock to be acquired")
+	}
+	m.Unlock("example")
+
+	summary := m.contentionSummary()
+	if len(summary) != 1 {
+		t.Fatalf("expected 1 contended key but got %d", len(summary))
+	}
+	if summary[0].Key != "example" || summary[0].Acquisitions != 2 || summary[0].Contended != 1 || summary[0].Timeouts != 1 {
+		t.Fatalf("unexpected contention summary: %+v", summary[0])
+	}
+	if count := m.contentionCount(); count != 2 {
+		t.Fatalf("expected a contention count of 2 but got %d", count)
+	}
+}
+
+func TestMultipleByNameWithContextReleasesOnError(t *testing.T) {
+	resourceType := "azurerm_example_multiple"
+	ByName("second", resourceType)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	if err := MultipleByNameWithContext(ctx, &[]string{"first", "second"}, resourceType); err == nil {
+		t.Fatalf("expected an error acquiring a held lock but didn't get one")
+	}
+
+	// "first" should have been released when "second" couldn't be acquired
+	if err := ByNameWithContext(ctx, "first", resourceType); err != nil {
+		t.Fatalf("expected no error re-acquiring a released lock but got: %+v", err)
+	}
+
+	UnlockByName("first", resourceType)
+	UnlockByName("second", resourceType)
+}
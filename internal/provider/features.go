@@ -180,10 +180,12 @@ func schemaFeatures(supportLegacyTestSuite bool) *pluginsdk.Schema {
 			MaxItems: 1,
 			Elem: &pluginsdk.Resource{
 				Schema: map[string]*pluginsdk.Schema{
+					// TODO: Remove `permanently_delete_on_destroy` in v5.0
 					"permanently_delete_on_destroy": {
-						Type:     pluginsdk.TypeBool,
-						Optional: true,
-						Default:  false,
+						Type:       pluginsdk.TypeBool,
+						Optional:   true,
+						Default:    false,
+						Deprecated: "This property is deprecated and will be removed in v5.0 of the AzureRM provider. Please use the `purge_on_destroy` property within the `log_analytics_workspace` block in the `soft_delete` block instead.",
 					},
 				},
 			},
@@ -285,10 +287,12 @@ func schemaFeatures(supportLegacyTestSuite bool) *pluginsdk.Schema {
 			MaxItems: 1,
 			Elem: &pluginsdk.Resource{
 				Schema: map[string]*schema.Schema{
+					// TODO: Remove `recover_soft_deleted_backup_protected_vm` in v5.0
 					"recover_soft_deleted_backup_protected_vm": {
-						Type:     pluginsdk.TypeBool,
-						Optional: true,
-						Default:  false,
+						Type:       pluginsdk.TypeBool,
+						Optional:   true,
+						Default:    false,
+						Deprecated: "This property is deprecated and will be removed in v5.0 of the AzureRM provider. Please use the `recover_soft_deleted` property within the `recovery_services_vault` block in the `soft_delete` block instead.",
 					},
 				},
 			},
@@ -379,10 +383,12 @@ func schemaFeatures(supportLegacyTestSuite bool) *pluginsdk.Schema {
 						Optional: true,
 						Default:  false,
 					},
+					// TODO: Remove `purge_protected_items_from_vault_on_destroy` in v5.0
 					"purge_protected_items_from_vault_on_destroy": {
-						Type:     pluginsdk.TypeBool,
-						Optional: true,
-						Default:  false,
+						Type:       pluginsdk.TypeBool,
+						Optional:   true,
+						Default:    false,
+						Deprecated: "This property is deprecated and will be removed in v5.0 of the AzureRM provider. Please use the `purge_on_destroy` property within the `recovery_services_vault` block in the `soft_delete` block instead.",
 					},
 				},
 			},
@@ -409,6 +415,59 @@ func schemaFeatures(supportLegacyTestSuite bool) *pluginsdk.Schema {
 				},
 			},
 		},
+
+		"kubernetes_cluster": {
+			Type:     pluginsdk.TypeList,
+			Optional: true,
+			MaxItems: 1,
+			Elem: &pluginsdk.Resource{
+				Schema: map[string]*pluginsdk.Schema{
+					"persist_kube_config": {
+						Description: "When disabled, the `kube_config` and `kube_admin_config` (and their `_raw` variants) will not be stored in the state of the `azurerm_kubernetes_cluster` resource",
+						Type:        pluginsdk.TypeBool,
+						Optional:    true,
+						Default:     true,
+					},
+				},
+			},
+		},
+	}
+
+	softDeleteServices := make(map[string]*pluginsdk.Schema)
+	for _, service := range features.SoftDeleteServices() {
+		// only the toggles which have been implemented for this service are exposed
+		toggles := make(map[string]*pluginsdk.Schema)
+		if service.Toggles().PurgeOnDestroy {
+			toggles["purge_on_destroy"] = &pluginsdk.Schema{
+				Type:     pluginsdk.TypeBool,
+				Optional: true,
+				Default:  features.DefaultSoftDeleteServiceFeatures().PurgeOnDestroy,
+			}
+		}
+		if service.Toggles().RecoverSoftDeleted {
+			toggles["recover_soft_deleted"] = &pluginsdk.Schema{
+				Type:     pluginsdk.TypeBool,
+				Optional: true,
+				Default:  features.DefaultSoftDeleteServiceFeatures().RecoverSoftDeleted,
+			}
+		}
+
+		softDeleteServices[string(service)] = &pluginsdk.Schema{
+			Type:     pluginsdk.TypeList,
+			Optional: true,
+			MaxItems: 1,
+			Elem: &pluginsdk.Resource{
+				Schema: toggles,
+			},
+		}
+	}
+	featuresMap["soft_delete"] = &pluginsdk.Schema{
+		Type:     pluginsdk.TypeList,
+		Optional: true,
+		MaxItems: 1,
+		Elem: &pluginsdk.Resource{
+			Schema: softDeleteServices,
+		},
 	}
 
 	// this is a temporary hack to enable us to gradually add provider blocks to test configurations
@@ -683,5 +742,38 @@ func expandFeatures(input []interface{}) features.UserFeatures {
 		}
 	}
 
+	if raw, ok := val["kubernetes_cluster"]; ok {
+		items := raw.([]interface{})
+		if len(items) > 0 {
+			kubernetesClusterRaw := items[0].(map[string]interface{})
+			if v, ok := kubernetesClusterRaw["persist_kube_config"]; ok {
+				featuresMap.KubernetesCluster.PersistKubeConfig = v.(bool)
+			}
+		}
+	}
+
+	if raw, ok := val["soft_delete"]; ok {
+		items := raw.([]interface{})
+		if len(items) > 0 && items[0] != nil {
+			softDeleteRaw := items[0].(map[string]interface{})
+			for _, service := range features.SoftDeleteServices() {
+				serviceItems, ok := softDeleteRaw[string(service)].([]interface{})
+				if !ok || len(serviceItems) == 0 || serviceItems[0] == nil {
+					continue
+				}
+
+				serviceFeatures := features.DefaultSoftDeleteServiceFeatures()
+				serviceRaw := serviceItems[0].(map[string]interface{})
+				if v, ok := serviceRaw["purge_on_destroy"]; ok {
+					serviceFeatures.PurgeOnDestroy = v.(bool)
+				}
+				if v, ok := serviceRaw["recover_soft_deleted"]; ok {
+					serviceFeatures.RecoverSoftDeleted = v.(bool)
+				}
+				featuresMap.SoftDelete[service] = serviceFeatures
+			}
+		}
+	}
+
 	return featuresMap
 }
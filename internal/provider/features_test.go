@@ -95,6 +95,15 @@ func TestExpandFeatures(t *testing.T) {
 					DeleteBackupsOnBackupVaultDestroy: false,
 					PreventVolumeDestruction:          true,
 				},
+				KubernetesCluster: features.KubernetesClusterFeatures{
+					PersistKubeConfig: true,
+				},
+				SoftDelete: features.SoftDeleteFeatures{
+					features.SoftDeleteServiceLogAnalyticsWorkspace: {PurgeOnDestroy: false, RecoverSoftDeleted: false},
+					features.SoftDeleteServiceRecoveryServicesVault: {PurgeOnDestroy: false, RecoverSoftDeleted: false},
+					features.SoftDeleteServiceStorageBlob:           {PurgeOnDestroy: false, RecoverSoftDeleted: false},
+					features.SoftDeleteServiceStorageContainer:      {PurgeOnDestroy: false, RecoverSoftDeleted: false},
+				},
 			},
 		},
 		{
@@ -211,6 +220,37 @@ func TestExpandFeatures(t *testing.T) {
 							"prevent_volume_destruction":             true,
 						},
 					},
+					"kubernetes_cluster": []interface{}{
+						map[string]interface{}{
+							"persist_kube_config": true,
+						},
+					},
+					"soft_delete": []interface{}{
+						map[string]interface{}{
+							"log_analytics_workspace": []interface{}{
+								map[string]interface{}{
+									"purge_on_destroy": true,
+								},
+							},
+							"recovery_services_vault": []interface{}{
+								map[string]interface{}{
+									"purge_on_destroy":     true,
+									"recover_soft_deleted": true,
+								},
+							},
+							"storage_blob": []interface{}{
+								map[string]interface{}{
+									"purge_on_destroy":     true,
+									"recover_soft_deleted": true,
+								},
+							},
+							"storage_container": []interface{}{
+								map[string]interface{}{
+									"recover_soft_deleted": true,
+								},
+							},
+						},
+					},
 				},
 			},
 			Expected: features.UserFeatures{
@@ -288,6 +328,15 @@ func TestExpandFeatures(t *testing.T) {
 					DeleteBackupsOnBackupVaultDestroy: true,
 					PreventVolumeDestruction:          true,
 				},
+				KubernetesCluster: features.KubernetesClusterFeatures{
+					PersistKubeConfig: true,
+				},
+				SoftDelete: features.SoftDeleteFeatures{
+					features.SoftDeleteServiceLogAnalyticsWorkspace: {PurgeOnDestroy: true, RecoverSoftDeleted: false},
+					features.SoftDeleteServiceRecoveryServicesVault: {PurgeOnDestroy: true, RecoverSoftDeleted: true},
+					features.SoftDeleteServiceStorageBlob:           {PurgeOnDestroy: true, RecoverSoftDeleted: true},
+					features.SoftDeleteServiceStorageContainer:      {PurgeOnDestroy: false, RecoverSoftDeleted: true},
+				},
 			},
 		},
 		{
@@ -404,6 +453,37 @@ func TestExpandFeatures(t *testing.T) {
 							"prevent_volume_destruction":             false,
 						},
 					},
+					"kubernetes_cluster": []interface{}{
+						map[string]interface{}{
+							"persist_kube_config": false,
+						},
+					},
+					"soft_delete": []interface{}{
+						map[string]interface{}{
+							"log_analytics_workspace": []interface{}{
+								map[string]interface{}{
+									"purge_on_destroy": false,
+								},
+							},
+							"recovery_services_vault": []interface{}{
+								map[string]interface{}{
+									"purge_on_destroy":     false,
+									"recover_soft_deleted": false,
+								},
+							},
+							"storage_blob": []interface{}{
+								map[string]interface{}{
+									"purge_on_destroy":     false,
+									"recover_soft_deleted": false,
+								},
+							},
+							"storage_container": []interface{}{
+								map[string]interface{}{
+									"recover_soft_deleted": false,
+								},
+							},
+						},
+					},
 				},
 			},
 			Expected: features.UserFeatures{
@@ -481,6 +561,15 @@ func TestExpandFeatures(t *testing.T) {
 					DeleteBackupsOnBackupVaultDestroy: false,
 					PreventVolumeDestruction:          false,
 				},
+				KubernetesCluster: features.KubernetesClusterFeatures{
+					PersistKubeConfig: false,
+				},
+				SoftDelete: features.SoftDeleteFeatures{
+					features.SoftDeleteServiceLogAnalyticsWorkspace: {PurgeOnDestroy: false, RecoverSoftDeleted: false},
+					features.SoftDeleteServiceRecoveryServicesVault: {PurgeOnDestroy: false, RecoverSoftDeleted: false},
+					features.SoftDeleteServiceStorageBlob:           {PurgeOnDestroy: false, RecoverSoftDeleted: false},
+					features.SoftDeleteServiceStorageContainer:      {PurgeOnDestroy: false, RecoverSoftDeleted: false},
+				},
 			},
 		},
 	}
@@ -1858,3 +1947,158 @@ func TestExpandFeaturesNetApp(t *testing.T) {
 		}
 	}
 }
+
+func TestExpandFeaturesKubernetesCluster(t *testing.T) {
+	testData := []struct {
+		Name     string
+		Input    []interface{}
+		EnvVars  map[string]interface{}
+		Expected features.UserFeatures
+	}{
+		{
+			Name: "Empty Block",
+			Input: []interface{}{
+				map[string]interface{}{
+					"kubernetes_cluster": []interface{}{},
+				},
+			},
+			Expected: features.UserFeatures{
+				KubernetesCluster: features.KubernetesClusterFeatures{
+					PersistKubeConfig: true,
+				},
+			},
+		},
+		{
+			Name: "Persist Kube Config Enabled",
+			Input: []interface{}{
+				map[string]interface{}{
+					"kubernetes_cluster": []interface{}{
+						map[string]interface{}{
+							"persist_kube_config": true,
+						},
+					},
+				},
+			},
+			Expected: features.UserFeatures{
+				KubernetesCluster: features.KubernetesClusterFeatures{
+					PersistKubeConfig: true,
+				},
+			},
+		},
+		{
+			Name: "Persist Kube Config Disabled",
+			Input: []interface{}{
+				map[string]interface{}{
+					"kubernetes_cluster": []interface{}{
+						map[string]interface{}{
+							"persist_kube_config": false,
+						},
+					},
+				},
+			},
+			Expected: features.UserFeatures{
+				KubernetesCluster: features.KubernetesClusterFeatures{
+					PersistKubeConfig: false,
+				},
+			},
+		},
+	}
+
+	for _, testCase := range testData {
+		t.Logf("[DEBUG] Test Case: %q", testCase.Name)
+		result := expandFeatures(testCase.Input)
+		if !reflect.DeepEqual(result.KubernetesCluster, testCase.Expected.KubernetesCluster) {
+			t.Fatalf("Expected %+v but got %+v", result.KubernetesCluster, testCase.Expected.KubernetesCluster)
+		}
+	}
+}
+
+func TestExpandFeaturesSoftDelete(t *testing.T) {
+	testData := []struct {
+		Name     string
+		Input    []interface{}
+		Service  features.SoftDeleteService
+		Expected features.SoftDeleteServiceFeatures
+	}{
+		{
+			Name: "Empty Block",
+			Input: []interface{}{
+				map[string]interface{}{
+					"soft_delete": []interface{}{},
+				},
+			},
+			Service: features.SoftDeleteServiceLogAnalyticsWorkspace,
+			Expected: features.SoftDeleteServiceFeatures{
+				PurgeOnDestroy:     false,
+				RecoverSoftDeleted: false,
+			},
+		},
+		{
+			Name: "Empty Service Block",
+			Input: []interface{}{
+				map[string]interface{}{
+					"soft_delete": []interface{}{
+						map[string]interface{}{
+							"log_analytics_workspace": []interface{}{},
+						},
+					},
+				},
+			},
+			Service: features.SoftDeleteServiceLogAnalyticsWorkspace,
+			Expected: features.SoftDeleteServiceFeatures{
+				PurgeOnDestroy:     false,
+				RecoverSoftDeleted: false,
+			},
+		},
+		{
+			Name: "Purge On Destroy Enabled",
+			Input: []interface{}{
+				map[string]interface{}{
+					"soft_delete": []interface{}{
+						map[string]interface{}{
+							"log_analytics_workspace": []interface{}{
+								map[string]interface{}{
+									"purge_on_destroy": true,
+								},
+							},
+						},
+					},
+				},
+			},
+			Service: features.SoftDeleteServiceLogAnalyticsWorkspace,
+			Expected: features.SoftDeleteServiceFeatures{
+				PurgeOnDestroy:     true,
+				RecoverSoftDeleted: false,
+			},
+		},
+		{
+			Name: "Recover Soft Deleted Enabled",
+			Input: []interface{}{
+				map[string]interface{}{
+					"soft_delete": []interface{}{
+						map[string]interface{}{
+							"storage_container": []interface{}{
+								map[string]interface{}{
+									"recover_soft_deleted": true,
+								},
+							},
+						},
+					},
+				},
+			},
+			Service: features.SoftDeleteServiceStorageContainer,
+			Expected: features.SoftDeleteServiceFeatures{
+				PurgeOnDestroy:     false,
+				RecoverSoftDeleted: true,
+			},
+		},
+	}
+
+	for _, testCase := range testData {
+		t.Logf("[DEBUG] Test Case: %q", testCase.Name)
+		result := expandFeatures(testCase.Input)
+		if !reflect.DeepEqual(result.SoftDelete.For(testCase.Service), testCase.Expected) {
+			t.Fatalf("Expected %+v but got %+v", testCase.Expected, result.SoftDelete.For(testCase.Service))
+		}
+	}
+}
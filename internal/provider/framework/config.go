@@ -6,12 +6,14 @@ package framework
 import (
 	"context"
 	"os"
+	"strings"
 	"time"
 
 	"github.com/hashicorp/go-azure-helpers/resourcemanager/commonids"
 	"github.com/hashicorp/go-azure-sdk/sdk/auth"
 	"github.com/hashicorp/go-azure-sdk/sdk/environments"
 	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/clients"
 	providerfeatures "github.com/hashicorp/terraform-provider-azurerm/internal/features"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/provider"
@@ -124,6 +126,18 @@ func (p *ProviderConfig) Load(ctx context.Context, data *ProviderModel, tfVersio
 	p.clientBuilder.PartnerID = partnerId
 	p.clientBuilder.DisableCorrelationRequestID = getEnvBoolOrDefault(data.DisableCorrelationRequestId, "ARM_DISABLE_CORRELATION_REQUEST_ID", false)
 	p.clientBuilder.DisableTerraformPartnerID = getEnvBoolOrDefault(data.DisableTerraformPartnerId, "ARM_DISABLE_TERRAFORM_PARTNER_ID", false)
+
+	maxConcurrentRequests, err := getEnvIntOrDefault(data.MaxConcurrentRequests, "ARM_MAX_CONCURRENT_REQUESTS", 0)
+	if err != nil {
+		diags.Append(diag.NewErrorDiagnostic("parsing ARM_MAX_CONCURRENT_REQUESTS", err.Error()))
+		return
+	}
+	if maxConcurrentRequests < 0 {
+		diags.Append(diag.NewErrorDiagnostic("validating `max_concurrent_requests`", "`max_concurrent_requests` must be at least 0"))
+		return
+	}
+	p.clientBuilder.MaxConcurrentRequests = maxConcurrentRequests
+
 	p.clientBuilder.StorageUseAzureAD = getEnvBoolOrDefault(data.StorageUseAzureAD, "ARM_STORAGE_USE_AZUREAD", false)
 
 	f := providerfeatures.UserFeatures{}
@@ -503,11 +517,76 @@ func (p *ProviderConfig) Load(ctx context.Context, data *ProviderModel, tfVersio
 			f.NetApp.DeleteBackupsOnBackupVaultDestroy = false
 			f.NetApp.PreventVolumeDestruction = true
 		}
+
+		if !features.KubernetesCluster.IsNull() && !features.KubernetesCluster.IsUnknown() {
+			var feature []KubernetesCluster
+			d := features.KubernetesCluster.ElementsAs(ctx, &feature, true)
+			diags.Append(d...)
+			if diags.HasError() {
+				return
+			}
+
+			f.KubernetesCluster.PersistKubeConfig = true
+			if !feature[0].PersistKubeConfig.IsNull() && !feature[0].PersistKubeConfig.IsUnknown() {
+				f.KubernetesCluster.PersistKubeConfig = feature[0].PersistKubeConfig.ValueBool()
+			}
+		} else {
+			f.KubernetesCluster.PersistKubeConfig = true
+		}
+
+		f.SoftDelete = make(providerfeatures.SoftDeleteFeatures)
+		for _, service := range providerfeatures.SoftDeleteServices() {
+			f.SoftDelete[service] = providerfeatures.DefaultSoftDeleteServiceFeatures()
+		}
+		if !features.SoftDelete.IsNull() && !features.SoftDelete.IsUnknown() {
+			var feature []SoftDelete
+			d := features.SoftDelete.ElementsAs(ctx, &feature, true)
+			diags.Append(d...)
+			if diags.HasError() {
+				return
+			}
+
+			if len(feature) > 0 {
+				services := map[providerfeatures.SoftDeleteService]types.List{
+					providerfeatures.SoftDeleteServiceLogAnalyticsWorkspace: feature[0].LogAnalyticsWorkspace,
+					providerfeatures.SoftDeleteServiceRecoveryServicesVault: feature[0].RecoveryServicesVault,
+					providerfeatures.SoftDeleteServiceStorageBlob:           feature[0].StorageBlob,
+					providerfeatures.SoftDeleteServiceStorageContainer:      feature[0].StorageContainer,
+				}
+				for service, raw := range services {
+					if raw.IsNull() || raw.IsUnknown() {
+						continue
+					}
+
+					// the toggles available differ by service, so these are retrieved by name
+					var serviceFeature []types.Object
+					d := raw.ElementsAs(ctx, &serviceFeature, true)
+					diags.Append(d...)
+					if diags.HasError() {
+						return
+					}
+					if len(serviceFeature) == 0 {
+						continue
+					}
+
+					serviceFeatures := providerfeatures.DefaultSoftDeleteServiceFeatures()
+					toggles := serviceFeature[0].Attributes()
+					if v, ok := toggles["purge_on_destroy"].(types.Bool); ok && !v.IsNull() && !v.IsUnknown() {
+						serviceFeatures.PurgeOnDestroy = v.ValueBool()
+					}
+					if v, ok := toggles["recover_soft_deleted"].(types.Bool); ok && !v.IsNull() && !v.IsUnknown() {
+						serviceFeatures.RecoverSoftDeleted = v.ValueBool()
+					}
+					f.SoftDelete[service] = serviceFeatures
+				}
+			}
+		}
 	}
 
 	p.clientBuilder.Features = f
 	p.clientBuilder.AuthConfig = authConfig
 	p.clientBuilder.CustomCorrelationRequestID = os.Getenv("ARM_CORRELATION_REQUEST_ID")
+	p.clientBuilder.LogRedactionPatterns = strings.Split(os.Getenv("ARM_LOG_REDACTION_PATTERNS"), ",")
 	p.clientBuilder.TerraformVersion = tfVersion
 
 	client, err := clients.Build(ctx, p.clientBuilder)
@@ -13,6 +13,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	providerfeatures "github.com/hashicorp/terraform-provider-azurerm/internal/features"
 )
 
 var testConfig = ProviderConfig{}
@@ -210,6 +211,20 @@ func TestProviderConfig_LoadDefault(t *testing.T) {
 	if !features.NetApp.PreventVolumeDestruction {
 		t.Errorf("expected netapp.PreventVolumeDestruction to be true")
 	}
+
+	if !features.KubernetesCluster.PersistKubeConfig {
+		t.Errorf("expected kubernetes_cluster.PersistKubeConfig to be true")
+	}
+
+	for _, service := range providerfeatures.SoftDeleteServices() {
+		if features.SoftDelete.For(service).PurgeOnDestroy {
+			t.Errorf("expected soft_delete.%s.purge_on_destroy to be false", service)
+		}
+
+		if features.SoftDelete.For(service).RecoverSoftDeleted {
+			t.Errorf("expected soft_delete.%s.recover_soft_deleted to be false", service)
+		}
+	}
 }
 
 // TODO - helper functions to make setting up test date more easily so we can add more configuration coverage
@@ -323,6 +338,25 @@ func defaultFeaturesList() types.List {
 	})
 	netappList, _ := basetypes.NewListValue(types.ObjectType{}.WithAttributeTypes(NetAppAttributes), []attr.Value{netapp})
 
+	kubernetesCluster, _ := basetypes.NewObjectValueFrom(context.Background(), KubernetesClusterAttributes, map[string]attr.Value{
+		"persist_kube_config": basetypes.NewBoolNull(),
+	})
+	kubernetesClusterList, _ := basetypes.NewListValue(types.ObjectType{}.WithAttributeTypes(KubernetesClusterAttributes), []attr.Value{kubernetesCluster})
+
+	softDeleteServices := make(map[string]attr.Value)
+	for _, service := range providerfeatures.SoftDeleteServices() {
+		serviceAttributes := SoftDeleteServiceAttributes(service)
+		toggles := make(map[string]attr.Value)
+		for name := range serviceAttributes {
+			toggles[name] = basetypes.NewBoolNull()
+		}
+		softDeleteService, _ := basetypes.NewObjectValueFrom(context.Background(), serviceAttributes, toggles)
+		softDeleteServices[string(service)], _ = basetypes.NewListValue(types.ObjectType{}.WithAttributeTypes(serviceAttributes), []attr.Value{softDeleteService})
+	}
+
+	softDelete, _ := basetypes.NewObjectValueFrom(context.Background(), SoftDeleteAttributes, softDeleteServices)
+	softDeleteList, _ := basetypes.NewListValue(types.ObjectType{}.WithAttributeTypes(SoftDeleteAttributes), []attr.Value{softDelete})
+
 	fData, d := basetypes.NewObjectValue(FeaturesAttributes, map[string]attr.Value{
 		"api_management":             apiManagementList,
 		"app_configuration":          appConfigurationList,
@@ -342,6 +376,8 @@ func defaultFeaturesList() types.List {
 		"recovery_service":           recoveryServicesList,
 		"recovery_services_vaults":   recoveryServicesVaultsList,
 		"netapp":                     netappList,
+		"kubernetes_cluster":         kubernetesClusterList,
+		"soft_delete":                softDeleteList,
 	})
 
 	fmt.Printf("%+v", d)
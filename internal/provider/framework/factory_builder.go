@@ -10,8 +10,10 @@ import (
 	"github.com/hashicorp/terraform-plugin-go/tfprotov5"
 	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
 	"github.com/hashicorp/terraform-plugin-mux/tf5muxserver"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-testing/echoprovider"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/common"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/provider"
 )
 
@@ -48,6 +50,14 @@ func ProtoV5ProviderFactoriesInit(ctx context.Context, providerNames ...string)
 func ProtoV5ProviderServerFactory(ctx context.Context) (func() tfprotov5.ProviderServer, *schema.Provider, error) {
 	v2Provider := provider.AzureProvider()
 
+	// when the acceptance tests are recording/replaying, the Recorder needs to be available when configuring the clients
+	if recorder := common.RecorderFromContext(ctx); recorder != nil {
+		configure := v2Provider.ConfigureContextFunc
+		v2Provider.ConfigureContextFunc = func(ctx context.Context, d *schema.ResourceData) (interface{}, diag.Diagnostics) {
+			return configure(common.ContextWithRecorder(ctx, recorder), d)
+		}
+	}
+
 	providers := []func() tfprotov5.ProviderServer{
 		v2Provider.GRPCProvider,
 		providerserver.NewProtocol5(NewFrameworkProvider(v2Provider)),
@@ -8,6 +8,7 @@ import (
 	"encoding/base64"
 	"fmt"
 	"os"
+	"strconv"
 	"strings"
 
 	"github.com/hashicorp/terraform-plugin-framework/types"
@@ -155,6 +156,22 @@ func getEnvBoolIfValueAbsent(val types.Bool, envVar string) bool {
 	return val.ValueBool()
 }
 
+// getEnvIntOrDefault takes a Framework Int64Value and a corresponding Environment Variable name and returns
+// either the value set in the Int64Value if not Null / Unknown _or_ the value of the Environment Variable
+// provided. If both of these are empty, the default value is returned.
+func getEnvIntOrDefault(val types.Int64, envVar string, def int) (int, error) {
+	if val.IsNull() || val.IsUnknown() {
+		v := os.Getenv(envVar)
+		if v == "" {
+			return def, nil
+		}
+
+		return strconv.Atoi(v)
+	}
+
+	return int(val.ValueInt64()), nil
+}
+
 func getEnvBoolOrDefault(val types.Bool, envVar string, def bool) bool {
 	if val.IsNull() || val.IsUnknown() {
 		v := os.Getenv(envVar)
@@ -6,6 +6,7 @@ package framework
 import (
 	"github.com/hashicorp/terraform-plugin-framework/attr"
 	"github.com/hashicorp/terraform-plugin-framework/types"
+	providerfeatures "github.com/hashicorp/terraform-provider-azurerm/internal/features"
 )
 
 type ProviderModel struct {
@@ -33,6 +34,7 @@ type ProviderModel struct {
 	PartnerId                     types.String `tfsdk:"partner_id"`
 	DisableCorrelationRequestId   types.Bool   `tfsdk:"disable_correlation_request_id"`
 	DisableTerraformPartnerId     types.Bool   `tfsdk:"disable_terraform_partner_id"`
+	MaxConcurrentRequests         types.Int64  `tfsdk:"max_concurrent_requests"`
 	StorageUseAzureAD             types.Bool   `tfsdk:"storage_use_azuread"`
 	Features                      types.List   `tfsdk:"features"`
 	SkipProviderRegistration      types.Bool   `tfsdk:"skip_provider_registration"` // TODO - Remove in 5.0
@@ -59,6 +61,8 @@ type Features struct {
 	RecoveryService          types.List `tfsdk:"recovery_service"`
 	RecoveryServicesVaults   types.List `tfsdk:"recovery_services_vaults"`
 	NetApp                   types.List `tfsdk:"netapp"`
+	KubernetesCluster        types.List `tfsdk:"kubernetes_cluster"`
+	SoftDelete               types.List `tfsdk:"soft_delete"`
 }
 
 // FeaturesAttributes and the other block attribute vars are required for unit testing on the Load func
@@ -82,6 +86,8 @@ var FeaturesAttributes = map[string]attr.Type{
 	"recovery_service":           types.ListType{}.WithElementType(types.ObjectType{}.WithAttributeTypes(RecoveryServiceAttributes)),
 	"recovery_services_vaults":   types.ListType{}.WithElementType(types.ObjectType{}.WithAttributeTypes(RecoveryServiceVaultsAttributes)),
 	"netapp":                     types.ListType{}.WithElementType(types.ObjectType{}.WithAttributeTypes(NetAppAttributes)),
+	"kubernetes_cluster":         types.ListType{}.WithElementType(types.ObjectType{}.WithAttributeTypes(KubernetesClusterAttributes)),
+	"soft_delete":                types.ListType{}.WithElementType(types.ObjectType{}.WithAttributeTypes(SoftDeleteAttributes)),
 }
 
 type APIManagement struct {
@@ -267,3 +273,38 @@ var NetAppAttributes = map[string]attr.Type{
 	"delete_backups_on_backup_vault_destroy": types.BoolType,
 	"prevent_volume_destruction":             types.BoolType,
 }
+
+type KubernetesCluster struct {
+	PersistKubeConfig types.Bool `tfsdk:"persist_kube_config"`
+}
+
+var KubernetesClusterAttributes = map[string]attr.Type{
+	"persist_kube_config": types.BoolType,
+}
+
+type SoftDelete struct {
+	LogAnalyticsWorkspace types.List `tfsdk:"log_analytics_workspace"`
+	RecoveryServicesVault types.List `tfsdk:"recovery_services_vault"`
+	StorageBlob           types.List `tfsdk:"storage_blob"`
+	StorageContainer      types.List `tfsdk:"storage_container"`
+}
+
+var SoftDeleteAttributes = map[string]attr.Type{
+	"log_analytics_workspace": types.ListType{}.WithElementType(types.ObjectType{}.WithAttributeTypes(SoftDeleteServiceAttributes(providerfeatures.SoftDeleteServiceLogAnalyticsWorkspace))),
+	"recovery_services_vault": types.ListType{}.WithElementType(types.ObjectType{}.WithAttributeTypes(SoftDeleteServiceAttributes(providerfeatures.SoftDeleteServiceRecoveryServicesVault))),
+	"storage_blob":            types.ListType{}.WithElementType(types.ObjectType{}.WithAttributeTypes(SoftDeleteServiceAttributes(providerfeatures.SoftDeleteServiceStorageBlob))),
+	"storage_container":       types.ListType{}.WithElementType(types.ObjectType{}.WithAttributeTypes(SoftDeleteServiceAttributes(providerfeatures.SoftDeleteServiceStorageContainer))),
+}
+
+// SoftDeleteServiceAttributes returns the attributes for the Soft Delete toggles which are available for the specified service
+func SoftDeleteServiceAttributes(service providerfeatures.SoftDeleteService) map[string]attr.Type {
+	attributes := make(map[string]attr.Type)
+	if service.Toggles().PurgeOnDestroy {
+		attributes["purge_on_destroy"] = types.BoolType
+	}
+	if service.Toggles().RecoverSoftDeleted {
+		attributes["recover_soft_deleted"] = types.BoolType
+	}
+
+	return attributes
+}
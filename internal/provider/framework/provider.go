@@ -5,6 +5,7 @@ package framework
 
 import (
 	"context"
+	"sync"
 
 	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
@@ -16,6 +17,8 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
+	pluginsdkschema "github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	providerfeatures "github.com/hashicorp/terraform-provider-azurerm/internal/features"
 	providerfunction "github.com/hashicorp/terraform-provider-azurerm/internal/provider/function"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/resourceproviders"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/sdk/frameworkhelpers"
@@ -36,11 +39,34 @@ var _ provider.ProviderWithEphemeralResources = &azureRmFrameworkProvider{}
 
 func (p *azureRmFrameworkProvider) Functions(_ context.Context) []func() function.Function {
 	return []func() function.Function{
+		providerfunction.NewBuildResourceIDFunction,
 		providerfunction.NewNormaliseResourceIDFunction,
 		providerfunction.NewParseResourceIDFunction,
+		func() function.Function {
+			return providerfunction.NewValidateResourceIDFunction(p.pluginSdkResources)
+		},
 	}
 }
 
+var (
+	// fallbackPluginSdkResources is built at most once, since building the Plugin SDKv2 provider is expensive
+	fallbackPluginSdkResources     map[string]*pluginsdkschema.Resource
+	fallbackPluginSdkResourcesOnce sync.Once
+)
+
+// pluginSdkResources returns the Plugin SDKv2 resources exposed by the provider, which is required to look up the
+// ID validation used by each resource at import time
+func (p *azureRmFrameworkProvider) pluginSdkResources() map[string]*pluginsdkschema.Resource {
+	if v, ok := p.V2Provider.(*pluginsdkschema.Provider); ok && v != nil {
+		return v.ResourcesMap
+	}
+
+	fallbackPluginSdkResourcesOnce.Do(func() {
+		fallbackPluginSdkResources = pluginsdkprovider.AzureProvider().ResourcesMap
+	})
+	return fallbackPluginSdkResources
+}
+
 func NewFrameworkProvider(primary interface{ Meta() interface{} }) provider.Provider {
 	return &azureRmFrameworkProvider{
 		V2Provider: primary,
@@ -188,6 +214,11 @@ func (p *azureRmFrameworkProvider) Schema(_ context.Context, _ provider.SchemaRe
 				Description: "This will disable the Terraform Partner ID which is used if a custom `partner_id` isn't specified.",
 			},
 
+			"max_concurrent_requests": schema.Int64Attribute{
+				Optional:    true,
+				Description: "The maximum number of concurrent requests which should be made to Azure. Defaults to `0`, which is unlimited.",
+			},
+
 			// Advanced feature flags
 			"skip_provider_registration": schema.BoolAttribute{
 				Optional:           true,
@@ -342,8 +373,10 @@ func (p *azureRmFrameworkProvider) Schema(_ context.Context, _ provider.SchemaRe
 						"log_analytics_workspace": schema.ListNestedBlock{
 							NestedObject: schema.NestedBlockObject{
 								Attributes: map[string]schema.Attribute{
+									// TODO: Remove `permanently_delete_on_destroy` in v5.0
 									"permanently_delete_on_destroy": schema.BoolAttribute{
-										Optional: true,
+										Optional:           true,
+										DeprecationMessage: "This property is deprecated and will be removed in v5.0 of the AzureRM provider. Please use the `purge_on_destroy` property within the `log_analytics_workspace` block in the `soft_delete` block instead.",
 									},
 								},
 							},
@@ -453,8 +486,10 @@ func (p *azureRmFrameworkProvider) Schema(_ context.Context, _ provider.SchemaRe
 									"vm_backup_stop_protection_and_retain_data_on_destroy": schema.BoolAttribute{
 										Optional: true,
 									},
+									// TODO: Remove `purge_protected_items_from_vault_on_destroy` in v5.0
 									"purge_protected_items_from_vault_on_destroy": schema.BoolAttribute{
-										Optional: true,
+										Optional:           true,
+										DeprecationMessage: "This property is deprecated and will be removed in v5.0 of the AzureRM provider. Please use the `purge_on_destroy` property within the `recovery_services_vault` block in the `soft_delete` block instead.",
 									},
 								},
 							},
@@ -462,8 +497,10 @@ func (p *azureRmFrameworkProvider) Schema(_ context.Context, _ provider.SchemaRe
 						"recovery_services_vaults": schema.ListNestedBlock{
 							NestedObject: schema.NestedBlockObject{
 								Attributes: map[string]schema.Attribute{
+									// TODO: Remove `recover_soft_deleted_backup_protected_vm` in v5.0
 									"recover_soft_deleted_backup_protected_vm": schema.BoolAttribute{
-										Optional: true,
+										Optional:           true,
+										DeprecationMessage: "This property is deprecated and will be removed in v5.0 of the AzureRM provider. Please use the `recover_soft_deleted` property within the `recovery_services_vault` block in the `soft_delete` block instead.",
 									},
 								},
 							},
@@ -482,6 +519,21 @@ func (p *azureRmFrameworkProvider) Schema(_ context.Context, _ provider.SchemaRe
 								},
 							},
 						},
+						"kubernetes_cluster": schema.ListNestedBlock{
+							NestedObject: schema.NestedBlockObject{
+								Attributes: map[string]schema.Attribute{
+									"persist_kube_config": schema.BoolAttribute{
+										Description: "When disabled, the `kube_config` and `kube_admin_config` (and their `_raw` variants) will not be stored in the state of the `azurerm_kubernetes_cluster` resource",
+										Optional:    true,
+									},
+								},
+							},
+						},
+						"soft_delete": schema.ListNestedBlock{
+							NestedObject: schema.NestedBlockObject{
+								Blocks: softDeleteServiceBlocks(),
+							},
+						},
 					},
 				},
 			},
@@ -489,6 +541,28 @@ func (p *azureRmFrameworkProvider) Schema(_ context.Context, _ provider.SchemaRe
 	}
 }
 
+// softDeleteServiceBlocks returns the `soft_delete` nested block for each service supporting the common Soft Delete toggles
+func softDeleteServiceBlocks() map[string]schema.Block {
+	blocks := make(map[string]schema.Block)
+	for _, service := range providerfeatures.SoftDeleteServices() {
+		// only the toggles which have been implemented for this service are exposed
+		attributes := make(map[string]schema.Attribute)
+		for name := range SoftDeleteServiceAttributes(service) {
+			attributes[name] = schema.BoolAttribute{
+				Optional: true,
+			}
+		}
+
+		blocks[string(service)] = schema.ListNestedBlock{
+			NestedObject: schema.NestedBlockObject{
+				Attributes: attributes,
+			},
+		}
+	}
+
+	return blocks
+}
+
 func (p *azureRmFrameworkProvider) Configure(ctx context.Context, request provider.ConfigureRequest, response *provider.ConfigureResponse) {
 	var data ProviderModel
 
@@ -0,0 +1,130 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package function
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/go-azure-helpers/resourcemanager/recaser"
+	"github.com/hashicorp/go-azure-helpers/resourcemanager/resourceids"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+type BuildResourceIDFunction struct{}
+
+var _ function.Function = BuildResourceIDFunction{}
+
+func NewBuildResourceIDFunction() function.Function {
+	return &BuildResourceIDFunction{}
+}
+
+func (b BuildResourceIDFunction) Metadata(_ context.Context, _ function.MetadataRequest, response *function.MetadataResponse) {
+	response.Name = "build_resource_id"
+}
+
+func (b BuildResourceIDFunction) Definition(_ context.Context, _ function.DefinitionRequest, response *function.DefinitionResponse) {
+	response.Definition = function.Definition{
+		Summary:             "build_resource_id",
+		Description:         "Builds a correctly cased Azure Resource Manager ID from a resource type, a scope and the names of the resource and its parents",
+		MarkdownDescription: "Builds a correctly cased Azure Resource Manager ID from a resource type, a scope and the names of the resource and its parents",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:                "resource_type",
+				Description:         "Full resource type, including the resource provider namespace, e.g. Microsoft.Network/virtualNetworks/subnets",
+				MarkdownDescription: "Full resource type, including the resource provider namespace, e.g. `Microsoft.Network/virtualNetworks/subnets`",
+			},
+			function.StringParameter{
+				Name:                "scope",
+				Description:         "The scope the resource is created in, e.g. a Resource Group ID. May be empty for tenant level resources",
+				MarkdownDescription: "The scope the resource is created in, e.g. a Resource Group ID. May be empty for tenant level resources",
+			},
+			function.ListParameter{
+				Name:                "names",
+				ElementType:         types.StringType,
+				Description:         "The names of the parent resources, in order, followed by the name of the resource itself",
+				MarkdownDescription: "The names of the parent resources, in order, followed by the name of the resource itself",
+			},
+		},
+		Return: function.StringReturn{},
+	}
+}
+
+func (b BuildResourceIDFunction) Run(ctx context.Context, request function.RunRequest, response *function.RunResponse) {
+	var resourceType, scope string
+	var names []string
+
+	response.Error = function.ConcatFuncErrors(request.Arguments.Get(ctx, &resourceType, &scope, &names))
+
+	if response.Error != nil {
+		return
+	}
+
+	input, err := buildResourceIdString(resourceType, scope, names)
+	if err != nil {
+		response.Error = function.NewFuncError(err.Error())
+		return
+	}
+
+	idType := recaser.ResourceIdTypeFromResourceId(input)
+	if idType == nil {
+		response.Error = function.NewFuncError(fmt.Sprintf("could not determine resource ID type for %q with the given scope, the resource type may be malformed or currently not supported in the provider", resourceType))
+		return
+	}
+
+	parser := resourceids.NewParserFromResourceIdType(idType)
+	parsed, err := parser.Parse(input, true)
+	if err != nil {
+		response.Error = function.NewFuncError(fmt.Sprintf("Building Resource ID Error: %s", err))
+		return
+	}
+
+	// the scope is a user specified segment, so it'll only be re-cased if it's a known ID itself
+	if v := parsed.Parsed["scope"]; v != "" {
+		parsed.Parsed["scope"] = recaser.ReCase(v)
+	}
+
+	if err := idType.FromParseResult(*parsed); err != nil {
+		response.Error = function.NewFuncError(fmt.Sprintf("Expanding Parsed Resource ID Error: %s", err))
+		return
+	}
+
+	response.Error = function.ConcatFuncErrors(response.Result.Set(ctx, idType.ID()))
+}
+
+// buildResourceIdString assembles an (uncased) Resource ID from the components supplied to the function, so that it
+// can be matched against the known Resource ID types
+func buildResourceIdString(resourceType, scope string, names []string) (string, error) {
+	typeSegments := strings.Split(strings.Trim(resourceType, "/"), "/")
+	if len(typeSegments) < 2 {
+		return "", fmt.Errorf("expected `resource_type` to be in the format `{ResourceProvider}/{ResourceType}[/{ChildResourceType}...]`, got %q", resourceType)
+	}
+
+	provider := typeSegments[0]
+	resourceTypes := typeSegments[1:]
+	if len(resourceTypes) != len(names) {
+		return "", fmt.Errorf("resource type %q requires %d name(s), got %d", resourceType, len(resourceTypes), len(names))
+	}
+
+	for i, v := range resourceTypes {
+		if v == "" {
+			return "", fmt.Errorf("`resource_type` %q contains an empty segment", resourceType)
+		}
+		if names[i] == "" {
+			return "", fmt.Errorf("name for the %q segment cannot be empty", v)
+		}
+		if strings.Contains(names[i], "/") {
+			return "", fmt.Errorf("name %q for the %q segment cannot contain a `/`", names[i], v)
+		}
+	}
+
+	segments := []string{strings.TrimSuffix(scope, "/"), "providers", provider}
+	for i, v := range resourceTypes {
+		segments = append(segments, v, names[i])
+	}
+
+	return strings.Join(segments, "/"), nil
+}
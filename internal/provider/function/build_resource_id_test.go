@@ -0,0 +1,101 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package function_test
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/go-version"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/acceptance"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/provider/framework"
+)
+
+func TestProviderFunctionBuildResourceID_basic(t *testing.T) {
+	t.Parallel()
+
+	resource.UnitTest(t, resource.TestCase{
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(version.Must(version.NewVersion("1.8.0-beta1"))),
+		},
+		ProtoV5ProviderFactories: framework.ProtoV5ProviderFactoriesInit(context.Background(), "azurerm"),
+		Steps: []resource.TestStep{
+			{
+				Config: testBuildResourceIdOutput("microsoft.network/VIRTUALNETWORKS/subnets", "/SUBSCRIPTIONS/12345678-1234-9876-4563-123456789012/resourcegroups/resGroup1", `"vnet1", "subnet1"`),
+				Check: acceptance.ComposeTestCheckFunc(
+					acceptance.TestCheckOutput("id", "/subscriptions/12345678-1234-9876-4563-123456789012/resourceGroups/resGroup1/providers/Microsoft.Network/virtualNetworks/vnet1/subnets/subnet1"),
+				),
+			},
+		},
+	})
+}
+
+func TestProviderFunctionBuildResourceID_scopedAtResource(t *testing.T) {
+	t.Parallel()
+
+	resource.UnitTest(t, resource.TestCase{
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(version.Must(version.NewVersion("1.8.0-beta1"))),
+		},
+		ProtoV5ProviderFactories: framework.ProtoV5ProviderFactoriesInit(context.Background(), "azurerm"),
+		Steps: []resource.TestStep{
+			{
+				Config: testBuildResourceIdOutput("Microsoft.EventGrid/eventSubscriptions", "/subscriptions/12345678-1234-9876-4563-123456789012/resourcegroups/resGroup1/providers/microsoft.storage/storageaccounts/mystorageaccount", `"event1"`),
+				Check: acceptance.ComposeTestCheckFunc(
+					acceptance.TestCheckOutput("id", "/subscriptions/12345678-1234-9876-4563-123456789012/resourceGroups/resGroup1/providers/Microsoft.Storage/storageAccounts/mystorageaccount/providers/Microsoft.EventGrid/eventSubscriptions/event1"),
+				),
+			},
+		},
+	})
+}
+
+func TestProviderFunctionBuildResourceID_mismatchedNames(t *testing.T) {
+	t.Parallel()
+
+	resource.UnitTest(t, resource.TestCase{
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(version.Must(version.NewVersion("1.8.0-beta1"))),
+		},
+		ProtoV5ProviderFactories: framework.ProtoV5ProviderFactoriesInit(context.Background(), "azurerm"),
+		Steps: []resource.TestStep{
+			{
+				Config:      testBuildResourceIdOutput("Microsoft.Network/virtualNetworks/subnets", "/subscriptions/12345678-1234-9876-4563-123456789012/resourceGroups/resGroup1", `"subnet1"`),
+				ExpectError: regexp.MustCompile("requires 2 name\\(s\\), got 1"),
+			},
+		},
+	})
+}
+
+func TestProviderFunctionBuildResourceID_unknownType(t *testing.T) {
+	t.Parallel()
+
+	resource.UnitTest(t, resource.TestCase{
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(version.Must(version.NewVersion("1.8.0-beta1"))),
+		},
+		ProtoV5ProviderFactories: framework.ProtoV5ProviderFactoriesInit(context.Background(), "azurerm"),
+		Steps: []resource.TestStep{
+			{
+				Config:      testBuildResourceIdOutput("Microsoft.Network/notARealType", "/subscriptions/12345678-1234-9876-4563-123456789012/resourceGroups/resGroup1", `"thing1"`),
+				ExpectError: regexp.MustCompile("could not determine resource ID type"),
+			},
+		},
+	})
+}
+
+func testBuildResourceIdOutput(resourceType, scope, names string) string {
+	return fmt.Sprintf(`
+provider "azurerm" {
+  features {}
+}
+
+output "id" {
+  value = provider::azurerm::build_resource_id("%s", "%s", [%s])
+}
+`, resourceType, scope, names)
+}
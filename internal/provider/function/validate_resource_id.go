@@ -0,0 +1,102 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package function
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
+)
+
+type ValidateResourceIDFunction struct {
+	resources func() map[string]*schema.Resource
+}
+
+var _ function.Function = ValidateResourceIDFunction{}
+
+var idValidateResultTypes = map[string]attr.Type{
+	"valid": types.BoolType,
+	"error": types.StringType,
+}
+
+// NewValidateResourceIDFunction returns a function which validates IDs using the same validation as the Importer
+// for the resources returned by `resources`
+func NewValidateResourceIDFunction(resources func() map[string]*schema.Resource) function.Function {
+	return &ValidateResourceIDFunction{
+		resources: resources,
+	}
+}
+
+func (v ValidateResourceIDFunction) Metadata(_ context.Context, _ function.MetadataRequest, response *function.MetadataResponse) {
+	response.Name = "validate_resource_id"
+}
+
+func (v ValidateResourceIDFunction) Definition(_ context.Context, _ function.DefinitionRequest, response *function.DefinitionResponse) {
+	response.Definition = function.Definition{
+		Summary:             "validate_resource_id",
+		Description:         "Validates an ID using the same rules as the specified resource type uses at import time",
+		MarkdownDescription: "Validates an ID using the same rules as the specified resource type uses at import time",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:                "resource_type",
+				Description:         "The Terraform resource type the ID should be valid for, e.g. azurerm_subnet",
+				MarkdownDescription: "The Terraform resource type the ID should be valid for, e.g. `azurerm_subnet`",
+			},
+			function.StringParameter{
+				Name:                "id",
+				Description:         "Resource ID",
+				MarkdownDescription: "Resource ID",
+			},
+		},
+		Return: function.ObjectReturn{
+			AttributeTypes: idValidateResultTypes,
+		},
+	}
+}
+
+func (v ValidateResourceIDFunction) Run(ctx context.Context, request function.RunRequest, response *function.RunResponse) {
+	var resourceType, id string
+
+	response.Error = function.ConcatFuncErrors(request.Arguments.Get(ctx, &resourceType, &id))
+
+	if response.Error != nil {
+		return
+	}
+
+	resource, ok := v.resources()[resourceType]
+	if !ok {
+		response.Error = function.NewArgumentFuncError(0, fmt.Sprintf("%q is not a resource type supported by the provider", resourceType))
+		return
+	}
+
+	validateFunc, ok := pluginsdk.IDValidationFuncForImporter(resource.Importer)
+	if !ok {
+		response.Error = function.NewArgumentFuncError(0, fmt.Sprintf("the resource type %q does not support validating Resource IDs", resourceType))
+		return
+	}
+
+	output := map[string]attr.Value{
+		"valid": types.BoolValue(true),
+		"error": types.StringNull(),
+	}
+
+	if err := validateFunc(id); err != nil {
+		output["valid"] = types.BoolValue(false)
+		output["error"] = types.StringValue(strings.TrimSpace(err.Error()))
+	}
+
+	result, diags := types.ObjectValue(idValidateResultTypes, output)
+	if diags.HasError() {
+		response.Error = function.ConcatFuncErrors(response.Error, function.FuncErrorFromDiags(ctx, diags))
+		return
+	}
+
+	response.Error = function.ConcatFuncErrors(response.Result.Set(ctx, result))
+}
@@ -0,0 +1,113 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package function_test
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/go-version"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/acceptance"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/provider/framework"
+)
+
+func TestProviderFunctionValidateResourceID_valid(t *testing.T) {
+	t.Parallel()
+
+	resource.UnitTest(t, resource.TestCase{
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(version.Must(version.NewVersion("1.8.0-beta1"))),
+		},
+		ProtoV5ProviderFactories: framework.ProtoV5ProviderFactoriesInit(context.Background(), "azurerm"),
+		Steps: []resource.TestStep{
+			{
+				Config: testValidateResourceIdOutput("azurerm_subnet", "/subscriptions/12345678-1234-9876-4563-123456789012/resourceGroups/resGroup1/providers/Microsoft.Network/virtualNetworks/vnet1/subnets/subnet1"),
+				Check: acceptance.ComposeTestCheckFunc(
+					acceptance.TestCheckOutput("valid", "true"),
+				),
+			},
+		},
+	})
+}
+
+func TestProviderFunctionValidateResourceID_typedResource(t *testing.T) {
+	t.Parallel()
+
+	resource.UnitTest(t, resource.TestCase{
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(version.Must(version.NewVersion("1.8.0-beta1"))),
+		},
+		ProtoV5ProviderFactories: framework.ProtoV5ProviderFactoriesInit(context.Background(), "azurerm"),
+		Steps: []resource.TestStep{
+			{
+				Config: testValidateResourceIdOutput("azurerm_resource_group_policy_assignment", "/subscriptions/12345678-1234-9876-4563-123456789012/resourceGroups/resGroup1/providers/Microsoft.Authorization/policyAssignments/assignment1"),
+				Check: acceptance.ComposeTestCheckFunc(
+					acceptance.TestCheckOutput("valid", "true"),
+				),
+			},
+		},
+	})
+}
+
+func TestProviderFunctionValidateResourceID_invalid(t *testing.T) {
+	t.Parallel()
+
+	resource.UnitTest(t, resource.TestCase{
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(version.Must(version.NewVersion("1.8.0-beta1"))),
+		},
+		ProtoV5ProviderFactories: framework.ProtoV5ProviderFactoriesInit(context.Background(), "azurerm"),
+		Steps: []resource.TestStep{
+			{
+				// the `subnets` segment is incorrectly cased
+				Config: testValidateResourceIdOutput("azurerm_subnet", "/subscriptions/12345678-1234-9876-4563-123456789012/resourceGroups/resGroup1/providers/Microsoft.Network/virtualNetworks/vnet1/Subnets/subnet1"),
+				Check: acceptance.ComposeTestCheckFunc(
+					acceptance.TestCheckOutput("valid", "false"),
+					resource.TestMatchOutput("error", regexp.MustCompile("parsing the Subnet ID: the segment at position [0-9]+ didn.t match")),
+				),
+			},
+		},
+	})
+}
+
+func TestProviderFunctionValidateResourceID_unknownResourceType(t *testing.T) {
+	t.Parallel()
+
+	resource.UnitTest(t, resource.TestCase{
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(version.Must(version.NewVersion("1.8.0-beta1"))),
+		},
+		ProtoV5ProviderFactories: framework.ProtoV5ProviderFactoriesInit(context.Background(), "azurerm"),
+		Steps: []resource.TestStep{
+			{
+				Config:      testValidateResourceIdOutput("azurerm_not_a_resource", "/subscriptions/12345678-1234-9876-4563-123456789012/resourceGroups/resGroup1"),
+				ExpectError: regexp.MustCompile("is not a resource type supported by the provider"),
+			},
+		},
+	})
+}
+
+func testValidateResourceIdOutput(resourceType, id string) string {
+	return fmt.Sprintf(`
+provider "azurerm" {
+  features {}
+}
+
+locals {
+  result = provider::azurerm::validate_resource_id("%s", "%s")
+}
+
+output "valid" {
+  value = local.result.valid
+}
+
+output "error" {
+  value = local.result.error != null ? local.result.error : ""
+}
+`, resourceType, id)
+}
@@ -17,6 +17,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/clients"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/common"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/features"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/resourceproviders"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/sdk"
@@ -319,6 +320,14 @@ func azureProvider(supportLegacyTestSuite bool) *schema.Provider {
 				Description: "This will disable the Terraform Partner ID which is used if a custom `partner_id` isn't specified.",
 			},
 
+			"max_concurrent_requests": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				DefaultFunc:  schema.EnvDefaultFunc("ARM_MAX_CONCURRENT_REQUESTS", 0),
+				ValidateFunc: validation.IntAtLeast(0),
+				Description:  "The maximum number of concurrent requests which should be made to Azure. Defaults to `0`, which is unlimited.",
+			},
+
 			"features": schemaFeatures(supportLegacyTestSuite),
 
 			// Advanced feature flags
@@ -521,6 +530,7 @@ func buildClient(ctx context.Context, p *schema.Provider, d *schema.ResourceData
 		DisableCorrelationRequestID: d.Get("disable_correlation_request_id").(bool),
 		DisableTerraformPartnerID:   d.Get("disable_terraform_partner_id").(bool),
 		Features:                    expandFeatures(d.Get("features").([]interface{})),
+		MaxConcurrentRequests:       d.Get("max_concurrent_requests").(int),
 		MetadataHost:                d.Get("metadata_host").(string),
 		PartnerID:                   d.Get("partner_id").(string),
 		RegisteredResourceProviders: requiredResourceProviders,
@@ -531,6 +541,13 @@ func buildClient(ctx context.Context, p *schema.Provider, d *schema.ResourceData
 		// this field is intentionally not exposed in the provider block, since it's only used for
 		// platform level tracing
 		CustomCorrelationRequestID: os.Getenv("ARM_CORRELATION_REQUEST_ID"),
+
+		// additional patterns matching the names of headers and JSON properties which should be redacted from
+		// the debug log, this is an environment variable since it relates to logging rather than configuration
+		LogRedactionPatterns: strings.Split(os.Getenv("ARM_LOG_REDACTION_PATTERNS"), ","),
+
+		// this is only present when running the acceptance tests in record/replay mode
+		Recorder: common.RecorderFromContext(ctx),
 	}
 
 	//lint:ignore SA1019 SDKv2 migration - staticcheck's own linter directives are currently being ignored under golangci-lint
@@ -340,7 +340,10 @@ func SupportedFrameworkServices() []sdk.FrameworkTypedServiceRegistration {
 		// Services with Framework Resources, Data Sources, or Ephemeral Resources to be listed here
 		// e.g.
 		// resource.Registration{}
+		authorization.Registration{},
+		containers.Registration{},
 		keyvault.Registration{},
+		storage.Registration{},
 	}
 
 	return services
@@ -5,14 +5,32 @@ package resourceproviders
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/json"
 	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"sort"
 	"strings"
 	"sync"
+	"time"
 
 	"github.com/hashicorp/go-azure-helpers/resourcemanager/commonids"
 	"github.com/hashicorp/go-azure-sdk/resource-manager/resources/2022-09-01/providers"
 )
 
+const (
+	// cachePathEnvVar is the directory in which the Resource Provider cache is persisted - when unset the
+	// cache is only held in memory for the lifetime of the provider process
+	cachePathEnvVar = "ARM_RESOURCE_PROVIDER_CACHE_PATH"
+
+	// cacheTTLEnvVar is how long the persisted Resource Provider cache is valid for, as a Go duration (e.g. `30m`)
+	cacheTTLEnvVar = "ARM_RESOURCE_PROVIDER_CACHE_TTL"
+
+	defaultCacheTTL = 1 * time.Hour
+)
+
 // cachedResourceProviders can be (validly) nil - as such this shouldn't be relied on
 var (
 	cachedResourceProviders       *[]string
@@ -49,6 +67,15 @@ func populateCache(ctx context.Context, client *providers.ProvidersClient, subsc
 	cacheLock.Lock()
 	defer cacheLock.Unlock()
 
+	diskCache := persistedCacheFromEnvironment(client.Client.BaseUri, subscriptionId.SubscriptionId)
+	if diskCache != nil {
+		if entry := diskCache.read(); entry != nil {
+			log.Printf("[DEBUG] Using the Resource Providers cached at %q (cached at %s)", diskCache.path(), entry.CachedAt.Format(time.RFC3339))
+			entry.apply()
+			return nil
+		}
+	}
+
 	providers, err := client.ListComplete(ctx, subscriptionId, providers.DefaultListOperationOptions())
 	if err != nil {
 		return fmt.Errorf("listing Resource Providers: %+v", err)
@@ -72,5 +99,173 @@ func populateCache(ctx context.Context, client *providers.ProvidersClient, subsc
 	}
 
 	cachedResourceProviders = &providerNames
+
+	if diskCache != nil {
+		if err := diskCache.write(); err != nil {
+			// the persisted cache is an optimisation, so this isn't fatal
+			log.Printf("[DEBUG] Unable to persist the Resource Provider cache to %q: %+v", diskCache.path(), err)
+		}
+	}
+
 	return nil
 }
+
+// persistedCache is a file-backed copy of the Resource Provider cache, keyed on the Resource Manager endpoint
+// and the Subscription ID, which allows the (relatively expensive) list of Resource Providers to be shared
+// across provider processes - for example across many workspaces.
+type persistedCache struct {
+	directory      string
+	environment    string
+	subscriptionId string
+	ttl            time.Duration
+}
+
+type persistedCacheEntry struct {
+	Environment    string    `json:"environment"`
+	SubscriptionId string    `json:"subscription_id"`
+	CachedAt       time.Time `json:"cached_at"`
+	Registered     []string  `json:"registered"`
+	Unregistered   []string  `json:"unregistered"`
+}
+
+// persistedCacheFromEnvironment returns the persisted cache for the specified environment and subscription,
+// or nil when the persisted cache hasn't been enabled
+func persistedCacheFromEnvironment(environment, subscriptionId string) *persistedCache {
+	directory := os.Getenv(cachePathEnvVar)
+	if directory == "" {
+		return nil
+	}
+
+	ttl := defaultCacheTTL
+	if v := os.Getenv(cacheTTLEnvVar); v != "" {
+		parsed, err := time.ParseDuration(v)
+		if err != nil {
+			log.Printf("[DEBUG] Ignoring the value %q for %q since it's not a valid duration, using the default of %s: %+v", v, cacheTTLEnvVar, defaultCacheTTL, err)
+		} else {
+			ttl = parsed
+		}
+	}
+
+	return &persistedCache{
+		directory:      directory,
+		environment:    strings.TrimSuffix(strings.ToLower(environment), "/"),
+		subscriptionId: strings.ToLower(subscriptionId),
+		ttl:            ttl,
+	}
+}
+
+func (c persistedCache) path() string {
+	hash := sha256.Sum256([]byte(fmt.Sprintf("%s|%s", c.environment, c.subscriptionId)))
+	return filepath.Join(c.directory, fmt.Sprintf("resource-providers-%x.json", hash[:8]))
+}
+
+// read returns the persisted cache entry, or nil if it doesn't exist, has expired or can't be read
+func (c persistedCache) read() *persistedCacheEntry {
+	contents, err := os.ReadFile(c.path())
+	if err != nil {
+		if !os.IsNotExist(err) {
+			log.Printf("[DEBUG] Unable to read the Resource Provider cache at %q: %+v", c.path(), err)
+		}
+		return nil
+	}
+
+	var entry persistedCacheEntry
+	if err := json.Unmarshal(contents, &entry); err != nil {
+		log.Printf("[DEBUG] Ignoring the Resource Provider cache at %q since it couldn't be parsed: %+v", c.path(), err)
+		return nil
+	}
+
+	// guard against a hash collision
+	if entry.Environment != c.environment || entry.SubscriptionId != c.subscriptionId {
+		return nil
+	}
+
+	if time.Since(entry.CachedAt) > c.ttl {
+		log.Printf("[DEBUG] Ignoring the Resource Provider cache at %q since it expired at %s", c.path(), entry.CachedAt.Add(c.ttl).Format(time.RFC3339))
+		return nil
+	}
+
+	return &entry
+}
+
+// write persists the in-memory cache, this must be called whilst holding cacheLock
+func (c persistedCache) write() error {
+	entry := persistedCacheEntry{
+		Environment:    c.environment,
+		SubscriptionId: c.subscriptionId,
+		CachedAt:       time.Now().UTC(),
+		Registered:     make([]string, 0),
+		Unregistered:   make([]string, 0),
+	}
+	for name := range registeredResourceProviders {
+		entry.Registered = append(entry.Registered, name)
+	}
+	for name := range unregisteredResourceProviders {
+		entry.Unregistered = append(entry.Unregistered, name)
+	}
+	sort.Strings(entry.Registered)
+	sort.Strings(entry.Unregistered)
+
+	contents, err := json.Marshal(entry)
+	if err != nil {
+		return fmt.Errorf("serializing: %+v", err)
+	}
+
+	if err := os.MkdirAll(c.directory, 0o700); err != nil {
+		return fmt.Errorf("creating directory: %+v", err)
+	}
+
+	// write to a temporary file and then rename it, so that concurrent processes never read a partial file
+	file, err := os.CreateTemp(c.directory, "resource-providers-*.tmp")
+	if err != nil {
+		return fmt.Errorf("creating temporary file: %+v", err)
+	}
+	defer os.Remove(file.Name())
+
+	if _, err := file.Write(contents); err != nil {
+		file.Close()
+		return fmt.Errorf("writing temporary file: %+v", err)
+	}
+	if err := file.Close(); err != nil {
+		return fmt.Errorf("closing temporary file: %+v", err)
+	}
+
+	if err := os.Rename(file.Name(), c.path()); err != nil {
+		return fmt.Errorf("renaming temporary file: %+v", err)
+	}
+
+	return nil
+}
+
+// invalidate removes the persisted cache, so that it's repopulated the next time it's required
+func (c persistedCache) invalidate() {
+	if err := os.Remove(c.path()); err != nil && !os.IsNotExist(err) {
+		log.Printf("[DEBUG] Unable to remove the Resource Provider cache at %q: %+v", c.path(), err)
+	}
+}
+
+// apply populates the in-memory cache from the persisted cache entry, this must be called whilst holding cacheLock
+func (e persistedCacheEntry) apply() {
+	providerNames := make([]string, 0, len(e.Registered)+len(e.Unregistered))
+	registeredResourceProviders = make(map[string]struct{})
+	unregisteredResourceProviders = make(map[string]struct{})
+	for _, name := range e.Registered {
+		providerNames = append(providerNames, name)
+		registeredResourceProviders[name] = struct{}{}
+	}
+	for _, name := range e.Unregistered {
+		providerNames = append(providerNames, name)
+		unregisteredResourceProviders[name] = struct{}{}
+	}
+
+	cachedResourceProviders = &providerNames
+}
+
+// invalidatePersistedCache removes the persisted cache for the Subscription (if enabled) since the
+// registration state of the Resource Providers has changed
+func invalidatePersistedCache(client *providers.ProvidersClient, subscriptionId commonids.SubscriptionId) {
+	if diskCache := persistedCacheFromEnvironment(client.Client.BaseUri, subscriptionId.SubscriptionId); diskCache != nil {
+		log.Printf("[DEBUG] Invalidating the Resource Provider cache at %q", diskCache.path())
+		diskCache.invalidate()
+	}
+}
@@ -0,0 +1,98 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package resourceproviders
+
+import (
+	"os"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestPersistedCache(t *testing.T) {
+	t.Setenv(cachePathEnvVar, t.TempDir())
+	t.Setenv(cacheTTLEnvVar, "")
+	defer ClearCache()
+
+	cache := persistedCacheFromEnvironment("https://management.azure.com/", "11111111-1111-1111-1111-111111111111")
+	if cache == nil {
+		t.Fatalf("expected a persisted cache when %q is set", cachePathEnvVar)
+	}
+	if cache.ttl != defaultCacheTTL {
+		t.Fatalf("expected the default TTL of %s but got %s", defaultCacheTTL, cache.ttl)
+	}
+
+	if entry := cache.read(); entry != nil {
+		t.Fatalf("expected no entry before the cache is written but got %+v", entry)
+	}
+
+	registeredResourceProviders = map[string]struct{}{
+		"Microsoft.Compute": {},
+		"Microsoft.Network": {},
+	}
+	unregisteredResourceProviders = map[string]struct{}{
+		"Microsoft.Quantum": {},
+	}
+	if err := cache.write(); err != nil {
+		t.Fatalf("writing cache: %+v", err)
+	}
+	ClearCache()
+
+	entry := cache.read()
+	if entry == nil {
+		t.Fatalf("expected an entry after the cache was written")
+	}
+	entry.apply()
+
+	if !reflect.DeepEqual(*cachedResourceProviders, []string{"Microsoft.Compute", "Microsoft.Network", "Microsoft.Quantum"}) {
+		t.Fatalf("unexpected cached Resource Providers: %+v", *cachedResourceProviders)
+	}
+	if _, ok := registeredResourceProviders["Microsoft.Network"]; !ok {
+		t.Fatalf("expected `Microsoft.Network` to be registered")
+	}
+	if _, ok := unregisteredResourceProviders["Microsoft.Quantum"]; !ok {
+		t.Fatalf("expected `Microsoft.Quantum` to be unregistered")
+	}
+
+	// a different subscription or environment has a separate cache
+	if other := persistedCacheFromEnvironment("https://management.azure.com/", "22222222-2222-2222-2222-222222222222").read(); other != nil {
+		t.Fatalf("expected no entry for a different subscription but got %+v", other)
+	}
+	if other := persistedCacheFromEnvironment("https://management.chinacloudapi.cn/", "11111111-1111-1111-1111-111111111111").read(); other != nil {
+		t.Fatalf("expected no entry for a different environment but got %+v", other)
+	}
+
+	cache.invalidate()
+	if _, err := os.Stat(cache.path()); !os.IsNotExist(err) {
+		t.Fatalf("expected the cache to be removed when invalidated")
+	}
+}
+
+func TestPersistedCacheExpired(t *testing.T) {
+	t.Setenv(cachePathEnvVar, t.TempDir())
+	t.Setenv(cacheTTLEnvVar, "1ms")
+	defer ClearCache()
+
+	cache := persistedCacheFromEnvironment("https://management.azure.com", "11111111-1111-1111-1111-111111111111")
+	registeredResourceProviders = map[string]struct{}{
+		"Microsoft.Compute": {},
+	}
+	unregisteredResourceProviders = map[string]struct{}{}
+	if err := cache.write(); err != nil {
+		t.Fatalf("writing cache: %+v", err)
+	}
+
+	time.Sleep(10 * time.Millisecond)
+	if entry := cache.read(); entry != nil {
+		t.Fatalf("expected the entry to have expired but got %+v", entry)
+	}
+}
+
+func TestPersistedCacheDisabled(t *testing.T) {
+	t.Setenv(cachePathEnvVar, "")
+
+	if cache := persistedCacheFromEnvironment("https://management.azure.com", "11111111-1111-1111-1111-111111111111"); cache != nil {
+		t.Fatalf("expected no persisted cache when %q isn't set", cachePathEnvVar)
+	}
+}
@@ -47,7 +47,12 @@ func EnsureRegistered(ctx context.Context, client *providers.ProvidersClient, su
 	}
 
 	log.Printf("[DEBUG] Registering %d Resource Providers", len(*providersToRegister))
-	if err = registerForSubscription(ctx, client, subscriptionId, *providersToRegister); err != nil {
+	err = registerForSubscription(ctx, client, subscriptionId, *providersToRegister)
+
+	// the registration state has changed (even if only some Resource Providers were registered)
+	invalidatePersistedCache(client, subscriptionId)
+
+	if err != nil {
 		return userError(err)
 	}
 
@@ -15,6 +15,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/clients"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/features"
 )
@@ -66,6 +67,43 @@ func (r *ResourceMetadata) Defaults(req resource.ConfigureRequest, resp *resourc
 	r.TimeoutDelete = 30 * time.Minute
 }
 
+// DefaultsWithTypedSchema configures the Resource Metadata as per Defaults, using the default Timeouts defined within
+// the TypedSchema for the Resource when these are specified.
+func (r *ResourceMetadata) DefaultsWithTypedSchema(req resource.ConfigureRequest, resp *resource.ConfigureResponse, typedSchema TypedSchema) {
+	r.Defaults(req, resp)
+	if req.ProviderData == nil || resp.Diagnostics.HasError() || typedSchema.Timeouts == nil {
+		return
+	}
+
+	r.TimeoutCreate = typedSchema.Timeouts.Create
+	r.TimeoutRead = typedSchema.Timeouts.Read
+	r.TimeoutDelete = typedSchema.Timeouts.Delete
+	r.TimeoutUpdate = typedSchema.Timeouts.Update
+}
+
+// ConfigureTimeouts overrides the default Timeouts with any values the user has specified within the `timeouts` block
+// of a Resource defined using a TypedSchema.
+func (r *ResourceMetadata) ConfigureTimeouts(ctx context.Context, timeouts types.Object) diag.Diagnostics {
+	defaults := TypedSchemaTimeouts{
+		Create: r.TimeoutCreate,
+		Read:   r.TimeoutRead,
+		Delete: r.TimeoutDelete,
+		Update: r.TimeoutUpdate,
+	}
+
+	configured, diags := defaults.Configured(ctx, timeouts)
+	if diags.HasError() {
+		return diags
+	}
+
+	r.TimeoutCreate = configured.Create
+	r.TimeoutRead = configured.Read
+	r.TimeoutDelete = configured.Delete
+	r.TimeoutUpdate = configured.Update
+
+	return diags
+}
+
 // DecodeCreate reads a plan from a resource.CreateRequest into a pointer to a target model and sets resource.CreateResponse diags on error.
 // Returns true if there are no error Diagnostics.
 func (r *ResourceMetadata) DecodeCreate(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse, plan interface{}) bool {
@@ -173,6 +211,16 @@ type FrameworkResource interface {
 	resource.ResourceWithImportState
 }
 
+// FrameworkResourceWithTypedSchema extends the FrameworkResource interface to define the Schema and Timeouts using a
+// TypedSchema - the same definition which can be used by a Plugin SDKv2 Resource via ResourceWithTypedSchema.
+// The Schema function should return `TypedSchema().FrameworkResourceSchema(...)` and Configure should call
+// `DefaultsWithTypedSchema` on the embedded ResourceMetadata.
+type FrameworkResourceWithTypedSchema interface {
+	FrameworkResource
+
+	TypedSchema() TypedSchema
+}
+
 // FrameworkResourceWithCustomImporter extends the FrameworkResource interface to also require a CustomImporter to
 // customise the import process if needed.
 type FrameworkResourceWithCustomImporter interface {
@@ -27,9 +27,9 @@ type resourceWithPluginSdkSchema interface {
 
 type resourceBase interface {
 	// resourceWithPluginSdkSchema ensure that the Arguments and Attributes are sourced
-	// from Plugin SDKv2 for now - longer term we'll likely introduce a `Typed Schema`
-	// which will cross-compile down to both the Plugin SDKv2 and Plugin Framework, but
-	// that's a story for another day.
+	// from Plugin SDKv2 - Resources can instead opt into defining a TypedSchema (which
+	// cross-compiles down to both the Plugin SDKv2 and Plugin Framework) by implementing
+	// the `ResourceWithTypedSchema` interface.
 	resourceWithPluginSdkSchema
 
 	// ModelObject is an instance of the object the Schema is decoded/encoded into
@@ -108,6 +108,19 @@ type ResourceWithCustomImporter interface {
 	CustomImporter() ResourceRunFunc
 }
 
+// ResourceWithTypedSchema is an optional interface
+//
+// Resources implementing this interface define their Schema (and Timeouts) using a
+// TypedSchema, which is used in place of the Arguments, Attributes and ResourceFunc Timeouts.
+// The Arguments and Attributes functions can return `TypedSchema().PluginSdkArguments()`
+// and `TypedSchema().PluginSdkAttributes()` respectively.
+type ResourceWithTypedSchema interface {
+	Resource
+
+	// TypedSchema returns the TypedSchema for this Resource
+	TypedSchema() TypedSchema
+}
+
 // ResourceWithUpdate is an optional interface
 //
 // Notably the Arguments for Resources implementing this interface
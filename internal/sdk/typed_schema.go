@@ -0,0 +1,370 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package sdk
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
+)
+
+// TypedSchemaType is the type of value held by a TypedSchemaField
+type TypedSchemaType string
+
+const (
+	TypedSchemaTypeBool   TypedSchemaType = "Bool"
+	TypedSchemaTypeFloat  TypedSchemaType = "Float"
+	TypedSchemaTypeInt    TypedSchemaType = "Int"
+	TypedSchemaTypeList   TypedSchemaType = "List"
+	TypedSchemaTypeMap    TypedSchemaType = "Map"
+	TypedSchemaTypeSet    TypedSchemaType = "Set"
+	TypedSchemaTypeString TypedSchemaType = "String"
+)
+
+func (t TypedSchemaType) isPrimitive() bool {
+	return t == TypedSchemaTypeBool || t == TypedSchemaTypeFloat || t == TypedSchemaTypeInt || t == TypedSchemaTypeString
+}
+
+// TypedSchema defines the Schema for a Resource independently of the Plugin SDK in use - which can then be
+// compiled down to both the Plugin SDKv2 and the Plugin Framework types, meaning that a single definition
+// can be used regardless of which SDK the Resource is implemented using.
+type TypedSchema struct {
+	// Arguments is a list of user-configurable (that is: Required, Optional, or Optional and Computed)
+	// fields for this Resource
+	Arguments map[string]TypedSchemaField
+
+	// Attributes is a list of read-only (e.g. Computed-only) fields for this Resource
+	Attributes map[string]TypedSchemaField
+
+	// Timeouts defines the default timeouts for this Resource, which can be overridden by users.
+	Timeouts *TypedSchemaTimeouts
+}
+
+// typedSchemaReservedRootNames are the names which cannot be used for top-level fields, since they're
+// either meta-arguments in Terraform, or (in the case of `timeouts`) defined via TypedSchemaTimeouts
+var typedSchemaReservedRootNames = map[string]struct{}{
+	"connection":  {},
+	"count":       {},
+	"depends_on":  {},
+	"for_each":    {},
+	"lifecycle":   {},
+	"provider":    {},
+	"provisioner": {},
+	"timeouts":    {},
+}
+
+// TypedSchemaField defines a single field within a TypedSchema, this is intentionally modelled on
+// the Plugin SDKv2 Schema to ease migrating existing Resources over.
+type TypedSchemaField struct {
+	// Type is the type of value held in this field
+	Type TypedSchemaType
+
+	// ElementType is the type of each element within a List, Set or Map of primitive values
+	// NOTE: this is mutually exclusive with NestedBlock
+	ElementType TypedSchemaType
+
+	// NestedBlock defines the fields for each element within a List or Set of nested objects
+	// NOTE: this is mutually exclusive with ElementType
+	NestedBlock map[string]TypedSchemaField
+
+	Required  bool
+	Optional  bool
+	Computed  bool
+	ForceNew  bool
+	Sensitive bool
+
+	// Default is the default value for an Optional primitive field
+	Default interface{}
+
+	// MinItems and MaxItems define the bounds of a List or Set
+	MinItems int
+	MaxItems int
+
+	// ValidateFunc validates the value of a String, Int or Float - or the value of each element
+	// within a List or Set of Strings, Ints or Floats.
+	ValidateFunc pluginsdk.SchemaValidateFunc
+
+	Description string
+
+	// Deprecated is the Deprecation message for this field, when set
+	Deprecated string
+}
+
+// TypedSchemaTimeouts defines the default timeouts used for each operation of a Resource
+type TypedSchemaTimeouts struct {
+	Create time.Duration
+	Read   time.Duration
+	Delete time.Duration
+
+	// Update is optional since not every Resource supports being updated
+	Update *time.Duration
+}
+
+// Validate ensures that the TypedSchema is valid, and can be compiled down to each of the Plugin SDKs
+func (s TypedSchema) Validate() error {
+	for _, k := range sortedTypedSchemaKeys(s.Arguments) {
+		v := s.Arguments[k]
+		if _, reserved := typedSchemaReservedRootNames[k]; reserved {
+			return fmt.Errorf("%q is a reserved name and cannot be used in the schema", k)
+		}
+		if _, alreadyExists := s.Attributes[k]; alreadyExists {
+			return fmt.Errorf("%q already exists in the schema", k)
+		}
+
+		if v.Computed && !(v.Optional || v.Required) {
+			return fmt.Errorf("%q is a Computed-only field - this should be specified as an Attribute", k)
+		}
+
+		if err := v.validate(k); err != nil {
+			return err
+		}
+	}
+
+	for _, k := range sortedTypedSchemaKeys(s.Attributes) {
+		v := s.Attributes[k]
+		if _, reserved := typedSchemaReservedRootNames[k]; reserved {
+			return fmt.Errorf("%q is a reserved name and cannot be used in the schema", k)
+		}
+		if v.Optional || v.Required {
+			return fmt.Errorf("%q is a user-specifyable field - this should be specified as an Argument", k)
+		}
+
+		// every attribute has to be computed
+		v.Computed = true
+		if err := v.validate(k); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (f TypedSchemaField) validate(key string) error {
+	if f.Required && (f.Optional || f.Computed) {
+		return fmt.Errorf("%q: a Required field cannot be Optional or Computed", key)
+	}
+	if !f.Required && !f.Optional && !f.Computed {
+		return fmt.Errorf("%q: one of Required, Optional or Computed must be set", key)
+	}
+
+	if f.Default != nil {
+		if !f.Type.isPrimitive() {
+			return fmt.Errorf("%q: a Default can only be set for a Bool, Float, Int or String", key)
+		}
+		if !f.Optional || f.Computed {
+			return fmt.Errorf("%q: a Default can only be set on an Optional (and not Computed) field", key)
+		}
+
+		valid := false
+		switch f.Type {
+		case TypedSchemaTypeBool:
+			_, valid = f.Default.(bool)
+		case TypedSchemaTypeFloat:
+			_, valid = f.Default.(float64)
+		case TypedSchemaTypeInt:
+			_, valid = f.Default.(int)
+		case TypedSchemaTypeString:
+			_, valid = f.Default.(string)
+		}
+		if !valid {
+			return fmt.Errorf("%q: the Default %+v is not a valid %s", key, f.Default, f.Type)
+		}
+	}
+
+	if f.Type.isPrimitive() {
+		if f.ElementType != "" || f.NestedBlock != nil {
+			return fmt.Errorf("%q: ElementType and NestedBlock can only be set for a List, Map or Set", key)
+		}
+		if f.MinItems != 0 || f.MaxItems != 0 {
+			return fmt.Errorf("%q: MinItems and MaxItems can only be set for a List or Set", key)
+		}
+		if f.ValidateFunc != nil && f.Type == TypedSchemaTypeBool {
+			return fmt.Errorf("%q: a ValidateFunc cannot be set for a Bool", key)
+		}
+
+		return nil
+	}
+
+	switch f.Type {
+	case TypedSchemaTypeList, TypedSchemaTypeSet:
+		if (f.ElementType == "") == (f.NestedBlock == nil) {
+			return fmt.Errorf("%q: exactly one of ElementType or NestedBlock must be set for a %s", key, f.Type)
+		}
+		if f.MinItems < 0 || f.MaxItems < 0 || (f.MaxItems > 0 && f.MinItems > f.MaxItems) {
+			return fmt.Errorf("%q: MinItems (%d) and MaxItems (%d) are invalid", key, f.MinItems, f.MaxItems)
+		}
+
+	case TypedSchemaTypeMap:
+		if f.ElementType == "" || f.NestedBlock != nil {
+			return fmt.Errorf("%q: a Map must specify an ElementType and cannot contain a NestedBlock", key)
+		}
+		if f.MinItems != 0 || f.MaxItems != 0 {
+			return fmt.Errorf("%q: MinItems and MaxItems can only be set for a List or Set", key)
+		}
+		if f.ValidateFunc != nil {
+			return fmt.Errorf("%q: a ValidateFunc cannot be set for a Map", key)
+		}
+
+	default:
+		return fmt.Errorf("%q: unsupported type %q", key, f.Type)
+	}
+
+	if f.ElementType != "" {
+		if !f.ElementType.isPrimitive() {
+			return fmt.Errorf("%q: the ElementType must be a Bool, Float, Int or String but got %q", key, f.ElementType)
+		}
+		if f.ValidateFunc != nil && f.ElementType == TypedSchemaTypeBool {
+			return fmt.Errorf("%q: a ValidateFunc cannot be set for a %s of Bools", key, f.Type)
+		}
+	}
+
+	if f.NestedBlock != nil {
+		if f.ValidateFunc != nil {
+			return fmt.Errorf("%q: a ValidateFunc cannot be set for a NestedBlock", key)
+		}
+		if len(f.NestedBlock) == 0 {
+			return fmt.Errorf("%q: a NestedBlock must contain at least one field", key)
+		}
+		if f.Optional || f.Required {
+			// these are Blocks in the Plugin Framework, which can't be Computed or Sensitive
+			if f.Computed {
+				return fmt.Errorf("%q: a NestedBlock which is Optional or Required cannot also be Computed", key)
+			}
+			if f.Sensitive {
+				return fmt.Errorf("%q: a NestedBlock which is Optional or Required cannot be Sensitive, mark the nested fields as Sensitive instead", key)
+			}
+		}
+
+		computedOnly := f.Computed && !f.Optional
+		for _, k := range sortedTypedSchemaKeys(f.NestedBlock) {
+			nested := f.NestedBlock[k]
+			if computedOnly {
+				if nested.Optional || nested.Required {
+					return fmt.Errorf("%q: fields within a Computed-only block must be Computed-only, but %q is not", key, k)
+				}
+				nested.Computed = true
+			}
+			if err := nested.validate(fmt.Sprintf("%s.%s", key, k)); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// PluginSdkArguments returns the Arguments for this TypedSchema using the types defined in Plugin SDKv2
+func (s TypedSchema) PluginSdkArguments() map[string]*schema.Schema {
+	out := make(map[string]*schema.Schema)
+	for k, v := range s.Arguments {
+		out[k] = v.pluginSdkSchema()
+	}
+	return out
+}
+
+// PluginSdkAttributes returns the Attributes for this TypedSchema using the types defined in Plugin SDKv2
+func (s TypedSchema) PluginSdkAttributes() map[string]*schema.Schema {
+	out := make(map[string]*schema.Schema)
+	for k, v := range s.Attributes {
+		v.Computed = true
+		out[k] = v.pluginSdkSchema()
+	}
+	return out
+}
+
+// PluginSdkTimeouts returns the Timeouts for this TypedSchema using the types defined in Plugin SDKv2
+func (s TypedSchema) PluginSdkTimeouts() *schema.ResourceTimeout {
+	if s.Timeouts == nil {
+		return nil
+	}
+
+	d := func(duration time.Duration) *time.Duration {
+		return &duration
+	}
+
+	out := &schema.ResourceTimeout{
+		Create: d(s.Timeouts.Create),
+		Read:   d(s.Timeouts.Read),
+		Delete: d(s.Timeouts.Delete),
+	}
+	if s.Timeouts.Update != nil {
+		out.Update = d(*s.Timeouts.Update)
+	}
+
+	return out
+}
+
+func (f TypedSchemaField) pluginSdkSchema() *schema.Schema {
+	out := &schema.Schema{
+		Type:         f.Type.pluginSdkValueType(),
+		Required:     f.Required,
+		Optional:     f.Optional,
+		Computed:     f.Computed,
+		ForceNew:     f.ForceNew,
+		Sensitive:    f.Sensitive,
+		Default:      f.Default,
+		MinItems:     f.MinItems,
+		MaxItems:     f.MaxItems,
+		Description:  f.Description,
+		Deprecated:   f.Deprecated,
+		ValidateFunc: f.ValidateFunc,
+	}
+
+	if f.ElementType != "" {
+		out.ValidateFunc = nil
+		out.Elem = &schema.Schema{
+			Type:         f.ElementType.pluginSdkValueType(),
+			ValidateFunc: f.ValidateFunc,
+		}
+	}
+
+	if f.NestedBlock != nil {
+		computedOnly := f.Computed && !f.Optional
+		nested := make(map[string]*schema.Schema)
+		for k, v := range f.NestedBlock {
+			if computedOnly {
+				v.Computed = true
+			}
+			nested[k] = v.pluginSdkSchema()
+		}
+		out.Elem = &schema.Resource{
+			Schema: nested,
+		}
+	}
+
+	return out
+}
+
+func (t TypedSchemaType) pluginSdkValueType() schema.ValueType {
+	switch t {
+	case TypedSchemaTypeBool:
+		return schema.TypeBool
+	case TypedSchemaTypeFloat:
+		return schema.TypeFloat
+	case TypedSchemaTypeInt:
+		return schema.TypeInt
+	case TypedSchemaTypeList:
+		return schema.TypeList
+	case TypedSchemaTypeMap:
+		return schema.TypeMap
+	case TypedSchemaTypeSet:
+		return schema.TypeSet
+	case TypedSchemaTypeString:
+		return schema.TypeString
+	}
+
+	return schema.TypeInvalid
+}
+
+func sortedTypedSchemaKeys(input map[string]TypedSchemaField) []string {
+	keys := make([]string, 0, len(input))
+	for k := range input {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,459 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package sdk
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/go-azure-helpers/lang/pointer"
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/setvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/boolplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/float64default"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/float64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64default"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/listplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/mapplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/setplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/sdk/frameworkhelpers"
+)
+
+// FrameworkResourceSchema populates the SchemaResponse with the Plugin Framework representation of this TypedSchema,
+// allowing this to be used directly as (or from) the Schema function of a Framework Resource.
+func (s TypedSchema) FrameworkResourceSchema(_ context.Context, _ resource.SchemaRequest, response *resource.SchemaResponse) {
+	if err := s.Validate(); err != nil {
+		response.Diagnostics.AddError("Building Schema", err.Error())
+		return
+	}
+
+	out := schema.Schema{
+		Attributes: map[string]schema.Attribute{},
+		Blocks:     map[string]schema.Block{},
+	}
+
+	fields := make(map[string]TypedSchemaField)
+	for k, v := range s.Arguments {
+		fields[k] = v
+	}
+	for k, v := range s.Attributes {
+		v.Computed = true
+		fields[k] = v
+	}
+
+	for k, v := range fields {
+		if v.isFrameworkBlock() {
+			block, err := v.frameworkBlock()
+			if err != nil {
+				response.Diagnostics.AddError("Building Schema", fmt.Sprintf("building %q: %+v", k, err))
+				return
+			}
+			out.Blocks[k] = block
+			continue
+		}
+
+		attribute, err := v.frameworkAttribute()
+		if err != nil {
+			response.Diagnostics.AddError("Building Schema", fmt.Sprintf("building %q: %+v", k, err))
+			return
+		}
+		out.Attributes[k] = attribute
+	}
+
+	// Plugin SDKv2 implicitly adds the `id` field, whereas the Framework requires that this is defined
+	if _, ok := out.Attributes["id"]; !ok {
+		out.Attributes["id"] = schema.StringAttribute{
+			Computed: true,
+			PlanModifiers: []planmodifier.String{
+				stringplanmodifier.UseStateForUnknown(),
+			},
+		}
+	}
+
+	if s.Timeouts != nil {
+		out.Blocks["timeouts"] = s.Timeouts.frameworkBlock()
+	}
+
+	response.Schema = out
+}
+
+// isFrameworkBlock returns whether this field should be represented as a Block, rather than an Attribute, when using
+// the Plugin Framework - which matches the behaviour of Plugin SDKv2, where user-configurable nested objects are blocks.
+func (f TypedSchemaField) isFrameworkBlock() bool {
+	return f.NestedBlock != nil && (f.Optional || f.Required)
+}
+
+func (f TypedSchemaField) frameworkBlock() (schema.Block, error) {
+	nested := schema.NestedBlockObject{
+		Attributes: map[string]schema.Attribute{},
+		Blocks:     map[string]schema.Block{},
+	}
+	for k, v := range f.NestedBlock {
+		if v.isFrameworkBlock() {
+			block, err := v.frameworkBlock()
+			if err != nil {
+				return nil, fmt.Errorf("building %q: %+v", k, err)
+			}
+			nested.Blocks[k] = block
+			continue
+		}
+
+		attribute, err := v.frameworkAttribute()
+		if err != nil {
+			return nil, fmt.Errorf("building %q: %+v", k, err)
+		}
+		nested.Attributes[k] = attribute
+	}
+
+	minItems := f.MinItems
+	if f.Required && minItems == 0 {
+		minItems = 1
+	}
+
+	if f.Type == TypedSchemaTypeSet {
+		validators := make([]validator.Set, 0)
+		if f.Required {
+			validators = append(validators, setvalidator.IsRequired())
+		}
+		if minItems > 0 {
+			validators = append(validators, setvalidator.SizeAtLeast(minItems))
+		}
+		if f.MaxItems > 0 {
+			validators = append(validators, setvalidator.SizeAtMost(f.MaxItems))
+		}
+
+		planModifiers := make([]planmodifier.Set, 0)
+		if f.ForceNew {
+			planModifiers = append(planModifiers, setplanmodifier.RequiresReplace())
+		}
+
+		return schema.SetNestedBlock{
+			NestedObject:       nested,
+			Description:        f.Description,
+			DeprecationMessage: f.Deprecated,
+			Validators:         validators,
+			PlanModifiers:      planModifiers,
+		}, nil
+	}
+
+	validators := make([]validator.List, 0)
+	if f.Required {
+		validators = append(validators, listvalidator.IsRequired())
+	}
+	if minItems > 0 {
+		validators = append(validators, listvalidator.SizeAtLeast(minItems))
+	}
+	if f.MaxItems > 0 {
+		validators = append(validators, listvalidator.SizeAtMost(f.MaxItems))
+	}
+
+	planModifiers := make([]planmodifier.List, 0)
+	if f.ForceNew {
+		planModifiers = append(planModifiers, listplanmodifier.RequiresReplace())
+	}
+
+	return schema.ListNestedBlock{
+		NestedObject:       nested,
+		Description:        f.Description,
+		DeprecationMessage: f.Deprecated,
+		Validators:         validators,
+		PlanModifiers:      planModifiers,
+	}, nil
+}
+
+func (f TypedSchemaField) frameworkAttribute() (schema.Attribute, error) {
+	// the Plugin Framework requires that any field with a Default is also Computed
+	computed := f.Computed || f.Default != nil
+
+	switch f.Type {
+	case TypedSchemaTypeBool:
+		out := schema.BoolAttribute{
+			Required:           f.Required,
+			Optional:           f.Optional,
+			Computed:           computed,
+			Sensitive:          f.Sensitive,
+			Description:        f.Description,
+			DeprecationMessage: f.Deprecated,
+		}
+		if f.ForceNew {
+			out.PlanModifiers = []planmodifier.Bool{boolplanmodifier.RequiresReplace()}
+		}
+		if f.Default != nil {
+			out.Default = booldefault.StaticBool(f.Default.(bool))
+		}
+		return out, nil
+
+	case TypedSchemaTypeFloat:
+		out := schema.Float64Attribute{
+			Required:           f.Required,
+			Optional:           f.Optional,
+			Computed:           computed,
+			Sensitive:          f.Sensitive,
+			Description:        f.Description,
+			DeprecationMessage: f.Deprecated,
+		}
+		if f.ForceNew {
+			out.PlanModifiers = []planmodifier.Float64{float64planmodifier.RequiresReplace()}
+		}
+		if f.Default != nil {
+			out.Default = float64default.StaticFloat64(f.Default.(float64))
+		}
+		if f.ValidateFunc != nil {
+			out.Validators = []validator.Float64{frameworkhelpers.WrappedFloat64Validator{Func: f.ValidateFunc}}
+		}
+		return out, nil
+
+	case TypedSchemaTypeInt:
+		out := schema.Int64Attribute{
+			Required:           f.Required,
+			Optional:           f.Optional,
+			Computed:           computed,
+			Sensitive:          f.Sensitive,
+			Description:        f.Description,
+			DeprecationMessage: f.Deprecated,
+		}
+		if f.ForceNew {
+			out.PlanModifiers = []planmodifier.Int64{int64planmodifier.RequiresReplace()}
+		}
+		if f.Default != nil {
+			out.Default = int64default.StaticInt64(int64(f.Default.(int)))
+		}
+		if f.ValidateFunc != nil {
+			out.Validators = []validator.Int64{frameworkhelpers.WrappedInt64Validator{Func: f.ValidateFunc}}
+		}
+		return out, nil
+
+	case TypedSchemaTypeString:
+		out := schema.StringAttribute{
+			Required:           f.Required,
+			Optional:           f.Optional,
+			Computed:           computed,
+			Sensitive:          f.Sensitive,
+			Description:        f.Description,
+			DeprecationMessage: f.Deprecated,
+		}
+		if f.ForceNew {
+			out.PlanModifiers = []planmodifier.String{stringplanmodifier.RequiresReplace()}
+		}
+		if f.Default != nil {
+			out.Default = stringdefault.StaticString(f.Default.(string))
+		}
+		if f.ValidateFunc != nil {
+			out.Validators = []validator.String{frameworkhelpers.WrappedStringValidator{Func: f.ValidateFunc}}
+		}
+		return out, nil
+
+	case TypedSchemaTypeMap:
+		out := schema.MapAttribute{
+			ElementType:        f.ElementType.frameworkType(),
+			Required:           f.Required,
+			Optional:           f.Optional,
+			Computed:           f.Computed,
+			Sensitive:          f.Sensitive,
+			Description:        f.Description,
+			DeprecationMessage: f.Deprecated,
+		}
+		if f.ForceNew {
+			out.PlanModifiers = []planmodifier.Map{mapplanmodifier.RequiresReplace()}
+		}
+		return out, nil
+
+	case TypedSchemaTypeList:
+		if f.NestedBlock != nil {
+			return schema.ListAttribute{
+				ElementType:        f.frameworkNestedObjectType(),
+				Computed:           true,
+				Sensitive:          f.Sensitive,
+				Description:        f.Description,
+				DeprecationMessage: f.Deprecated,
+			}, nil
+		}
+
+		out := schema.ListAttribute{
+			ElementType:        f.ElementType.frameworkType(),
+			Required:           f.Required,
+			Optional:           f.Optional,
+			Computed:           f.Computed,
+			Sensitive:          f.Sensitive,
+			Description:        f.Description,
+			DeprecationMessage: f.Deprecated,
+		}
+		if f.ForceNew {
+			out.PlanModifiers = []planmodifier.List{listplanmodifier.RequiresReplace()}
+		}
+		if f.MinItems > 0 {
+			out.Validators = append(out.Validators, listvalidator.SizeAtLeast(f.MinItems))
+		}
+		if f.MaxItems > 0 {
+			out.Validators = append(out.Validators, listvalidator.SizeAtMost(f.MaxItems))
+		}
+		if f.ValidateFunc != nil {
+			switch f.ElementType {
+			case TypedSchemaTypeFloat:
+				out.Validators = append(out.Validators, listvalidator.ValueFloat64sAre(frameworkhelpers.WrappedFloat64Validator{Func: f.ValidateFunc}))
+			case TypedSchemaTypeInt:
+				out.Validators = append(out.Validators, listvalidator.ValueInt64sAre(frameworkhelpers.WrappedInt64Validator{Func: f.ValidateFunc}))
+			case TypedSchemaTypeString:
+				out.Validators = append(out.Validators, listvalidator.ValueStringsAre(frameworkhelpers.WrappedStringValidator{Func: f.ValidateFunc}))
+			}
+		}
+		return out, nil
+
+	case TypedSchemaTypeSet:
+		if f.NestedBlock != nil {
+			return schema.SetAttribute{
+				ElementType:        f.frameworkNestedObjectType(),
+				Computed:           true,
+				Sensitive:          f.Sensitive,
+				Description:        f.Description,
+				DeprecationMessage: f.Deprecated,
+			}, nil
+		}
+
+		out := schema.SetAttribute{
+			ElementType:        f.ElementType.frameworkType(),
+			Required:           f.Required,
+			Optional:           f.Optional,
+			Computed:           f.Computed,
+			Sensitive:          f.Sensitive,
+			Description:        f.Description,
+			DeprecationMessage: f.Deprecated,
+		}
+		if f.ForceNew {
+			out.PlanModifiers = []planmodifier.Set{setplanmodifier.RequiresReplace()}
+		}
+		if f.MinItems > 0 {
+			out.Validators = append(out.Validators, setvalidator.SizeAtLeast(f.MinItems))
+		}
+		if f.MaxItems > 0 {
+			out.Validators = append(out.Validators, setvalidator.SizeAtMost(f.MaxItems))
+		}
+		if f.ValidateFunc != nil {
+			switch f.ElementType {
+			case TypedSchemaTypeFloat:
+				out.Validators = append(out.Validators, setvalidator.ValueFloat64sAre(frameworkhelpers.WrappedFloat64Validator{Func: f.ValidateFunc}))
+			case TypedSchemaTypeInt:
+				out.Validators = append(out.Validators, setvalidator.ValueInt64sAre(frameworkhelpers.WrappedInt64Validator{Func: f.ValidateFunc}))
+			case TypedSchemaTypeString:
+				out.Validators = append(out.Validators, setvalidator.ValueStringsAre(frameworkhelpers.WrappedStringValidator{Func: f.ValidateFunc}))
+			}
+		}
+		return out, nil
+	}
+
+	return nil, fmt.Errorf("unsupported TypedSchemaType %q", f.Type)
+}
+
+// frameworkNestedObjectType returns the object type for each element within a Computed-only List or Set of objects, which
+// (as with Plugin SDKv2) is exposed as an Attribute rather than a Block, since it can't be configured by users.
+// NOTE: Nested Attributes aren't supported in Protocol v5, so this is intentionally exposed as a List/Set of objects.
+func (f TypedSchemaField) frameworkNestedObjectType() types.ObjectType {
+	attributeTypes := make(map[string]attr.Type)
+	for k, v := range f.NestedBlock {
+		attributeTypes[k] = v.frameworkAttributeType()
+	}
+
+	return types.ObjectType{
+		AttrTypes: attributeTypes,
+	}
+}
+
+func (f TypedSchemaField) frameworkAttributeType() attr.Type {
+	switch f.Type {
+	case TypedSchemaTypeList:
+		if f.NestedBlock != nil {
+			return types.ListType{ElemType: f.frameworkNestedObjectType()}
+		}
+		return types.ListType{ElemType: f.ElementType.frameworkType()}
+
+	case TypedSchemaTypeSet:
+		if f.NestedBlock != nil {
+			return types.SetType{ElemType: f.frameworkNestedObjectType()}
+		}
+		return types.SetType{ElemType: f.ElementType.frameworkType()}
+
+	case TypedSchemaTypeMap:
+		return types.MapType{ElemType: f.ElementType.frameworkType()}
+	}
+
+	return f.Type.frameworkType()
+}
+
+func (t TypedSchemaType) frameworkType() attr.Type {
+	switch t {
+	case TypedSchemaTypeBool:
+		return types.BoolType
+	case TypedSchemaTypeFloat:
+		return types.Float64Type
+	case TypedSchemaTypeInt:
+		return types.Int64Type
+	case TypedSchemaTypeString:
+		return types.StringType
+	}
+
+	return nil
+}
+
+func (t TypedSchemaTimeouts) frameworkBlock() schema.Block {
+	attributes := map[string]schema.Attribute{
+		"create": schema.StringAttribute{Optional: true},
+		"read":   schema.StringAttribute{Optional: true},
+		"delete": schema.StringAttribute{Optional: true},
+	}
+	if t.Update != nil {
+		attributes["update"] = schema.StringAttribute{Optional: true}
+	}
+
+	return schema.SingleNestedBlock{
+		Attributes: attributes,
+	}
+}
+
+// Configured returns a copy of these Timeouts, overridden by any values the user has specified within the `timeouts`
+// block generated for a Framework Resource.
+func (t TypedSchemaTimeouts) Configured(_ context.Context, timeouts types.Object) (*TypedSchemaTimeouts, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	out := t
+	if timeouts.IsNull() || timeouts.IsUnknown() {
+		return &out, diags
+	}
+
+	values := timeouts.Attributes()
+	parse := func(key string, defaultValue time.Duration) time.Duration {
+		v, ok := values[key].(types.String)
+		if !ok || v.IsNull() || v.IsUnknown() {
+			return defaultValue
+		}
+
+		duration, err := time.ParseDuration(v.ValueString())
+		if err != nil {
+			diags.AddError("Invalid Timeout", fmt.Sprintf("parsing the %q timeout %q: %+v", key, v.ValueString(), err))
+			return defaultValue
+		}
+
+		return duration
+	}
+
+	out.Create = parse("create", t.Create)
+	out.Read = parse("read", t.Read)
+	out.Delete = parse("delete", t.Delete)
+	if t.Update != nil {
+		out.Update = pointer.To(parse("update", *t.Update))
+	}
+
+	return &out, diags
+}
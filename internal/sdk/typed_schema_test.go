@@ -0,0 +1,750 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package sdk
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/hashicorp/go-azure-helpers/lang/pointer"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/provider"
+	"github.com/hashicorp/terraform-plugin-framework/providerserver"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	frameworkschema "github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov5"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/clients"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
+)
+
+func TestTypedSchema_RoundTrip(t *testing.T) {
+	testData := []struct {
+		name   string
+		schema TypedSchema
+	}{
+		{
+			name: "primitives",
+			schema: TypedSchema{
+				Arguments: map[string]TypedSchemaField{
+					"name": {
+						Type:         TypedSchemaTypeString,
+						Required:     true,
+						ForceNew:     true,
+						ValidateFunc: validation.StringIsNotEmpty,
+						Description:  "The name of this resource.",
+					},
+					"enabled": {
+						Type:     TypedSchemaTypeBool,
+						Optional: true,
+						Computed: true,
+					},
+					"instance_count": {
+						Type:         TypedSchemaTypeInt,
+						Optional:     true,
+						ValidateFunc: validation.IntBetween(1, 10),
+						Deprecated:   "`instance_count` has been deprecated.",
+					},
+					"ratio": {
+						Type:     TypedSchemaTypeFloat,
+						Optional: true,
+					},
+					"secret": {
+						Type:      TypedSchemaTypeString,
+						Optional:  true,
+						Sensitive: true,
+					},
+				},
+				Attributes: map[string]TypedSchemaField{
+					"output": {
+						Type: TypedSchemaTypeString,
+					},
+				},
+				Timeouts: &typedSchemaTestTimeouts,
+			},
+		},
+		{
+			name: "collections",
+			schema: TypedSchema{
+				Arguments: map[string]TypedSchemaField{
+					"networks": {
+						Type:         TypedSchemaTypeList,
+						ElementType:  TypedSchemaTypeString,
+						Optional:     true,
+						MinItems:     1,
+						ValidateFunc: validation.StringIsNotEmpty,
+					},
+					"ports": {
+						Type:        TypedSchemaTypeSet,
+						ElementType: TypedSchemaTypeInt,
+						Required:    true,
+						MaxItems:    5,
+					},
+					"tags": {
+						Type:        TypedSchemaTypeMap,
+						ElementType: TypedSchemaTypeString,
+						Optional:    true,
+					},
+				},
+				Attributes: map[string]TypedSchemaField{
+					"addresses": {
+						Type:        TypedSchemaTypeList,
+						ElementType: TypedSchemaTypeString,
+					},
+				},
+				Timeouts: &typedSchemaTestTimeouts,
+			},
+		},
+		{
+			name: "nested blocks",
+			schema: TypedSchema{
+				Arguments: map[string]TypedSchemaField{
+					"configuration": {
+						Type:     TypedSchemaTypeList,
+						Optional: true,
+						MaxItems: 1,
+						NestedBlock: map[string]TypedSchemaField{
+							"key": {
+								Type:     TypedSchemaTypeString,
+								Required: true,
+							},
+							"rule": {
+								Type:     TypedSchemaTypeSet,
+								Required: true,
+								NestedBlock: map[string]TypedSchemaField{
+									"priority": {
+										Type:     TypedSchemaTypeInt,
+										Required: true,
+									},
+									"identifier": {
+										Type:     TypedSchemaTypeString,
+										Computed: true,
+									},
+								},
+							},
+						},
+					},
+					"identity": {
+						Type:     TypedSchemaTypeSet,
+						Optional: true,
+						NestedBlock: map[string]TypedSchemaField{
+							"type": {
+								Type:     TypedSchemaTypeString,
+								Required: true,
+							},
+						},
+					},
+				},
+				Attributes: map[string]TypedSchemaField{
+					"endpoints": {
+						Type: TypedSchemaTypeList,
+						NestedBlock: map[string]TypedSchemaField{
+							"name": {
+								Type: TypedSchemaTypeString,
+							},
+							"ports": {
+								Type:        TypedSchemaTypeSet,
+								ElementType: TypedSchemaTypeInt,
+							},
+						},
+					},
+				},
+				Timeouts: &typedSchemaTestTimeouts,
+			},
+		},
+		{
+			name: "timeouts",
+			schema: TypedSchema{
+				Arguments: map[string]TypedSchemaField{
+					"name": {
+						Type:     TypedSchemaTypeString,
+						Required: true,
+					},
+				},
+				Timeouts: &TypedSchemaTimeouts{
+					Create: time.Hour,
+					Read:   10 * time.Minute,
+					Update: pointer.To(time.Hour),
+					Delete: time.Hour,
+				},
+			},
+		},
+	}
+
+	for _, v := range testData {
+		t.Run(v.name, func(t *testing.T) {
+			pluginSdk, err := typedSchemaPluginSdkProviderSchema(v.schema)
+			if err != nil {
+				t.Fatalf("building the Plugin SDKv2 schema: %+v", err)
+			}
+
+			framework, err := typedSchemaFrameworkProviderSchema(v.schema)
+			if err != nil {
+				t.Fatalf("building the Plugin Framework schema: %+v", err)
+			}
+
+			if diff := cmp.Diff(pluginSdk, framework); diff != "" {
+				t.Fatalf("the Plugin SDKv2 and Plugin Framework schemas differ (-sdkv2 +framework):\n%s", diff)
+			}
+		})
+	}
+}
+
+// typedSchemaTestTimeouts are the Timeouts used in the tests, since Resources using the ResourceWrapper always
+// expose a `timeouts` block
+var typedSchemaTestTimeouts = TypedSchemaTimeouts{
+	Create: 30 * time.Minute,
+	Read:   5 * time.Minute,
+	Update: pointer.To(30 * time.Minute),
+	Delete: 30 * time.Minute,
+}
+
+func TestTypedSchema_Default(t *testing.T) {
+	s := TypedSchema{
+		Arguments: map[string]TypedSchemaField{
+			"sku": {
+				Type:     TypedSchemaTypeString,
+				Optional: true,
+				Default:  "Standard",
+			},
+		},
+	}
+
+	if v := s.PluginSdkArguments()["sku"]; v.Default != "Standard" || v.Computed {
+		t.Fatalf("expected the Plugin SDKv2 field to have a Default of `Standard` and not be Computed but got %+v", v)
+	}
+
+	response := &resource.SchemaResponse{}
+	s.FrameworkResourceSchema(context.Background(), resource.SchemaRequest{}, response)
+	if response.Diagnostics.HasError() {
+		t.Fatalf("building Framework schema: %+v", response.Diagnostics)
+	}
+
+	attribute, ok := response.Schema.Attributes["sku"].(frameworkschema.StringAttribute)
+	if !ok {
+		t.Fatalf("expected `sku` to be a StringAttribute but got %+v", response.Schema.Attributes["sku"])
+	}
+	if attribute.Default == nil || !attribute.Computed {
+		t.Fatalf("expected the Framework attribute to have a Default and be Computed but got %+v", attribute)
+	}
+}
+
+func TestTypedSchema_Validate(t *testing.T) {
+	testData := []struct {
+		name   string
+		schema TypedSchema
+		valid  bool
+	}{
+		{
+			name: "valid",
+			schema: TypedSchema{
+				Arguments: map[string]TypedSchemaField{
+					"name": {Type: TypedSchemaTypeString, Required: true},
+				},
+				Attributes: map[string]TypedSchemaField{
+					"output": {Type: TypedSchemaTypeString},
+				},
+			},
+			valid: true,
+		},
+		{
+			name: "duplicate field",
+			schema: TypedSchema{
+				Arguments: map[string]TypedSchemaField{
+					"name": {Type: TypedSchemaTypeString, Required: true},
+				},
+				Attributes: map[string]TypedSchemaField{
+					"name": {Type: TypedSchemaTypeString},
+				},
+			},
+		},
+		{
+			name: "computed-only argument",
+			schema: TypedSchema{
+				Arguments: map[string]TypedSchemaField{
+					"name": {Type: TypedSchemaTypeString, Computed: true},
+				},
+			},
+		},
+		{
+			name: "optional attribute",
+			schema: TypedSchema{
+				Attributes: map[string]TypedSchemaField{
+					"name": {Type: TypedSchemaTypeString, Optional: true},
+				},
+			},
+		},
+		{
+			name: "required and optional",
+			schema: TypedSchema{
+				Arguments: map[string]TypedSchemaField{
+					"name": {Type: TypedSchemaTypeString, Required: true, Optional: true},
+				},
+			},
+		},
+		{
+			name: "list without element type",
+			schema: TypedSchema{
+				Arguments: map[string]TypedSchemaField{
+					"names": {Type: TypedSchemaTypeList, Optional: true},
+				},
+			},
+		},
+		{
+			name: "list with element type and nested block",
+			schema: TypedSchema{
+				Arguments: map[string]TypedSchemaField{
+					"names": {
+						Type:        TypedSchemaTypeList,
+						Optional:    true,
+						ElementType: TypedSchemaTypeString,
+						NestedBlock: map[string]TypedSchemaField{
+							"name": {Type: TypedSchemaTypeString, Required: true},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "map with nested block",
+			schema: TypedSchema{
+				Arguments: map[string]TypedSchemaField{
+					"tags": {
+						Type:     TypedSchemaTypeMap,
+						Optional: true,
+						NestedBlock: map[string]TypedSchemaField{
+							"name": {Type: TypedSchemaTypeString, Required: true},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "default of the wrong type",
+			schema: TypedSchema{
+				Arguments: map[string]TypedSchemaField{
+					"count": {Type: TypedSchemaTypeInt, Optional: true, Default: "1"},
+				},
+			},
+		},
+		{
+			name: "default on a required field",
+			schema: TypedSchema{
+				Arguments: map[string]TypedSchemaField{
+					"count": {Type: TypedSchemaTypeInt, Required: true, Default: 1},
+				},
+			},
+		},
+		{
+			name: "optional field within a computed-only block",
+			schema: TypedSchema{
+				Attributes: map[string]TypedSchemaField{
+					"endpoints": {
+						Type: TypedSchemaTypeList,
+						NestedBlock: map[string]TypedSchemaField{
+							"name": {Type: TypedSchemaTypeString, Optional: true},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "min items greater than max items",
+			schema: TypedSchema{
+				Arguments: map[string]TypedSchemaField{
+					"names": {Type: TypedSchemaTypeList, ElementType: TypedSchemaTypeString, Optional: true, MinItems: 2, MaxItems: 1},
+				},
+			},
+		},
+		{
+			name: "optional and computed nested block",
+			schema: TypedSchema{
+				Arguments: map[string]TypedSchemaField{
+					"identity": {
+						Type:     TypedSchemaTypeList,
+						Optional: true,
+						Computed: true,
+						NestedBlock: map[string]TypedSchemaField{
+							"type": {Type: TypedSchemaTypeString, Required: true},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "sensitive nested block",
+			schema: TypedSchema{
+				Arguments: map[string]TypedSchemaField{
+					"credential": {
+						Type:      TypedSchemaTypeList,
+						Required:  true,
+						Sensitive: true,
+						NestedBlock: map[string]TypedSchemaField{
+							"secret": {Type: TypedSchemaTypeString, Required: true},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "computed block within a nested block",
+			schema: TypedSchema{
+				Arguments: map[string]TypedSchemaField{
+					"rule": {
+						Type:     TypedSchemaTypeList,
+						Optional: true,
+						NestedBlock: map[string]TypedSchemaField{
+							"target": {
+								Type:     TypedSchemaTypeList,
+								Optional: true,
+								Computed: true,
+								NestedBlock: map[string]TypedSchemaField{
+									"name": {Type: TypedSchemaTypeString, Required: true},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "validate func on a bool",
+			schema: TypedSchema{
+				Arguments: map[string]TypedSchemaField{
+					"enabled": {Type: TypedSchemaTypeBool, Optional: true, ValidateFunc: validation.StringIsNotEmpty},
+				},
+			},
+		},
+	}
+
+	for _, v := range testData {
+		t.Logf("[DEBUG] Testing %q..", v.name)
+
+		err := v.schema.Validate()
+		if v.valid && err != nil {
+			t.Fatalf("expected %q to be valid but got %+v", v.name, err)
+		}
+		if !v.valid && err == nil {
+			t.Fatalf("expected %q to be invalid but didn't get an error", v.name)
+		}
+	}
+}
+
+func TestTypedSchemaTimeouts_Configured(t *testing.T) {
+	defaults := TypedSchemaTimeouts{
+		Create: 30 * time.Minute,
+		Read:   5 * time.Minute,
+		Update: pointer.To(30 * time.Minute),
+		Delete: 30 * time.Minute,
+	}
+
+	timeouts := types.ObjectValueMust(map[string]attr.Type{
+		"create": types.StringType,
+		"read":   types.StringType,
+		"update": types.StringType,
+		"delete": types.StringType,
+	}, map[string]attr.Value{
+		"create": types.StringValue("1h"),
+		"read":   types.StringNull(),
+		"update": types.StringValue("45m"),
+		"delete": types.StringNull(),
+	})
+
+	actual, diags := defaults.Configured(context.Background(), timeouts)
+	if diags.HasError() {
+		t.Fatalf("configuring timeouts: %+v", diags)
+	}
+
+	expected := TypedSchemaTimeouts{
+		Create: time.Hour,
+		Read:   5 * time.Minute,
+		Update: pointer.To(45 * time.Minute),
+		Delete: 30 * time.Minute,
+	}
+	if diff := cmp.Diff(expected, *actual); diff != "" {
+		t.Fatalf("unexpected timeouts (-expected +actual):\n%s", diff)
+	}
+
+	invalid := types.ObjectValueMust(map[string]attr.Type{
+		"create": types.StringType,
+	}, map[string]attr.Value{
+		"create": types.StringValue("soon"),
+	})
+	if _, diags := defaults.Configured(context.Background(), invalid); !diags.HasError() {
+		t.Fatalf("expected an error for an invalid timeout but didn't get one")
+	}
+}
+
+func TestResourceMetadata_DefaultsWithTypedSchema(t *testing.T) {
+	r := &typedSchemaTestFrameworkResource{
+		schema: TypedSchema{
+			Arguments: map[string]TypedSchemaField{
+				"name": {
+					Type:     TypedSchemaTypeString,
+					Required: true,
+				},
+			},
+			Timeouts: &TypedSchemaTimeouts{
+				Create: time.Hour,
+				Read:   10 * time.Minute,
+				Delete: 2 * time.Hour,
+			},
+		},
+	}
+
+	request := resource.ConfigureRequest{
+		ProviderData: &clients.Client{
+			Account: &clients.ResourceManagerAccount{
+				SubscriptionId: "00000000-0000-0000-0000-000000000000",
+			},
+		},
+	}
+	response := &resource.ConfigureResponse{}
+	r.Configure(context.Background(), request, response)
+	if response.Diagnostics.HasError() {
+		t.Fatalf("configuring: %+v", response.Diagnostics)
+	}
+
+	if r.TimeoutCreate != time.Hour || r.TimeoutRead != 10*time.Minute || r.TimeoutDelete != 2*time.Hour || r.TimeoutUpdate != nil {
+		t.Fatalf("expected the Timeouts from the Typed Schema but got Create %s / Read %s / Delete %s / Update %v", r.TimeoutCreate, r.TimeoutRead, r.TimeoutDelete, r.TimeoutUpdate)
+	}
+
+	timeouts := types.ObjectValueMust(map[string]attr.Type{
+		"create": types.StringType,
+		"read":   types.StringType,
+		"delete": types.StringType,
+	}, map[string]attr.Value{
+		"create": types.StringNull(),
+		"read":   types.StringNull(),
+		"delete": types.StringValue("3h"),
+	})
+	if diags := r.ConfigureTimeouts(context.Background(), timeouts); diags.HasError() {
+		t.Fatalf("configuring timeouts: %+v", diags)
+	}
+
+	if r.TimeoutCreate != time.Hour || r.TimeoutDelete != 3*time.Hour {
+		t.Fatalf("expected the configured Timeouts to be used but got Create %s / Delete %s", r.TimeoutCreate, r.TimeoutDelete)
+	}
+}
+
+// typedSchemaPluginSdkProviderSchema returns a normalised version of the schema for a Resource using this TypedSchema, as
+// exposed to Terraform by the Plugin SDKv2 via the ResourceWrapper
+func typedSchemaPluginSdkProviderSchema(input TypedSchema) (map[string]string, error) {
+	wrapper := NewResourceWrapper(typedSchemaTestResource{schema: input})
+	r, err := wrapper.Resource()
+	if err != nil {
+		return nil, fmt.Errorf("wrapping resource: %+v", err)
+	}
+
+	p := &schema.Provider{
+		ResourcesMap: map[string]*schema.Resource{
+			"example_resource": r,
+		},
+	}
+	resp, err := schema.NewGRPCProviderServer(p).GetProviderSchema(context.Background(), &tfprotov5.GetProviderSchemaRequest{})
+	if err != nil {
+		return nil, err
+	}
+
+	return normaliseProviderSchema(resp)
+}
+
+// typedSchemaFrameworkProviderSchema returns a normalised version of the schema for a Resource using this TypedSchema, as
+// exposed to Terraform by the Plugin Framework
+func typedSchemaFrameworkProviderSchema(input TypedSchema) (map[string]string, error) {
+	server := providerserver.NewProtocol5(&typedSchemaTestProvider{schema: input})()
+	resp, err := server.GetProviderSchema(context.Background(), &tfprotov5.GetProviderSchemaRequest{})
+	if err != nil {
+		return nil, err
+	}
+
+	return normaliseProviderSchema(resp)
+}
+
+func normaliseProviderSchema(input *tfprotov5.GetProviderSchemaResponse) (map[string]string, error) {
+	for _, d := range input.Diagnostics {
+		if d.Severity == tfprotov5.DiagnosticSeverityError {
+			return nil, fmt.Errorf("%s: %s", d.Summary, d.Detail)
+		}
+	}
+
+	v, ok := input.ResourceSchemas["example_resource"]
+	if !ok {
+		return nil, fmt.Errorf("`example_resource` was not found in the provider schema")
+	}
+
+	out := make(map[string]string)
+	normaliseSchemaBlock(v.Block, "", out)
+
+	// Plugin SDKv2 exposes the `id` field as Optional & Computed, whereas this is Computed-only in the Framework
+	delete(out, "id")
+
+	return out, nil
+}
+
+func normaliseSchemaBlock(input *tfprotov5.SchemaBlock, prefix string, out map[string]string) {
+	for _, v := range input.Attributes {
+		out[prefix+v.Name] = fmt.Sprintf("type=%s required=%t optional=%t computed=%t sensitive=%t deprecated=%t description=%q", normaliseSchemaType(v.Type), v.Required, v.Optional, v.Computed, v.Sensitive, v.Deprecated, v.Description)
+	}
+
+	for _, v := range input.BlockTypes {
+		// NOTE: MinItems/MaxItems are intentionally not compared, since these are expressed as Validators in the Framework
+		out[prefix+v.TypeName] = fmt.Sprintf("nesting=%s deprecated=%t description=%q", v.Nesting.String(), v.Block.Deprecated, v.Block.Description)
+		normaliseSchemaBlock(v.Block, fmt.Sprintf("%s%s.", prefix, v.TypeName), out)
+	}
+}
+
+func normaliseSchemaType(input interface{ String() string }) string {
+	// the ordering of the attributes within an object isn't guaranteed, so sort these for consistency
+	value := input.String()
+	start := strings.Index(value, "Object[")
+	if start == -1 {
+		return value
+	}
+
+	fields := strings.Split(strings.TrimSuffix(value[start+len("Object["):], "]"), ", ")
+	sort.Strings(fields)
+	return value[:start] + "Object[" + strings.Join(fields, ", ") + "]"
+}
+
+var _ ResourceWithTypedSchema = typedSchemaTestResource{}
+
+type typedSchemaTestResource struct {
+	schema TypedSchema
+}
+
+func (r typedSchemaTestResource) TypedSchema() TypedSchema {
+	return r.schema
+}
+
+func (r typedSchemaTestResource) Arguments() map[string]*pluginsdk.Schema {
+	return r.schema.PluginSdkArguments()
+}
+
+func (r typedSchemaTestResource) Attributes() map[string]*pluginsdk.Schema {
+	return r.schema.PluginSdkAttributes()
+}
+
+func (r typedSchemaTestResource) ModelObject() interface{} {
+	return nil
+}
+
+func (r typedSchemaTestResource) ResourceType() string {
+	return "example_resource"
+}
+
+func (r typedSchemaTestResource) Create() ResourceFunc {
+	return ResourceFunc{
+		Func: func(ctx context.Context, metadata ResourceMetaData) error {
+			return nil
+		},
+		Timeout: 30 * time.Minute,
+	}
+}
+
+func (r typedSchemaTestResource) Read() ResourceFunc {
+	return ResourceFunc{
+		Func: func(ctx context.Context, metadata ResourceMetaData) error {
+			return nil
+		},
+		Timeout: 5 * time.Minute,
+	}
+}
+
+func (r typedSchemaTestResource) Update() ResourceFunc {
+	return ResourceFunc{
+		Func: func(ctx context.Context, metadata ResourceMetaData) error {
+			return nil
+		},
+		Timeout: 30 * time.Minute,
+	}
+}
+
+func (r typedSchemaTestResource) Delete() ResourceFunc {
+	return ResourceFunc{
+		Func: func(ctx context.Context, metadata ResourceMetaData) error {
+			return nil
+		},
+		Timeout: 30 * time.Minute,
+	}
+}
+
+func (r typedSchemaTestResource) IDValidationFunc() pluginsdk.SchemaValidateFunc {
+	return validation.StringIsNotEmpty
+}
+
+var _ provider.Provider = &typedSchemaTestProvider{}
+
+type typedSchemaTestProvider struct {
+	schema TypedSchema
+}
+
+func (p *typedSchemaTestProvider) Metadata(_ context.Context, _ provider.MetadataRequest, response *provider.MetadataResponse) {
+	response.TypeName = "example"
+}
+
+func (p *typedSchemaTestProvider) Schema(_ context.Context, _ provider.SchemaRequest, _ *provider.SchemaResponse) {
+}
+
+func (p *typedSchemaTestProvider) Configure(_ context.Context, _ provider.ConfigureRequest, _ *provider.ConfigureResponse) {
+}
+
+func (p *typedSchemaTestProvider) DataSources(_ context.Context) []func() datasource.DataSource {
+	return nil
+}
+
+func (p *typedSchemaTestProvider) Resources(_ context.Context) []func() resource.Resource {
+	return []func() resource.Resource{
+		func() resource.Resource {
+			return &typedSchemaTestFrameworkResource{schema: p.schema}
+		},
+	}
+}
+
+var _ FrameworkResourceWithTypedSchema = &typedSchemaTestFrameworkResource{}
+
+type typedSchemaTestFrameworkResource struct {
+	ResourceMetadata
+
+	schema TypedSchema
+}
+
+func (r *typedSchemaTestFrameworkResource) TypedSchema() TypedSchema {
+	return r.schema
+}
+
+func (r *typedSchemaTestFrameworkResource) Configure(_ context.Context, request resource.ConfigureRequest, response *resource.ConfigureResponse) {
+	r.DefaultsWithTypedSchema(request, response, r.TypedSchema())
+}
+
+func (r *typedSchemaTestFrameworkResource) ImportState(_ context.Context, _ resource.ImportStateRequest, _ *resource.ImportStateResponse) {
+}
+
+func (r *typedSchemaTestFrameworkResource) Metadata(_ context.Context, request resource.MetadataRequest, response *resource.MetadataResponse) {
+	response.TypeName = request.ProviderTypeName + "_resource"
+}
+
+func (r *typedSchemaTestFrameworkResource) Schema(ctx context.Context, request resource.SchemaRequest, response *resource.SchemaResponse) {
+	r.TypedSchema().FrameworkResourceSchema(ctx, request, response)
+}
+
+func (r *typedSchemaTestFrameworkResource) Create(_ context.Context, _ resource.CreateRequest, _ *resource.CreateResponse) {
+}
+
+func (r *typedSchemaTestFrameworkResource) Read(_ context.Context, _ resource.ReadRequest, _ *resource.ReadResponse) {
+}
+
+func (r *typedSchemaTestFrameworkResource) Update(_ context.Context, _ resource.UpdateRequest, _ *resource.UpdateResponse) {
+}
+
+func (r *typedSchemaTestFrameworkResource) Delete(_ context.Context, _ resource.DeleteRequest, _ *resource.DeleteResponse) {
+}
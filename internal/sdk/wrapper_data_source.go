@@ -46,7 +46,7 @@ func (dw *DataSourceWrapper) DataSource() (*schema.Resource, error) {
 
 	resource := schema.Resource{
 		Schema: *resourceSchema,
-		ReadContext: dw.diagnosticsWrapper(func(ctx context.Context, d *schema.ResourceData, meta interface{}) error {
+		ReadContext: dw.diagnosticsWrapper("Read", func(ctx context.Context, d *schema.ResourceData, meta interface{}) error {
 			metaData := runArgs(d, meta, dw.logger)
 			return dw.dataSource.Read().Func(ctx, metaData)
 		}),
@@ -71,6 +71,6 @@ and we recommend using the %[2]q datasource instead.
 	return &resource, nil
 }
 
-func (dw *DataSourceWrapper) diagnosticsWrapper(in func(ctx context.Context, d *schema.ResourceData, meta interface{}) error) schema.ReadContextFunc {
-	return diagnosticsWrapper(in, dw.logger)
+func (dw *DataSourceWrapper) diagnosticsWrapper(operation string, in func(ctx context.Context, d *schema.ResourceData, meta interface{}) error) schema.ReadContextFunc {
+	return diagnosticsWrapper(dw.dataSource.ResourceType(), operation, in, dw.logger)
 }
@@ -12,7 +12,9 @@ import (
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/clients"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/common"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tracing"
 )
 
 // ResourceWrapper is a wrapper for converting a Resource implementation
@@ -32,7 +34,20 @@ func NewResourceWrapper(resource Resource) ResourceWrapper {
 
 // Resource returns the Terraform Plugin SDK type for this Resource implementation
 func (rw *ResourceWrapper) Resource() (*schema.Resource, error) {
-	resourceSchema, err := combineSchema(rw.resource.Arguments(), rw.resource.Attributes())
+	arguments := rw.resource.Arguments()
+	attributes := rw.resource.Attributes()
+	var typedSchema *TypedSchema
+	if v, ok := rw.resource.(ResourceWithTypedSchema); ok {
+		s := v.TypedSchema()
+		if err := s.Validate(); err != nil {
+			return nil, fmt.Errorf("validating Typed Schema: %+v", err)
+		}
+		arguments = s.PluginSdkArguments()
+		attributes = s.PluginSdkAttributes()
+		typedSchema = &s
+	}
+
+	resourceSchema, err := combineSchema(arguments, attributes)
 	if err != nil {
 		return nil, fmt.Errorf("building Schema: %+v", err)
 	}
@@ -51,7 +66,7 @@ func (rw *ResourceWrapper) Resource() (*schema.Resource, error) {
 	resource := schema.Resource{
 		Schema: *resourceSchema,
 
-		CreateContext: rw.diagnosticsWrapper(func(ctx context.Context, d *schema.ResourceData, meta interface{}) error {
+		CreateContext: rw.diagnosticsWrapper("Create", func(ctx context.Context, d *schema.ResourceData, meta interface{}) error {
 			metaData := runArgs(d, meta, rw.logger)
 			err := rw.resource.Create().Func(ctx, metaData)
 			if err != nil {
@@ -64,11 +79,11 @@ func (rw *ResourceWrapper) Resource() (*schema.Resource, error) {
 		}),
 
 		// looks like these could be reused, easiest if they're not
-		ReadContext: rw.diagnosticsWrapper(func(ctx context.Context, d *schema.ResourceData, meta interface{}) error {
+		ReadContext: rw.diagnosticsWrapper("Read", func(ctx context.Context, d *schema.ResourceData, meta interface{}) error {
 			metaData := runArgs(d, meta, rw.logger)
 			return rw.resource.Read().Func(ctx, metaData)
 		}),
-		DeleteContext: rw.diagnosticsWrapper(func(ctx context.Context, d *schema.ResourceData, meta interface{}) error {
+		DeleteContext: rw.diagnosticsWrapper("Delete", func(ctx context.Context, d *schema.ResourceData, meta interface{}) error {
 			metaData := runArgs(d, meta, rw.logger)
 			return rw.resource.Delete().Func(ctx, metaData)
 		}),
@@ -116,7 +131,7 @@ func (rw *ResourceWrapper) Resource() (*schema.Resource, error) {
 	// Not all resources support update - so this is an separate interface
 	// implementations can opt to interface
 	if v, ok := rw.resource.(ResourceWithUpdate); ok {
-		resource.UpdateContext = rw.diagnosticsWrapper(func(ctx context.Context, d *schema.ResourceData, meta interface{}) error {
+		resource.UpdateContext = rw.diagnosticsWrapper("Update", func(ctx context.Context, d *schema.ResourceData, meta interface{}) error {
 			metaData := runArgs(d, meta, rw.logger)
 
 			err := v.Update().Func(ctx, metaData)
@@ -180,21 +195,52 @@ and we recommend using the %[2]q resource instead.
 	}
 	// TODO: State Migrations
 
+	if typedSchema != nil && typedSchema.Timeouts != nil {
+		if _, ok := rw.resource.(ResourceWithUpdate); ok && typedSchema.Timeouts.Update == nil {
+			return nil, fmt.Errorf("Resource %q must define an Update timeout within the Typed Schema since it implements ResourceWithUpdate", rw.resource.ResourceType())
+		}
+
+		resource.Timeouts = typedSchema.PluginSdkTimeouts()
+	}
+
 	return &resource, nil
 }
 
-func (rw *ResourceWrapper) diagnosticsWrapper(in func(ctx context.Context, d *schema.ResourceData, meta interface{}) error) func(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	return diagnosticsWrapper(in, rw.logger)
+func (rw *ResourceWrapper) diagnosticsWrapper(operation string, in func(ctx context.Context, d *schema.ResourceData, meta interface{}) error) func(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	return diagnosticsWrapper(rw.resource.ResourceType(), operation, in, rw.logger)
 }
 
-func diagnosticsWrapper(in func(ctx context.Context, d *schema.ResourceData, meta interface{}) error, logger Logger) func(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+// diagnosticsWrapper converts the error returned from the function into Diagnostics, recording the function within
+// a Span named `{resourceType} {operation}` when tracing is enabled. The requests made by the function are sent with
+// a Correlation Request ID unique to this operation, which is included in the error Diagnostic.
+func diagnosticsWrapper(resourceType, operation string, in func(ctx context.Context, d *schema.ResourceData, meta interface{}) error, logger Logger) func(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	return func(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+		ctx = common.WithOperationCorrelationRequestID(ctx)
+		ctx, span := tracing.Start(ctx, fmt.Sprintf("%s %s", resourceType, operation), tracing.SpanKindInternal,
+			tracing.String("azurerm.resource_type", resourceType),
+			tracing.String("azurerm.operation", operation),
+		)
+		defer span.End()
+
 		out := make([]diag.Diagnostic, 0)
-		if err := in(ctx, d, meta); err != nil {
+		err := in(ctx, d, meta)
+		if id := d.Id(); id != "" {
+			span.SetAttributes(tracing.String("azurerm.resource_id", id))
+		}
+		correlationRequestId := common.OperationCorrelationRequestID(ctx)
+		if correlationRequestId != "" {
+			span.SetAttributes(tracing.String("azurerm.correlation_request_id", correlationRequestId))
+		}
+		span.RecordError(err)
+		if err != nil {
+			detail := err.Error()
+			if correlationRequestId != "" {
+				detail = fmt.Sprintf("%s\n\nCorrelation Request ID: %s (this can be provided to Azure Support to identify the requests made during this %s)", detail, correlationRequestId, operation)
+			}
 			out = append(out, diag.Diagnostic{
 				Severity:      diag.Error,
 				Summary:       err.Error(),
-				Detail:        err.Error(),
+				Detail:        detail,
 				AttributePath: nil,
 			})
 		}
@@ -0,0 +1,130 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package authorization
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/go-azure-sdk/sdk/environments"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/sdk"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/sdk/frameworkhelpers"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/validation"
+)
+
+var _ sdk.EphemeralResource = &AccessTokenEphemeralResource{}
+
+func NewAccessTokenEphemeralResource() ephemeral.EphemeralResource {
+	return &AccessTokenEphemeralResource{}
+}
+
+type AccessTokenEphemeralResource struct {
+	sdk.EphemeralResourceMetadata
+}
+
+type AccessTokenEphemeralResourceModel struct {
+	Scope     types.String `tfsdk:"scope"`
+	Resource  types.String `tfsdk:"resource"`
+	Token     types.String `tfsdk:"token"`
+	ExpiresOn types.String `tfsdk:"expires_on"`
+	TenantID  types.String `tfsdk:"tenant_id"`
+	ClientID  types.String `tfsdk:"client_id"`
+}
+
+func (e *AccessTokenEphemeralResource) Metadata(_ context.Context, _ ephemeral.MetadataRequest, resp *ephemeral.MetadataResponse) {
+	resp.TypeName = "azurerm_access_token"
+}
+
+func (e *AccessTokenEphemeralResource) Configure(_ context.Context, req ephemeral.ConfigureRequest, resp *ephemeral.ConfigureResponse) {
+	e.Defaults(req, resp)
+}
+
+func (e *AccessTokenEphemeralResource) Schema(_ context.Context, _ ephemeral.SchemaRequest, resp *ephemeral.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Attributes: map[string]schema.Attribute{
+			"scope": schema.StringAttribute{
+				Optional: true,
+				Computed: true,
+				Validators: []validator.String{
+					frameworkhelpers.WrappedStringValidator{
+						Func: validation.StringIsNotEmpty,
+					},
+					stringvalidator.ConflictsWith(path.MatchRoot("resource")),
+				},
+			},
+
+			"resource": schema.StringAttribute{
+				Optional: true,
+				Validators: []validator.String{
+					frameworkhelpers.WrappedStringValidator{
+						Func: validation.IsURLWithScheme([]string{"https", "api"}),
+					},
+				},
+			},
+
+			"token": schema.StringAttribute{
+				Computed:  true,
+				Sensitive: true,
+			},
+
+			"expires_on": schema.StringAttribute{
+				Computed: true,
+			},
+
+			"tenant_id": schema.StringAttribute{
+				Computed: true,
+			},
+
+			"client_id": schema.StringAttribute{
+				Computed: true,
+			},
+		},
+	}
+}
+
+func (e *AccessTokenEphemeralResource) Open(ctx context.Context, req ephemeral.OpenRequest, resp *ephemeral.OpenResponse) {
+	ctx, cancel := context.WithTimeout(ctx, time.Minute*5)
+	defer cancel()
+
+	var data AccessTokenEphemeralResourceModel
+
+	if ok := e.DecodeOpen(ctx, req, resp, &data); !ok {
+		return
+	}
+
+	scope := data.Scope.ValueString()
+	if resource := data.Resource.ValueString(); resource != "" {
+		scope = fmt.Sprintf("%s/.default", strings.TrimSuffix(resource, "/"))
+	}
+	if scope == "" {
+		defaultScope, err := environments.Scope(e.Client.Account.Environment.ResourceManager)
+		if err != nil {
+			sdk.SetResponseErrorDiagnostic(resp, "determining the default scope", err)
+			return
+		}
+		scope = *defaultScope
+	}
+
+	token, err := e.Client.AccessToken(ctx, scope)
+	if err != nil {
+		sdk.SetResponseErrorDiagnostic(resp, fmt.Sprintf("acquiring an access token for %q", scope), err)
+		return
+	}
+
+	data.Scope = types.StringValue(scope)
+	data.Token = types.StringValue(token.AccessToken)
+	data.ExpiresOn = types.StringValue(token.Expiry.UTC().Format(time.RFC3339))
+	data.TenantID = types.StringValue(e.Client.Account.TenantId)
+	data.ClientID = types.StringValue(e.Client.Account.ClientId)
+
+	resp.Diagnostics.Append(resp.Result.Set(ctx, &data)...)
+}
@@ -0,0 +1,99 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package authorization_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/go-version"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
+	"github.com/hashicorp/terraform-plugin-testing/statecheck"
+	"github.com/hashicorp/terraform-plugin-testing/tfjsonpath"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/acceptance"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/provider/framework"
+)
+
+type AccessTokenEphemeral struct{}
+
+func TestAccEphemeralAccessToken_basic(t *testing.T) {
+	data := acceptance.BuildTestData(t, "ephemeral.azurerm_access_token", "test")
+	r := AccessTokenEphemeral{}
+
+	resource.Test(t, resource.TestCase{
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(version.Must(version.NewVersion("1.10.0-rc1"))),
+		},
+		ProtoV5ProviderFactories: framework.ProtoV5ProviderFactoriesInit(context.Background(), "azurerm"),
+		ProtoV6ProviderFactories: framework.ProtoV6ProviderFactoriesInit(context.Background(), "azurerm", "echo"),
+		Steps: []resource.TestStep{
+			{
+				Config: r.basic(data),
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue("echo.test", tfjsonpath.New("data").AtMapKey("token"), knownvalue.NotNull()),
+					statecheck.ExpectKnownValue("echo.test", tfjsonpath.New("data").AtMapKey("expires_on"), knownvalue.NotNull()),
+					statecheck.ExpectKnownValue("echo.test", tfjsonpath.New("data").AtMapKey("scope"), knownvalue.NotNull()),
+				},
+			},
+		},
+	})
+}
+
+func TestAccEphemeralAccessToken_resource(t *testing.T) {
+	data := acceptance.BuildTestData(t, "ephemeral.azurerm_access_token", "test")
+	r := AccessTokenEphemeral{}
+
+	resource.Test(t, resource.TestCase{
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(version.Must(version.NewVersion("1.10.0-rc1"))),
+		},
+		ProtoV5ProviderFactories: framework.ProtoV5ProviderFactoriesInit(context.Background(), "azurerm"),
+		ProtoV6ProviderFactories: framework.ProtoV6ProviderFactoriesInit(context.Background(), "azurerm", "echo"),
+		Steps: []resource.TestStep{
+			{
+				Config: r.resource(data),
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue("echo.test", tfjsonpath.New("data").AtMapKey("token"), knownvalue.NotNull()),
+					statecheck.ExpectKnownValue("echo.test", tfjsonpath.New("data").AtMapKey("scope"), knownvalue.StringExact("https://vault.azure.net/.default")),
+				},
+			},
+		},
+	})
+}
+
+func (AccessTokenEphemeral) basic(_ acceptance.TestData) string {
+	return `
+provider "azurerm" {
+  features {}
+}
+
+ephemeral "azurerm_access_token" "test" {}
+
+provider "echo" {
+  data = ephemeral.azurerm_access_token.test
+}
+
+resource "echo" "test" {}
+`
+}
+
+func (AccessTokenEphemeral) resource(_ acceptance.TestData) string {
+	return `
+provider "azurerm" {
+  features {}
+}
+
+ephemeral "azurerm_access_token" "test" {
+  resource = "https://vault.azure.net/"
+}
+
+provider "echo" {
+  data = ephemeral.azurerm_access_token.test
+}
+
+resource "echo" "test" {}
+`
+}
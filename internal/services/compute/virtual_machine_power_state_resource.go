@@ -0,0 +1,368 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package compute
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/go-azure-helpers/lang/pointer"
+	"github.com/hashicorp/go-azure-helpers/lang/response"
+	"github.com/hashicorp/go-azure-helpers/resourcemanager/resourceids"
+	"github.com/hashicorp/go-azure-sdk/resource-manager/compute/2024-03-01/virtualmachines"
+	"github.com/hashicorp/go-azure-sdk/resource-manager/compute/2024-03-01/virtualmachinescalesetvms"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/locks"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/sdk"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/validation"
+)
+
+const (
+	virtualMachinePowerStateRunning     = "running"
+	virtualMachinePowerStateStopped     = "stopped"
+	virtualMachinePowerStateDeallocated = "deallocated"
+)
+
+var _ sdk.ResourceWithUpdate = VirtualMachinePowerStateResource{}
+
+type VirtualMachinePowerStateResource struct{}
+
+type VirtualMachinePowerStateResourceModel struct {
+	VirtualMachineId                 string `tfschema:"virtual_machine_id"`
+	VirtualMachineScaleSetInstanceId string `tfschema:"virtual_machine_scale_set_instance_id"`
+	PowerState                       string `tfschema:"power_state"`
+	GracefulShutdownEnabled          bool   `tfschema:"graceful_shutdown_enabled"`
+}
+
+func (r VirtualMachinePowerStateResource) Arguments() map[string]*pluginsdk.Schema {
+	return map[string]*pluginsdk.Schema{
+		"virtual_machine_id": {
+			Type:         pluginsdk.TypeString,
+			Optional:     true,
+			ForceNew:     true,
+			ValidateFunc: virtualmachines.ValidateVirtualMachineID,
+			ExactlyOneOf: []string{"virtual_machine_id", "virtual_machine_scale_set_instance_id"},
+		},
+
+		"virtual_machine_scale_set_instance_id": {
+			Type:         pluginsdk.TypeString,
+			Optional:     true,
+			ForceNew:     true,
+			ValidateFunc: virtualmachinescalesetvms.ValidateVirtualMachineScaleSetVirtualMachineID,
+			ExactlyOneOf: []string{"virtual_machine_id", "virtual_machine_scale_set_instance_id"},
+		},
+
+		"power_state": {
+			Type:     pluginsdk.TypeString,
+			Required: true,
+			ValidateFunc: validation.StringInSlice([]string{
+				virtualMachinePowerStateRunning,
+				virtualMachinePowerStateStopped,
+				virtualMachinePowerStateDeallocated,
+			}, false),
+		},
+
+		"graceful_shutdown_enabled": {
+			Type:     pluginsdk.TypeBool,
+			Optional: true,
+			Default:  true,
+		},
+	}
+}
+
+func (r VirtualMachinePowerStateResource) Attributes() map[string]*pluginsdk.Schema {
+	return map[string]*pluginsdk.Schema{}
+}
+
+func (r VirtualMachinePowerStateResource) ResourceType() string {
+	return "azurerm_virtual_machine_power_state"
+}
+
+func (r VirtualMachinePowerStateResource) ModelObject() interface{} {
+	return &VirtualMachinePowerStateResourceModel{}
+}
+
+func (r VirtualMachinePowerStateResource) IDValidationFunc() pluginsdk.SchemaValidateFunc {
+	return validateVirtualMachinePowerStateID
+}
+
+func (r VirtualMachinePowerStateResource) Create() sdk.ResourceFunc {
+	return sdk.ResourceFunc{
+		Timeout: 30 * time.Minute,
+		Func: func(ctx context.Context, metadata sdk.ResourceMetaData) error {
+			var config VirtualMachinePowerStateResourceModel
+			if err := metadata.Decode(&config); err != nil {
+				return fmt.Errorf("decoding: %+v", err)
+			}
+
+			var id resourceids.ResourceId
+			if config.VirtualMachineId != "" {
+				vmId, err := virtualmachines.ParseVirtualMachineID(config.VirtualMachineId)
+				if err != nil {
+					return err
+				}
+				id = vmId
+			} else {
+				instanceId, err := virtualmachinescalesetvms.ParseVirtualMachineScaleSetVirtualMachineID(config.VirtualMachineScaleSetInstanceId)
+				if err != nil {
+					return err
+				}
+				id = instanceId
+			}
+
+			locks.ByID(id.ID())
+			defer locks.UnlockByID(id.ID())
+
+			if err := setVirtualMachinePowerState(ctx, metadata, id, config.PowerState, config.GracefulShutdownEnabled); err != nil {
+				return err
+			}
+
+			metadata.SetID(id)
+			return nil
+		},
+	}
+}
+
+func (r VirtualMachinePowerStateResource) Read() sdk.ResourceFunc {
+	return sdk.ResourceFunc{
+		Timeout: 5 * time.Minute,
+		Func: func(ctx context.Context, metadata sdk.ResourceMetaData) error {
+			id, err := parseVirtualMachinePowerStateID(metadata.ResourceData.Id())
+			if err != nil {
+				return err
+			}
+
+			powerState, found, err := retrieveVirtualMachinePowerState(ctx, metadata, id)
+			if err != nil {
+				return err
+			}
+			if !found {
+				return metadata.MarkAsGone(id)
+			}
+
+			state := VirtualMachinePowerStateResourceModel{
+				PowerState:              powerState,
+				GracefulShutdownEnabled: true,
+			}
+			// this isn't returned from the API, so is retained from the state (defaulting to `true` when importing)
+			if v, ok := metadata.ResourceData.GetOkExists("graceful_shutdown_enabled"); ok {
+				state.GracefulShutdownEnabled = v.(bool)
+			}
+
+			switch v := id.(type) {
+			case *virtualmachines.VirtualMachineId:
+				state.VirtualMachineId = v.ID()
+			case *virtualmachinescalesetvms.VirtualMachineScaleSetVirtualMachineId:
+				state.VirtualMachineScaleSetInstanceId = v.ID()
+			}
+
+			return metadata.Encode(&state)
+		},
+	}
+}
+
+func (r VirtualMachinePowerStateResource) Update() sdk.ResourceFunc {
+	return sdk.ResourceFunc{
+		Timeout: 30 * time.Minute,
+		Func: func(ctx context.Context, metadata sdk.ResourceMetaData) error {
+			id, err := parseVirtualMachinePowerStateID(metadata.ResourceData.Id())
+			if err != nil {
+				return err
+			}
+
+			var config VirtualMachinePowerStateResourceModel
+			if err := metadata.Decode(&config); err != nil {
+				return fmt.Errorf("decoding: %+v", err)
+			}
+
+			if !metadata.ResourceData.HasChange("power_state") {
+				return nil
+			}
+
+			locks.ByID(id.ID())
+			defer locks.UnlockByID(id.ID())
+
+			return setVirtualMachinePowerState(ctx, metadata, id, config.PowerState, config.GracefulShutdownEnabled)
+		},
+	}
+}
+
+func (r VirtualMachinePowerStateResource) Delete() sdk.ResourceFunc {
+	return sdk.ResourceFunc{
+		Timeout: 5 * time.Minute,
+		Func: func(ctx context.Context, metadata sdk.ResourceMetaData) error {
+			id, err := parseVirtualMachinePowerStateID(metadata.ResourceData.Id())
+			if err != nil {
+				return err
+			}
+
+			// the power state isn't a resource in Azure, as such removing this resource leaves the Virtual Machine as-is
+			log.Printf("[DEBUG] Removing the Power State for %s from the state - the Virtual Machine is unchanged", id)
+			return nil
+		},
+	}
+}
+
+// setVirtualMachinePowerState starts, stops or deallocates the Virtual Machine (or Virtual Machine Scale Set instance)
+// so that it's in the desired power state, doing nothing when it's already in the desired power state
+func setVirtualMachinePowerState(ctx context.Context, metadata sdk.ResourceMetaData, id resourceids.ResourceId, desired string, gracefulShutdown bool) error {
+	current, found, err := retrieveVirtualMachinePowerState(ctx, metadata, id)
+	if err != nil {
+		return err
+	}
+	if !found {
+		return fmt.Errorf("%s was not found", id)
+	}
+
+	if strings.EqualFold(current, desired) {
+		log.Printf("[DEBUG] %s is already %q", id, desired)
+		return nil
+	}
+
+	vmClient := metadata.Client.Compute.VirtualMachinesClient
+	instanceClient := metadata.Client.Compute.VirtualMachineScaleSetVMsClient
+
+	start := func() error {
+		log.Printf("[DEBUG] Starting %s", id)
+		switch v := id.(type) {
+		case *virtualmachines.VirtualMachineId:
+			return vmClient.StartThenPoll(ctx, *v)
+		case *virtualmachinescalesetvms.VirtualMachineScaleSetVirtualMachineId:
+			return instanceClient.StartThenPoll(ctx, *v)
+		}
+		return fmt.Errorf("unsupported ID type %T", id)
+	}
+
+	switch desired {
+	case virtualMachinePowerStateRunning:
+		if err := start(); err != nil {
+			return fmt.Errorf("starting %s: %+v", id, err)
+		}
+
+	case virtualMachinePowerStateStopped:
+		// a deallocated Virtual Machine can't be powered off, so it needs to be started first
+		if strings.EqualFold(current, virtualMachinePowerStateDeallocated) {
+			if err := start(); err != nil {
+				return fmt.Errorf("starting %s prior to powering it off: %+v", id, err)
+			}
+		}
+
+		log.Printf("[DEBUG] Powering Off %s", id)
+		switch v := id.(type) {
+		case *virtualmachines.VirtualMachineId:
+			err = vmClient.PowerOffThenPoll(ctx, *v, virtualmachines.PowerOffOperationOptions{
+				SkipShutdown: pointer.To(!gracefulShutdown),
+			})
+		case *virtualmachinescalesetvms.VirtualMachineScaleSetVirtualMachineId:
+			err = instanceClient.PowerOffThenPoll(ctx, *v, virtualmachinescalesetvms.PowerOffOperationOptions{
+				SkipShutdown: pointer.To(!gracefulShutdown),
+			})
+		}
+		if err != nil {
+			return fmt.Errorf("powering off %s: %+v", id, err)
+		}
+
+	case virtualMachinePowerStateDeallocated:
+		log.Printf("[DEBUG] Deallocating %s", id)
+		switch v := id.(type) {
+		case *virtualmachines.VirtualMachineId:
+			err = vmClient.DeallocateThenPoll(ctx, *v, virtualmachines.DefaultDeallocateOperationOptions())
+		case *virtualmachinescalesetvms.VirtualMachineScaleSetVirtualMachineId:
+			err = instanceClient.DeallocateThenPoll(ctx, *v)
+		}
+		if err != nil {
+			return fmt.Errorf("deallocating %s: %+v", id, err)
+		}
+
+	default:
+		return fmt.Errorf("unsupported power state %q", desired)
+	}
+
+	return nil
+}
+
+// retrieveVirtualMachinePowerState returns the current power state (e.g. `running` or `deallocating`) of the Virtual
+// Machine (or Virtual Machine Scale Set instance) from its Instance View, and whether it exists
+func retrieveVirtualMachinePowerState(ctx context.Context, metadata sdk.ResourceMetaData, id resourceids.ResourceId) (string, bool, error) {
+	codes := make([]string, 0)
+
+	switch v := id.(type) {
+	case *virtualmachines.VirtualMachineId:
+		resp, err := metadata.Client.Compute.VirtualMachinesClient.InstanceView(ctx, *v)
+		if err != nil {
+			if response.WasNotFound(resp.HttpResponse) {
+				return "", false, nil
+			}
+			return "", false, fmt.Errorf("retrieving the Instance View for %s: %+v", id, err)
+		}
+		if model := resp.Model; model != nil && model.Statuses != nil {
+			for _, status := range *model.Statuses {
+				codes = append(codes, pointer.From(status.Code))
+			}
+		}
+
+	case *virtualmachinescalesetvms.VirtualMachineScaleSetVirtualMachineId:
+		resp, err := metadata.Client.Compute.VirtualMachineScaleSetVMsClient.GetInstanceView(ctx, *v)
+		if err != nil {
+			if response.WasNotFound(resp.HttpResponse) {
+				return "", false, nil
+			}
+			return "", false, fmt.Errorf("retrieving the Instance View for %s: %+v", id, err)
+		}
+		if model := resp.Model; model != nil && model.Statuses != nil {
+			for _, status := range *model.Statuses {
+				codes = append(codes, pointer.From(status.Code))
+			}
+		}
+
+	default:
+		return "", false, fmt.Errorf("unsupported ID type %T", id)
+	}
+
+	return powerStateFromStatusCodes(codes), true, nil
+}
+
+// powerStateFromStatusCodes returns the power state from the Instance View status codes (e.g. `PowerState/running`),
+// which also include the provisioning state that we're not bothered with here
+func powerStateFromStatusCodes(codes []string) string {
+	for _, code := range codes {
+		state := strings.ToLower(code)
+		if strings.HasPrefix(state, "powerstate/") {
+			return strings.TrimPrefix(state, "powerstate/")
+		}
+	}
+
+	return ""
+}
+
+// parseVirtualMachinePowerStateID parses the ID of the Power State resource, which is the ID of either the Virtual
+// Machine or the Virtual Machine Scale Set instance
+func parseVirtualMachinePowerStateID(input string) (resourceids.ResourceId, error) {
+	if instanceId, err := virtualmachinescalesetvms.ParseVirtualMachineScaleSetVirtualMachineID(input); err == nil {
+		return instanceId, nil
+	}
+
+	vmId, err := virtualmachines.ParseVirtualMachineID(input)
+	if err != nil {
+		return nil, fmt.Errorf("expected %q to be either a Virtual Machine ID or a Virtual Machine Scale Set Instance ID: %+v", input, err)
+	}
+	return vmId, nil
+}
+
+func validateVirtualMachinePowerStateID(input interface{}, key string) (warnings []string, errors []error) {
+	v, ok := input.(string)
+	if !ok {
+		errors = append(errors, fmt.Errorf("expected %q to be a string", key))
+		return
+	}
+
+	if _, err := parseVirtualMachinePowerStateID(v); err != nil {
+		errors = append(errors, err)
+	}
+
+	return
+}
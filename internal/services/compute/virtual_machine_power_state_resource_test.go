@@ -0,0 +1,259 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package compute_test
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/go-azure-helpers/lang/pointer"
+	"github.com/hashicorp/go-azure-helpers/lang/response"
+	"github.com/hashicorp/go-azure-sdk/resource-manager/compute/2024-03-01/virtualmachines"
+	"github.com/hashicorp/go-azure-sdk/resource-manager/compute/2024-03-01/virtualmachinescalesetvms"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/acceptance"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/acceptance/check"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/clients"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
+)
+
+type VirtualMachinePowerStateResource struct{}
+
+func TestAccVirtualMachinePowerState_virtualMachine(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azurerm_virtual_machine_power_state", "test")
+	r := VirtualMachinePowerStateResource{}
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.virtualMachine(data, "deallocated"),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("power_state").HasValue("deallocated"),
+			),
+		},
+		data.ImportStep(),
+		{
+			Config: r.virtualMachine(data, "running"),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("power_state").HasValue("running"),
+			),
+		},
+		data.ImportStep(),
+		{
+			Config: r.virtualMachine(data, "stopped"),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("power_state").HasValue("stopped"),
+			),
+		},
+		data.ImportStep(),
+	})
+}
+
+func TestAccVirtualMachinePowerState_virtualMachineDrift(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azurerm_virtual_machine_power_state", "test")
+	r := VirtualMachinePowerStateResource{}
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		data.DriftStep(acceptance.DriftStepData{
+			Config: func(data acceptance.TestData) string {
+				return r.virtualMachine(data, "deallocated")
+			},
+			Mutation:        r.startOutsideTerraform,
+			ExpectedChanges: []string{"power_state"},
+		}),
+	})
+}
+
+func TestAccVirtualMachinePowerState_virtualMachineScaleSetInstance(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azurerm_virtual_machine_power_state", "test")
+	r := VirtualMachinePowerStateResource{}
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.virtualMachineScaleSetInstance(data, "stopped"),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("power_state").HasValue("stopped"),
+			),
+		},
+		data.ImportStep(),
+		{
+			Config: r.virtualMachineScaleSetInstance(data, "running"),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("power_state").HasValue("running"),
+			),
+		},
+		data.ImportStep(),
+	})
+}
+
+func (r VirtualMachinePowerStateResource) Exists(ctx context.Context, client *clients.Client, state *pluginsdk.InstanceState) (*bool, error) {
+	if strings.Contains(strings.ToLower(state.ID), "/virtualmachinescalesets/") {
+		id, err := virtualmachinescalesetvms.ParseVirtualMachineScaleSetVirtualMachineID(state.ID)
+		if err != nil {
+			return nil, err
+		}
+
+		resp, err := client.Compute.VirtualMachineScaleSetVMsClient.GetInstanceView(ctx, *id)
+		if err != nil {
+			if response.WasNotFound(resp.HttpResponse) {
+				return pointer.To(false), nil
+			}
+			return nil, fmt.Errorf("retrieving the Instance View for %s: %+v", id, err)
+		}
+		return pointer.To(true), nil
+	}
+
+	id, err := virtualmachines.ParseVirtualMachineID(state.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := client.Compute.VirtualMachinesClient.InstanceView(ctx, *id)
+	if err != nil {
+		if response.WasNotFound(resp.HttpResponse) {
+			return pointer.To(false), nil
+		}
+		return nil, fmt.Errorf("retrieving the Instance View for %s: %+v", id, err)
+	}
+	return pointer.To(true), nil
+}
+
+func (r VirtualMachinePowerStateResource) startOutsideTerraform(ctx context.Context, client *clients.Client, state *pluginsdk.InstanceState) error {
+	id, err := virtualmachines.ParseVirtualMachineID(state.ID)
+	if err != nil {
+		return err
+	}
+
+	if err := client.Compute.VirtualMachinesClient.StartThenPoll(ctx, *id); err != nil {
+		return fmt.Errorf("starting %s: %+v", id, err)
+	}
+
+	return nil
+}
+
+func (r VirtualMachinePowerStateResource) virtualMachine(data acceptance.TestData, powerState string) string {
+	return fmt.Sprintf(`
+%s
+
+resource "azurerm_linux_virtual_machine" "test" {
+  name                            = "acctestVM-%d"
+  resource_group_name             = azurerm_resource_group.test.name
+  location                        = azurerm_resource_group.test.location
+  size                            = "Standard_F2"
+  admin_username                  = "adminuser"
+  admin_password                  = "P@$$w0rd1234!"
+  disable_password_authentication = false
+  network_interface_ids           = [azurerm_network_interface.test.id]
+
+  os_disk {
+    caching              = "ReadWrite"
+    storage_account_type = "Standard_LRS"
+  }
+
+  source_image_reference {
+    publisher = "Canonical"
+    offer     = "0001-com-ubuntu-server-jammy"
+    sku       = "22_04-lts"
+    version   = "latest"
+  }
+}
+
+resource "azurerm_virtual_machine_power_state" "test" {
+  virtual_machine_id = azurerm_linux_virtual_machine.test.id
+  power_state        = %q
+}
+`, r.template(data), data.RandomInteger, powerState)
+}
+
+func (r VirtualMachinePowerStateResource) virtualMachineScaleSetInstance(data acceptance.TestData, powerState string) string {
+	return fmt.Sprintf(`
+%s
+
+resource "azurerm_linux_virtual_machine_scale_set" "test" {
+  name                            = "acctestvmss-%d"
+  resource_group_name             = azurerm_resource_group.test.name
+  location                        = azurerm_resource_group.test.location
+  sku                             = "Standard_F2"
+  instances                       = 1
+  admin_username                  = "adminuser"
+  admin_password                  = "P@$$w0rd1234!"
+  disable_password_authentication = false
+
+  source_image_reference {
+    publisher = "Canonical"
+    offer     = "0001-com-ubuntu-server-jammy"
+    sku       = "22_04-lts"
+    version   = "latest"
+  }
+
+  os_disk {
+    storage_account_type = "Standard_LRS"
+    caching              = "ReadWrite"
+  }
+
+  network_interface {
+    name    = "example"
+    primary = true
+
+    ip_configuration {
+      name      = "internal"
+      primary   = true
+      subnet_id = azurerm_subnet.test.id
+    }
+  }
+}
+
+data "azurerm_virtual_machine_scale_set" "test" {
+  name                = azurerm_linux_virtual_machine_scale_set.test.name
+  resource_group_name = azurerm_linux_virtual_machine_scale_set.test.resource_group_name
+}
+
+resource "azurerm_virtual_machine_power_state" "test" {
+  virtual_machine_scale_set_instance_id = "${azurerm_linux_virtual_machine_scale_set.test.id}/virtualMachines/${data.azurerm_virtual_machine_scale_set.test.instances.0.instance_id}"
+  power_state                           = %q
+  graceful_shutdown_enabled             = false
+}
+`, r.template(data), data.RandomInteger, powerState)
+}
+
+func (VirtualMachinePowerStateResource) template(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+provider "azurerm" {
+  features {}
+}
+
+resource "azurerm_resource_group" "test" {
+  name     = "acctestRG-%[1]d"
+  location = "%[2]s"
+}
+
+resource "azurerm_virtual_network" "test" {
+  name                = "acctestnw-%[1]d"
+  address_space       = ["10.0.0.0/16"]
+  location            = azurerm_resource_group.test.location
+  resource_group_name = azurerm_resource_group.test.name
+}
+
+resource "azurerm_subnet" "test" {
+  name                 = "internal"
+  resource_group_name  = azurerm_resource_group.test.name
+  virtual_network_name = azurerm_virtual_network.test.name
+  address_prefixes     = ["10.0.2.0/24"]
+}
+
+resource "azurerm_network_interface" "test" {
+  name                = "acctestnic-%[1]d"
+  location            = azurerm_resource_group.test.location
+  resource_group_name = azurerm_resource_group.test.name
+
+  ip_configuration {
+    name                          = "internal"
+    subnet_id                     = azurerm_subnet.test.id
+    private_ip_address_allocation = "Dynamic"
+  }
+}
+`, data.RandomInteger, data.Locations.Primary)
+}
@@ -0,0 +1,288 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package containers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/hashicorp/go-azure-sdk/sdk/environments"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/clients"
+)
+
+// containerRegistryAccessTokenUsername is the username used when authenticating to a Container Registry using
+// a refresh token obtained from exchanging an Azure Active Directory access token
+const containerRegistryAccessTokenUsername = "00000000-0000-0000-0000-000000000000"
+
+// containerRegistryManifestMediaTypes are the media types accepted when retrieving a manifest, which ensures the digest
+// of a multi-architecture image is that of the image index/manifest list, rather than a single platform
+var containerRegistryManifestMediaTypes = []string{
+	"application/vnd.oci.image.index.v1+json",
+	"application/vnd.docker.distribution.manifest.list.v2+json",
+	"application/vnd.oci.image.manifest.v1+json",
+	"application/vnd.docker.distribution.manifest.v2+json",
+}
+
+// containerRegistryDataPlaneClient is a minimal client for the Docker Registry HTTP API V2, which is used to look up
+// the digest of an image and to remove tags - authenticating using the Bearer token challenge issued by the registry
+type containerRegistryDataPlaneClient struct {
+	httpClient *http.Client
+	host       string
+	username   string
+	password   string
+}
+
+func newContainerRegistryDataPlaneClient(client *clients.Client, host, username, password string) *containerRegistryDataPlaneClient {
+	return &containerRegistryDataPlaneClient{
+		httpClient: client.HttpClient(),
+		host:       containerRegistryDataPlaneHost(host),
+		username:   username,
+		password:   password,
+	}
+}
+
+// newAzureContainerRegistryDataPlaneClient returns a client for the Azure Container Registry with the specified login
+// server, exchanging an access token for the credentials the Provider has been configured with for a refresh token
+func newAzureContainerRegistryDataPlaneClient(ctx context.Context, client *clients.Client, loginServer string) (*containerRegistryDataPlaneClient, error) {
+	scope, err := environments.Scope(client.Account.Environment.ResourceManager)
+	if err != nil {
+		return nil, fmt.Errorf("determining the Resource Manager scope: %+v", err)
+	}
+
+	token, err := client.AccessToken(ctx, *scope)
+	if err != nil {
+		return nil, err
+	}
+
+	form := url.Values{}
+	form.Set("grant_type", "access_token")
+	form.Set("service", loginServer)
+	form.Set("tenant", client.Account.TenantId)
+	form.Set("access_token", token.AccessToken)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, fmt.Sprintf("https://%s/oauth2/exchange", loginServer), strings.NewReader(form.Encode()))
+	if err != nil {
+		return nil, fmt.Errorf("building request: %+v", err)
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	httpClient := client.HttpClient()
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("exchanging the access token for a refresh token for %q: %+v", loginServer, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("exchanging the access token for a refresh token for %q: unexpected status %d", loginServer, resp.StatusCode)
+	}
+
+	var result struct {
+		RefreshToken string `json:"refresh_token"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil, fmt.Errorf("decoding the refresh token for %q: %+v", loginServer, err)
+	}
+
+	return &containerRegistryDataPlaneClient{
+		httpClient: httpClient,
+		host:       loginServer,
+		username:   containerRegistryAccessTokenUsername,
+		password:   result.RefreshToken,
+	}, nil
+}
+
+// ManifestDigest returns the digest of the manifest for the specified repository and reference (a tag or digest),
+// or nil if the manifest doesn't exist
+func (c *containerRegistryDataPlaneClient) ManifestDigest(ctx context.Context, repository, reference string) (*string, error) {
+	resp, err := c.do(ctx, http.MethodHead, fmt.Sprintf("/v2/%s/manifests/%s", repository, reference), func(req *http.Request) {
+		req.Header.Set("Accept", strings.Join(containerRegistryManifestMediaTypes, ", "))
+	})
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, nil
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("retrieving the manifest for %s:%s from %q: unexpected status %d", repository, reference, c.host, resp.StatusCode)
+	}
+
+	digest := resp.Header.Get("Docker-Content-Digest")
+	if digest == "" {
+		return nil, fmt.Errorf("retrieving the manifest for %s:%s from %q: `Docker-Content-Digest` was empty", repository, reference, c.host)
+	}
+
+	return &digest, nil
+}
+
+// DeleteTag removes the specified tag from a repository within an Azure Container Registry, the manifest it references is retained
+func (c *containerRegistryDataPlaneClient) DeleteTag(ctx context.Context, repository, tag string) error {
+	resp, err := c.do(ctx, http.MethodDelete, fmt.Sprintf("/acr/v1/%s/_tags/%s", repository, tag), nil)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusAccepted && resp.StatusCode != http.StatusNotFound {
+		return fmt.Errorf("deleting the tag %s:%s from %q: unexpected status %d", repository, tag, c.host, resp.StatusCode)
+	}
+
+	return nil
+}
+
+// do performs the request, responding to an authentication challenge from the registry if one is issued
+func (c *containerRegistryDataPlaneClient) do(ctx context.Context, method, path string, decorate func(req *http.Request)) (*http.Response, error) {
+	newRequest := func() (*http.Request, error) {
+		req, err := http.NewRequestWithContext(ctx, method, fmt.Sprintf("https://%s%s", c.host, path), nil)
+		if err != nil {
+			return nil, fmt.Errorf("building request: %+v", err)
+		}
+		if decorate != nil {
+			decorate(req)
+		}
+		return req, nil
+	}
+
+	req, err := newRequest()
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("sending request to %q: %+v", c.host, err)
+	}
+	if resp.StatusCode != http.StatusUnauthorized {
+		return resp, nil
+	}
+	challenge := resp.Header.Get("WWW-Authenticate")
+	resp.Body.Close()
+
+	req, err = newRequest()
+	if err != nil {
+		return nil, err
+	}
+
+	scheme, params := parseContainerRegistryAuthenticationChallenge(challenge)
+	switch strings.ToLower(scheme) {
+	case "basic":
+		req.SetBasicAuth(c.username, c.password)
+	case "bearer":
+		token, err := c.bearerToken(ctx, params)
+		if err != nil {
+			return nil, err
+		}
+		req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", token))
+	default:
+		return nil, fmt.Errorf("unsupported authentication challenge %q from %q", challenge, c.host)
+	}
+
+	resp, err = c.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("sending request to %q: %+v", c.host, err)
+	}
+
+	return resp, nil
+}
+
+func (c *containerRegistryDataPlaneClient) bearerToken(ctx context.Context, params map[string]string) (string, error) {
+	realm := params["realm"]
+	if realm == "" {
+		return "", fmt.Errorf("the authentication challenge from %q didn't specify a realm", c.host)
+	}
+
+	query := url.Values{}
+	if v := params["service"]; v != "" {
+		query.Set("service", v)
+	}
+	if v := params["scope"]; v != "" {
+		query.Set("scope", v)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, fmt.Sprintf("%s?%s", realm, query.Encode()), nil)
+	if err != nil {
+		return "", fmt.Errorf("building token request: %+v", err)
+	}
+	if c.password != "" {
+		req.SetBasicAuth(c.username, c.password)
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("retrieving a token from %q: %+v", realm, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return "", fmt.Errorf("retrieving a token from %q: unexpected status %d: %s", realm, resp.StatusCode, string(body))
+	}
+
+	var result struct {
+		Token       string `json:"token"`
+		AccessToken string `json:"access_token"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return "", fmt.Errorf("decoding the token from %q: %+v", realm, err)
+	}
+
+	if result.Token != "" {
+		return result.Token, nil
+	}
+	return result.AccessToken, nil
+}
+
+// parseContainerRegistryAuthenticationChallenge parses a `WWW-Authenticate` header value, such as
+// `Bearer realm="https://auth.docker.io/token",service="registry.docker.io",scope="repository:library/nginx:pull"`
+func parseContainerRegistryAuthenticationChallenge(input string) (string, map[string]string) {
+	params := make(map[string]string)
+
+	scheme, rest, _ := strings.Cut(strings.TrimSpace(input), " ")
+	for rest != "" {
+		var key, value string
+		key, rest, _ = strings.Cut(strings.TrimLeft(rest, " ,"), "=")
+		if strings.HasPrefix(rest, `"`) {
+			value, rest, _ = strings.Cut(rest[1:], `"`)
+		} else {
+			value, rest, _ = strings.Cut(rest, ",")
+		}
+		if key = strings.TrimSpace(key); key != "" {
+			params[strings.ToLower(key)] = value
+		}
+	}
+
+	return scheme, params
+}
+
+// containerRegistryDataPlaneHost returns the host serving the registry API for the specified registry, which differs for Docker Hub
+func containerRegistryDataPlaneHost(registry string) string {
+	registry = strings.TrimSuffix(strings.TrimPrefix(strings.TrimPrefix(registry, "https://"), "http://"), "/")
+	if strings.EqualFold(registry, "docker.io") || strings.EqualFold(registry, "index.docker.io") {
+		return "registry-1.docker.io"
+	}
+	return registry
+}
+
+// containerRegistryImageReference splits an image (e.g. `library/nginx:latest` or `library/nginx@sha256:...`) into the
+// repository and the reference - defaulting the reference to `latest` when neither a tag nor a digest is specified
+func containerRegistryImageReference(image string) (repository string, reference string) {
+	if repository, digest, ok := strings.Cut(image, "@"); ok {
+		return repository, digest
+	}
+
+	// a `:` before the last `/` is the port of the registry, rather than the tag
+	if i := strings.LastIndex(image, ":"); i > strings.LastIndex(image, "/") {
+		return image[:i], image[i+1:]
+	}
+
+	return image, "latest"
+}
@@ -0,0 +1,621 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package containers
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/go-azure-helpers/lang/pointer"
+	"github.com/hashicorp/go-azure-helpers/lang/response"
+	"github.com/hashicorp/go-azure-helpers/resourcemanager/resourceids"
+	"github.com/hashicorp/go-azure-sdk/resource-manager/containerregistry/2023-11-01-preview/registries"
+	"github.com/hashicorp/terraform-provider-azurerm/helpers/tf"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/sdk"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/validation"
+)
+
+var (
+	_ sdk.ResourceWithUpdate        = ContainerRegistryImageImportResource{}
+	_ sdk.ResourceWithCustomizeDiff = ContainerRegistryImageImportResource{}
+)
+
+type ContainerRegistryImageImportResource struct{}
+
+type ContainerRegistryImageImportModel struct {
+	ContainerRegistryId        string                                   `tfschema:"container_registry_id"`
+	SourceImage                string                                   `tfschema:"source_image"`
+	SourceRegistryUri          string                                   `tfschema:"source_registry_uri"`
+	SourceContainerRegistryId  string                                   `tfschema:"source_container_registry_id"`
+	SourceCredentials          []ContainerRegistryImageImportCredential `tfschema:"source_credentials"`
+	TargetTags                 []string                                 `tfschema:"target_tags"`
+	UntaggedTargetRepositories []string                                 `tfschema:"untagged_target_repositories"`
+	ForceEnabled               bool                                     `tfschema:"force_enabled"`
+	Digest                     string                                   `tfschema:"digest"`
+	SourceDigest               string                                   `tfschema:"source_digest"`
+}
+
+type ContainerRegistryImageImportCredential struct {
+	Username string `tfschema:"username"`
+	Password string `tfschema:"password"`
+}
+
+func (ContainerRegistryImageImportResource) Arguments() map[string]*pluginsdk.Schema {
+	return map[string]*pluginsdk.Schema{
+		"container_registry_id": {
+			Type:         pluginsdk.TypeString,
+			Required:     true,
+			ForceNew:     true,
+			ValidateFunc: registries.ValidateRegistryID,
+		},
+
+		"source_image": {
+			Type:         pluginsdk.TypeString,
+			Required:     true,
+			ForceNew:     true,
+			ValidateFunc: validation.StringIsNotEmpty,
+		},
+
+		"source_registry_uri": {
+			Type:         pluginsdk.TypeString,
+			Optional:     true,
+			ForceNew:     true,
+			ValidateFunc: validation.StringIsNotEmpty,
+			ExactlyOneOf: []string{"source_registry_uri", "source_container_registry_id"},
+		},
+
+		"source_container_registry_id": {
+			Type:         pluginsdk.TypeString,
+			Optional:     true,
+			ForceNew:     true,
+			ValidateFunc: registries.ValidateRegistryID,
+			ExactlyOneOf: []string{"source_registry_uri", "source_container_registry_id"},
+		},
+
+		"source_credentials": {
+			Type:          pluginsdk.TypeList,
+			Optional:      true,
+			MaxItems:      1,
+			ConflictsWith: []string{"source_container_registry_id"},
+			Elem: &pluginsdk.Resource{
+				Schema: map[string]*pluginsdk.Schema{
+					"username": {
+						Type:         pluginsdk.TypeString,
+						Optional:     true,
+						ValidateFunc: validation.StringIsNotEmpty,
+					},
+
+					"password": {
+						Type:         pluginsdk.TypeString,
+						Required:     true,
+						Sensitive:    true,
+						ValidateFunc: validation.StringIsNotEmpty,
+					},
+				},
+			},
+		},
+
+		"target_tags": {
+			Type:         pluginsdk.TypeList,
+			Optional:     true,
+			ForceNew:     true,
+			AtLeastOneOf: []string{"target_tags", "untagged_target_repositories"},
+			Elem: &pluginsdk.Schema{
+				Type:         pluginsdk.TypeString,
+				ValidateFunc: validateContainerRegistryImageImportTargetTag,
+			},
+		},
+
+		"untagged_target_repositories": {
+			Type:         pluginsdk.TypeList,
+			Optional:     true,
+			ForceNew:     true,
+			AtLeastOneOf: []string{"target_tags", "untagged_target_repositories"},
+			Elem: &pluginsdk.Schema{
+				Type:         pluginsdk.TypeString,
+				ValidateFunc: validation.StringIsNotEmpty,
+			},
+		},
+
+		"force_enabled": {
+			Type:     pluginsdk.TypeBool,
+			Optional: true,
+			Default:  false,
+		},
+	}
+}
+
+func (ContainerRegistryImageImportResource) Attributes() map[string]*pluginsdk.Schema {
+	return map[string]*pluginsdk.Schema{
+		"digest": {
+			Type:     pluginsdk.TypeString,
+			Computed: true,
+		},
+
+		"source_digest": {
+			Type:     pluginsdk.TypeString,
+			Computed: true,
+		},
+	}
+}
+
+func (ContainerRegistryImageImportResource) ModelObject() interface{} {
+	return &ContainerRegistryImageImportModel{}
+}
+
+func (ContainerRegistryImageImportResource) ResourceType() string {
+	return "azurerm_container_registry_image_import"
+}
+
+func (ContainerRegistryImageImportResource) IDValidationFunc() pluginsdk.SchemaValidateFunc {
+	return validateContainerRegistryImageImportID
+}
+
+func (r ContainerRegistryImageImportResource) Create() sdk.ResourceFunc {
+	return sdk.ResourceFunc{
+		Timeout: 30 * time.Minute,
+		Func: func(ctx context.Context, metadata sdk.ResourceMetaData) error {
+			client := metadata.Client.Containers.ContainerRegistryClient.Registries
+
+			var config ContainerRegistryImageImportModel
+			if err := metadata.Decode(&config); err != nil {
+				return fmt.Errorf("decoding: %+v", err)
+			}
+
+			registryId, err := registries.ParseRegistryID(config.ContainerRegistryId)
+			if err != nil {
+				return err
+			}
+
+			sourceRegistry := config.SourceRegistryUri
+			if config.SourceContainerRegistryId != "" {
+				sourceRegistry = config.SourceContainerRegistryId
+			}
+			targets := append(append([]string{}, config.TargetTags...), config.UntaggedTargetRepositories...)
+			id := NewContainerRegistryImageImportID(*registryId, targets, sourceRegistry, config.SourceImage)
+
+			registry, err := client.Get(ctx, *registryId)
+			if err != nil {
+				return fmt.Errorf("retrieving %s: %+v", registryId, err)
+			}
+			loginServer := ""
+			if model := registry.Model; model != nil && model.Properties != nil {
+				loginServer = pointer.From(model.Properties.LoginServer)
+			}
+			if loginServer == "" {
+				return fmt.Errorf("retrieving %s: `loginServer` was nil", registryId)
+			}
+
+			// unless the import should overwrite the existing tags, any existing tag needs to be imported into Terraform
+			if !config.ForceEnabled && len(config.TargetTags) > 0 {
+				dataPlaneClient, err := newAzureContainerRegistryDataPlaneClient(ctx, metadata.Client, loginServer)
+				if err != nil {
+					return fmt.Errorf("building data plane client for %s: %+v", registryId, err)
+				}
+
+				for _, tag := range config.TargetTags {
+					repository, reference := containerRegistryImageReference(tag)
+					existing, err := dataPlaneClient.ManifestDigest(ctx, repository, reference)
+					if err != nil {
+						return fmt.Errorf("checking for presence of existing tag %q in %s: %+v", tag, registryId, err)
+					}
+					if existing != nil {
+						return tf.ImportAsExistsError(r.ResourceType(), id.ID())
+					}
+				}
+			}
+
+			mode := registries.ImportModeNoForce
+			if config.ForceEnabled {
+				mode = registries.ImportModeForce
+			}
+
+			payload := registries.ImportImageParameters{
+				Mode: pointer.To(mode),
+				Source: registries.ImportSource{
+					SourceImage: config.SourceImage,
+				},
+			}
+			if config.SourceRegistryUri != "" {
+				payload.Source.RegistryUri = pointer.To(config.SourceRegistryUri)
+			}
+			if config.SourceContainerRegistryId != "" {
+				payload.Source.ResourceId = pointer.To(config.SourceContainerRegistryId)
+			}
+			if len(config.SourceCredentials) > 0 {
+				credentials := config.SourceCredentials[0]
+				payload.Source.Credentials = &registries.ImportSourceCredentials{
+					Password: credentials.Password,
+				}
+				if credentials.Username != "" {
+					payload.Source.Credentials.Username = pointer.To(credentials.Username)
+				}
+			}
+			if len(config.TargetTags) > 0 {
+				payload.TargetTags = pointer.To(config.TargetTags)
+			}
+			if len(config.UntaggedTargetRepositories) > 0 {
+				payload.UntaggedTargetRepositories = pointer.To(config.UntaggedTargetRepositories)
+			}
+
+			if err := client.ImportImageThenPoll(ctx, *registryId, payload); err != nil {
+				return fmt.Errorf("importing %q into %s: %+v", config.SourceImage, registryId, err)
+			}
+
+			metadata.SetID(id)
+
+			// the digest of an image imported into untagged repositories can't be determined from a tag, so is that of the
+			// source image - which Read then uses to check the manifest remains present in each of the repositories
+			if len(config.TargetTags) == 0 {
+				digest, err := r.retrieveSourceDigest(ctx, metadata, config)
+				if err != nil {
+					return fmt.Errorf("retrieving the digest of the source image %q for %s: %+v", config.SourceImage, id, err)
+				}
+				if digest == nil {
+					return fmt.Errorf("retrieving the digest of the source image %q for %s: the image was not found", config.SourceImage, id)
+				}
+
+				dataPlaneClient, err := newAzureContainerRegistryDataPlaneClient(ctx, metadata.Client, loginServer)
+				if err != nil {
+					return fmt.Errorf("building data plane client for %s: %+v", registryId, err)
+				}
+				for _, repository := range config.UntaggedTargetRepositories {
+					existing, err := dataPlaneClient.ManifestDigest(ctx, repository, *digest)
+					if err != nil {
+						return fmt.Errorf("retrieving the manifest %q in the repository %q for %s: %+v", *digest, repository, id, err)
+					}
+					if existing == nil {
+						return fmt.Errorf("the manifest %q was not imported into the repository %q for %s", *digest, repository, id)
+					}
+				}
+
+				if err := metadata.ResourceData.Set("digest", *digest); err != nil {
+					return fmt.Errorf("setting `digest`: %+v", err)
+				}
+			}
+
+			return nil
+		},
+	}
+}
+
+func (r ContainerRegistryImageImportResource) Read() sdk.ResourceFunc {
+	return sdk.ResourceFunc{
+		Timeout: 5 * time.Minute,
+		Func: func(ctx context.Context, metadata sdk.ResourceMetaData) error {
+			client := metadata.Client.Containers.ContainerRegistryClient.Registries
+
+			id, err := ParseContainerRegistryImageImportID(metadata.ResourceData.Id())
+			if err != nil {
+				return err
+			}
+
+			var state ContainerRegistryImageImportModel
+			if err := metadata.Decode(&state); err != nil {
+				return fmt.Errorf("decoding: %+v", err)
+			}
+
+			registry, err := client.Get(ctx, id.RegistryId)
+			if err != nil {
+				if response.WasNotFound(registry.HttpResponse) {
+					return metadata.MarkAsGone(id)
+				}
+				return fmt.Errorf("retrieving %s: %+v", id.RegistryId, err)
+			}
+			loginServer := ""
+			if model := registry.Model; model != nil && model.Properties != nil {
+				loginServer = pointer.From(model.Properties.LoginServer)
+			}
+			if loginServer == "" {
+				return fmt.Errorf("retrieving %s: `loginServer` was nil", id.RegistryId)
+			}
+
+			state.ContainerRegistryId = id.RegistryId.ID()
+			state.SourceImage = id.SourceImage
+			state.SourceRegistryUri = ""
+			state.SourceContainerRegistryId = ""
+			if _, err := registries.ParseRegistryID(id.SourceRegistry); err == nil {
+				state.SourceContainerRegistryId = id.SourceRegistry
+			} else {
+				state.SourceRegistryUri = id.SourceRegistry
+			}
+
+			state.TargetTags = make([]string, 0)
+			state.UntaggedTargetRepositories = make([]string, 0)
+			for _, target := range id.Targets {
+				if strings.Contains(target, ":") {
+					state.TargetTags = append(state.TargetTags, target)
+				} else {
+					state.UntaggedTargetRepositories = append(state.UntaggedTargetRepositories, target)
+				}
+			}
+
+			dataPlaneClient, err := newAzureContainerRegistryDataPlaneClient(ctx, metadata.Client, loginServer)
+			if err != nil {
+				return fmt.Errorf("building data plane client for %s: %+v", id.RegistryId, err)
+			}
+
+			if len(state.TargetTags) > 0 {
+				// the digest is that of the first tag, any other tag referencing a different image is drift from the import
+				digest := ""
+				for _, tag := range state.TargetTags {
+					repository, reference := containerRegistryImageReference(tag)
+					tagDigest, err := dataPlaneClient.ManifestDigest(ctx, repository, reference)
+					if err != nil {
+						return fmt.Errorf("retrieving the digest of the tag %q for %s: %+v", tag, id, err)
+					}
+					if tagDigest == nil {
+						return metadata.MarkAsGone(id)
+					}
+
+					if digest == "" {
+						digest = *tagDigest
+					} else if digest != *tagDigest {
+						log.Printf("[DEBUG] the tag %q for %s references %q rather than %q", tag, id, *tagDigest, digest)
+						digest = *tagDigest
+					}
+				}
+				state.Digest = digest
+			} else if state.Digest != "" {
+				for _, repository := range state.UntaggedTargetRepositories {
+					digest, err := dataPlaneClient.ManifestDigest(ctx, repository, state.Digest)
+					if err != nil {
+						return fmt.Errorf("retrieving the manifest %q in the repository %q for %s: %+v", state.Digest, repository, id, err)
+					}
+					if digest == nil {
+						return metadata.MarkAsGone(id)
+					}
+				}
+			}
+
+			sourceDigest, err := r.retrieveSourceDigest(ctx, metadata, state)
+			if err != nil {
+				// the source registry may be unavailable (or rate limited) - which shouldn't prevent the target from being managed
+				log.Printf("[WARN] unable to retrieve the digest of the source image %q for %s: %+v", state.SourceImage, id, err)
+			} else if sourceDigest != nil {
+				state.SourceDigest = *sourceDigest
+			}
+
+			return metadata.Encode(&state)
+		},
+	}
+}
+
+func (ContainerRegistryImageImportResource) Update() sdk.ResourceFunc {
+	return sdk.ResourceFunc{
+		Timeout: 30 * time.Minute,
+		Func: func(ctx context.Context, metadata sdk.ResourceMetaData) error {
+			// `source_credentials` and `force_enabled` are only used during the import, as such changing them only updates the state
+			return nil
+		},
+	}
+}
+
+func (ContainerRegistryImageImportResource) Delete() sdk.ResourceFunc {
+	return sdk.ResourceFunc{
+		Timeout: 30 * time.Minute,
+		Func: func(ctx context.Context, metadata sdk.ResourceMetaData) error {
+			client := metadata.Client.Containers.ContainerRegistryClient.Registries
+
+			id, err := ParseContainerRegistryImageImportID(metadata.ResourceData.Id())
+			if err != nil {
+				return err
+			}
+
+			var state ContainerRegistryImageImportModel
+			if err := metadata.Decode(&state); err != nil {
+				return fmt.Errorf("decoding: %+v", err)
+			}
+
+			// only the target tags are removed, the imported manifests are retained in the repositories
+			if len(state.TargetTags) == 0 {
+				return nil
+			}
+
+			registry, err := client.Get(ctx, id.RegistryId)
+			if err != nil {
+				if response.WasNotFound(registry.HttpResponse) {
+					return nil
+				}
+				return fmt.Errorf("retrieving %s: %+v", id.RegistryId, err)
+			}
+			loginServer := ""
+			if model := registry.Model; model != nil && model.Properties != nil {
+				loginServer = pointer.From(model.Properties.LoginServer)
+			}
+
+			dataPlaneClient, err := newAzureContainerRegistryDataPlaneClient(ctx, metadata.Client, loginServer)
+			if err != nil {
+				return fmt.Errorf("building data plane client for %s: %+v", id.RegistryId, err)
+			}
+
+			for _, tag := range state.TargetTags {
+				repository, reference := containerRegistryImageReference(tag)
+				if err := dataPlaneClient.DeleteTag(ctx, repository, reference); err != nil {
+					return fmt.Errorf("deleting %s: %+v", id, err)
+				}
+			}
+
+			return nil
+		},
+	}
+}
+
+func (ContainerRegistryImageImportResource) CustomizeDiff() sdk.ResourceFunc {
+	return sdk.ResourceFunc{
+		Timeout: 5 * time.Minute,
+		Func: func(ctx context.Context, metadata sdk.ResourceMetaData) error {
+			if metadata.ResourceDiff.Id() == "" {
+				return nil
+			}
+
+			// when the image the target tags reference differs from the source image (either since the source tag has been
+			// updated, or the target tag has been overwritten) the image needs to be imported again
+			digest := metadata.ResourceDiff.Get("digest").(string)
+			sourceDigest := metadata.ResourceDiff.Get("source_digest").(string)
+			if digest != "" && sourceDigest != "" && digest != sourceDigest {
+				if err := metadata.ResourceDiff.SetNew("digest", sourceDigest); err != nil {
+					return fmt.Errorf("setting `digest`: %+v", err)
+				}
+				if err := metadata.ResourceDiff.ForceNew("digest"); err != nil {
+					return err
+				}
+			}
+
+			return nil
+		},
+	}
+}
+
+// retrieveSourceDigest returns the digest of the source image, which is looked up from the source registry when the
+// source image references a tag
+func (ContainerRegistryImageImportResource) retrieveSourceDigest(ctx context.Context, metadata sdk.ResourceMetaData, state ContainerRegistryImageImportModel) (*string, error) {
+	repository, reference := containerRegistryImageReference(state.SourceImage)
+	if strings.Contains(reference, ":") {
+		return pointer.To(reference), nil
+	}
+
+	if state.SourceContainerRegistryId != "" {
+		sourceRegistryId, err := registries.ParseRegistryID(state.SourceContainerRegistryId)
+		if err != nil {
+			return nil, err
+		}
+
+		sourceRegistry, err := metadata.Client.Containers.ContainerRegistryClient.Registries.Get(ctx, *sourceRegistryId)
+		if err != nil {
+			return nil, fmt.Errorf("retrieving %s: %+v", sourceRegistryId, err)
+		}
+		if sourceRegistry.Model == nil || sourceRegistry.Model.Properties == nil || sourceRegistry.Model.Properties.LoginServer == nil {
+			return nil, fmt.Errorf("retrieving %s: `loginServer` was nil", sourceRegistryId)
+		}
+
+		dataPlaneClient, err := newAzureContainerRegistryDataPlaneClient(ctx, metadata.Client, *sourceRegistry.Model.Properties.LoginServer)
+		if err != nil {
+			return nil, fmt.Errorf("building data plane client for %s: %+v", sourceRegistryId, err)
+		}
+
+		return dataPlaneClient.ManifestDigest(ctx, repository, reference)
+	}
+
+	username, password := "", ""
+	if len(state.SourceCredentials) > 0 {
+		username = state.SourceCredentials[0].Username
+		password = state.SourceCredentials[0].Password
+	}
+	dataPlaneClient := newContainerRegistryDataPlaneClient(metadata.Client, state.SourceRegistryUri, username, password)
+
+	// images from Docker Hub without a namespace are within the `library` namespace
+	if dataPlaneClient.host == "registry-1.docker.io" && !strings.Contains(repository, "/") {
+		repository = fmt.Sprintf("library/%s", repository)
+	}
+
+	return dataPlaneClient.ManifestDigest(ctx, repository, reference)
+}
+
+var _ resourceids.Id = ContainerRegistryImageImportId{}
+
+// ContainerRegistryImageImportId identifies an image imported into a Container Registry, the targets are either tags
+// (e.g. `library/nginx:latest`) or untagged repositories (e.g. `library/nginx`) - and the source registry is either the
+// URI of a registry (e.g. `docker.io`) or the ID of a Container Registry. Since the source of the import can't be
+// retrieved from the API, it's part of the ID so that importing this resource doesn't plan a replacement.
+type ContainerRegistryImageImportId struct {
+	RegistryId     registries.RegistryId
+	Targets        []string
+	SourceRegistry string
+	SourceImage    string
+}
+
+func NewContainerRegistryImageImportID(registryId registries.RegistryId, targets []string, sourceRegistry string, sourceImage string) ContainerRegistryImageImportId {
+	return ContainerRegistryImageImportId{
+		RegistryId:     registryId,
+		Targets:        targets,
+		SourceRegistry: sourceRegistry,
+		SourceImage:    sourceImage,
+	}
+}
+
+func (id ContainerRegistryImageImportId) ID() string {
+	targets := make([]string, 0, len(id.Targets))
+	for _, target := range id.Targets {
+		targets = append(targets, url.PathEscape(target))
+	}
+
+	return fmt.Sprintf("%s/importedImages/%s/sourceRegistries/%s/sourceImages/%s", id.RegistryId.ID(), strings.Join(targets, ","), url.PathEscape(id.SourceRegistry), url.PathEscape(id.SourceImage))
+}
+
+func (id ContainerRegistryImageImportId) String() string {
+	return fmt.Sprintf("Imported Image %q from %q (%s)", strings.Join(id.Targets, ", "), id.SourceImage, id.RegistryId)
+}
+
+func ParseContainerRegistryImageImportID(input string) (*ContainerRegistryImageImportId, error) {
+	registryId, remainder, ok := strings.Cut(input, "/importedImages/")
+	segments := strings.Split(remainder, "/")
+	if !ok || len(segments) != 5 || segments[1] != "sourceRegistries" || segments[3] != "sourceImages" || segments[0] == "" || segments[2] == "" || segments[4] == "" {
+		return nil, fmt.Errorf("parsing %q as a Container Registry Image Import ID: expected the format `{containerRegistryId}/importedImages/{targets}/sourceRegistries/{sourceRegistry}/sourceImages/{sourceImage}`", input)
+	}
+
+	parsedRegistryId, err := registries.ParseRegistryID(registryId)
+	if err != nil {
+		return nil, fmt.Errorf("parsing %q as a Container Registry Image Import ID: %+v", input, err)
+	}
+
+	targets := make([]string, 0)
+	for _, target := range strings.Split(segments[0], ",") {
+		unescapedTarget, err := url.PathUnescape(target)
+		if err != nil {
+			return nil, fmt.Errorf("parsing %q as a Container Registry Image Import ID: unescaping the target %q: %+v", input, target, err)
+		}
+		if unescapedTarget == "" {
+			return nil, fmt.Errorf("parsing %q as a Container Registry Image Import ID: the targets contained an empty value", input)
+		}
+		targets = append(targets, unescapedTarget)
+	}
+
+	sourceRegistry, err := url.PathUnescape(segments[2])
+	if err != nil {
+		return nil, fmt.Errorf("parsing %q as a Container Registry Image Import ID: unescaping the source registry: %+v", input, err)
+	}
+
+	sourceImage, err := url.PathUnescape(segments[4])
+	if err != nil {
+		return nil, fmt.Errorf("parsing %q as a Container Registry Image Import ID: unescaping the source image: %+v", input, err)
+	}
+
+	id := NewContainerRegistryImageImportID(*parsedRegistryId, targets, sourceRegistry, sourceImage)
+	return &id, nil
+}
+
+func validateContainerRegistryImageImportID(input interface{}, key string) (warnings []string, errors []error) {
+	v, ok := input.(string)
+	if !ok {
+		errors = append(errors, fmt.Errorf("expected %q to be a string", key))
+		return
+	}
+
+	if _, err := ParseContainerRegistryImageImportID(v); err != nil {
+		errors = append(errors, err)
+	}
+
+	return
+}
+
+func validateContainerRegistryImageImportTargetTag(input interface{}, key string) (warnings []string, errors []error) {
+	v, ok := input.(string)
+	if !ok {
+		errors = append(errors, fmt.Errorf("expected %q to be a string", key))
+		return
+	}
+
+	repository, tag, ok := strings.Cut(v, ":")
+	if !ok || repository == "" || tag == "" || strings.Contains(tag, ":") {
+		errors = append(errors, fmt.Errorf("expected %q to be in the format `{repository}:{tag}`, got %q", key, v))
+	}
+
+	return
+}
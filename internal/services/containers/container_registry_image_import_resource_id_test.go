@@ -0,0 +1,69 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package containers
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/go-azure-sdk/resource-manager/containerregistry/2023-11-01-preview/registries"
+)
+
+func TestContainerRegistryImageImportID(t *testing.T) {
+	registryId := registries.NewRegistryID("00000000-0000-0000-0000-000000000000", "resGroup1", "registry1")
+	sourceRegistryId := registries.NewRegistryID("00000000-0000-0000-0000-000000000000", "resGroup1", "registry2")
+
+	testData := []struct {
+		Name     string
+		Input    ContainerRegistryImageImportId
+		Expected string
+	}{
+		{
+			Name:     "Source Registry URI",
+			Input:    NewContainerRegistryImageImportID(registryId, []string{"hello-world:acctest", "hello-world:latest", "hello-world-untagged"}, "mcr.microsoft.com", "hello-world:latest"),
+			Expected: "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/resGroup1/providers/Microsoft.ContainerRegistry/registries/registry1/importedImages/hello-world:acctest,hello-world:latest,hello-world-untagged/sourceRegistries/mcr.microsoft.com/sourceImages/hello-world:latest",
+		},
+		{
+			Name:     "Source Container Registry",
+			Input:    NewContainerRegistryImageImportID(registryId, []string{"library/nginx:latest"}, sourceRegistryId.ID(), "library/nginx@sha256:0000"),
+			Expected: "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/resGroup1/providers/Microsoft.ContainerRegistry/registries/registry1/importedImages/library%2Fnginx:latest/sourceRegistries/%2Fsubscriptions%2F00000000-0000-0000-0000-000000000000%2FresourceGroups%2FresGroup1%2Fproviders%2FMicrosoft.ContainerRegistry%2Fregistries%2Fregistry2/sourceImages/library%2Fnginx@sha256:0000",
+		},
+	}
+
+	for _, v := range testData {
+		t.Logf("[DEBUG] Testing %q", v.Name)
+
+		actual := v.Input.ID()
+		if actual != v.Expected {
+			t.Fatalf("expected the ID %q but got %q", v.Expected, actual)
+		}
+
+		parsed, err := ParseContainerRegistryImageImportID(actual)
+		if err != nil {
+			t.Fatalf("parsing %q: %+v", actual, err)
+		}
+		if !reflect.DeepEqual(*parsed, v.Input) {
+			t.Fatalf("expected %+v but got %+v", v.Input, *parsed)
+		}
+	}
+}
+
+func TestParseContainerRegistryImageImportIDInvalid(t *testing.T) {
+	testData := []string{
+		"",
+		"/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/resGroup1/providers/Microsoft.ContainerRegistry/registries/registry1",
+		"/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/resGroup1/providers/Microsoft.ContainerRegistry/registries/registry1/importedImages/hello-world:acctest",
+		"/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/resGroup1/providers/Microsoft.ContainerRegistry/registries/registry1/importedImages/hello-world:acctest,/sourceRegistries/mcr.microsoft.com/sourceImages/hello-world:latest",
+		"/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/resGroup1/providers/Microsoft.ContainerRegistry/registries/registry1/importedImages/hello-world:acctest/sourceRegistries/mcr.microsoft.com/sourceImages/",
+		"/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/resGroup1/importedImages/hello-world:acctest/sourceRegistries/mcr.microsoft.com/sourceImages/hello-world:latest",
+	}
+
+	for _, v := range testData {
+		t.Logf("[DEBUG] Testing %q", v)
+
+		if _, err := ParseContainerRegistryImageImportID(v); err == nil {
+			t.Fatalf("expected an error parsing %q but didn't get one", v)
+		}
+	}
+}
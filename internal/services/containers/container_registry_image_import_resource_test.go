@@ -0,0 +1,267 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package containers_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/go-azure-helpers/lang/pointer"
+	"github.com/hashicorp/go-azure-helpers/lang/response"
+	"github.com/hashicorp/go-azure-sdk/resource-manager/containerregistry/2023-11-01-preview/registries"
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/acceptance"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/acceptance/check"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/clients"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/services/containers"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
+)
+
+type ContainerRegistryImageImportResource struct{}
+
+func TestAccContainerRegistryImageImport_basic(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azurerm_container_registry_image_import", "test")
+	r := ContainerRegistryImageImportResource{}
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.basic(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("digest").Exists(),
+				check.That(data.ResourceName).Key("source_digest").Exists(),
+			),
+		},
+		data.ImportStep(),
+	})
+}
+
+func TestAccContainerRegistryImageImport_importThenPlan(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azurerm_container_registry_image_import", "test")
+	r := ContainerRegistryImageImportResource{}
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.multipleTargets(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+			),
+		},
+		{
+			// the imported state replaces the existing state, which mustn't plan a replacement (importing the image again)
+			Config:             r.multipleTargets(data),
+			ResourceName:       data.ResourceName,
+			ImportState:        true,
+			ImportStatePersist: true,
+		},
+		{
+			Config:   r.multipleTargets(data),
+			PlanOnly: true,
+		},
+	})
+}
+
+func TestAccContainerRegistryImageImport_requiresImport(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azurerm_container_registry_image_import", "test")
+	r := ContainerRegistryImageImportResource{}
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.basic(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+			),
+		},
+		data.RequiresImportErrorStep(r.requiresImport),
+	})
+}
+
+func TestAccContainerRegistryImageImport_complete(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azurerm_container_registry_image_import", "test")
+	r := ContainerRegistryImageImportResource{}
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.complete(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("digest").Exists(),
+			),
+		},
+		data.ImportStep("force_enabled"),
+	})
+}
+
+func TestAccContainerRegistryImageImport_sourceContainerRegistry(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azurerm_container_registry_image_import", "test")
+	r := ContainerRegistryImageImportResource{}
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.sourceContainerRegistry(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("digest").Exists(),
+				check.That(data.ResourceName).Key("source_digest").Exists(),
+			),
+		},
+		data.ImportStep(),
+	})
+}
+
+func TestAccContainerRegistryImageImport_targetTagDrift(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azurerm_container_registry_image_import", "test")
+	r := ContainerRegistryImageImportResource{}
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		data.DriftStep(acceptance.DriftStepData{
+			Config:          r.basic,
+			Mutation:        r.overwriteTargetTag,
+			ExpectedChanges: []string{"digest"},
+			ExpectedAction:  plancheck.ResourceActionReplace,
+		}),
+	})
+}
+
+func (r ContainerRegistryImageImportResource) Exists(ctx context.Context, client *clients.Client, state *pluginsdk.InstanceState) (*bool, error) {
+	id, err := containers.ParseContainerRegistryImageImportID(state.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	// the target tags are checked when the resource is read, which removes the resource from the state when they no longer exist
+	resp, err := client.Containers.ContainerRegistryClient.Registries.Get(ctx, id.RegistryId)
+	if err != nil {
+		if response.WasNotFound(resp.HttpResponse) {
+			return pointer.To(false), nil
+		}
+		return nil, fmt.Errorf("retrieving %s: %+v", id.RegistryId, err)
+	}
+
+	return pointer.To(state.Attributes["digest"] != ""), nil
+}
+
+func (r ContainerRegistryImageImportResource) overwriteTargetTag(ctx context.Context, client *clients.Client, state *pluginsdk.InstanceState) error {
+	id, err := containers.ParseContainerRegistryImageImportID(state.ID)
+	if err != nil {
+		return err
+	}
+
+	payload := registries.ImportImageParameters{
+		Mode: pointer.To(registries.ImportModeForce),
+		Source: registries.ImportSource{
+			RegistryUri: pointer.To("mcr.microsoft.com"),
+			SourceImage: "azuredocs/aci-helloworld:latest",
+		},
+		TargetTags: pointer.To([]string{id.Targets[0]}),
+	}
+	if err := client.Containers.ContainerRegistryClient.Registries.ImportImageThenPoll(ctx, id.RegistryId, payload); err != nil {
+		return fmt.Errorf("overwriting the tag %q in %s: %+v", id.Targets[0], id.RegistryId, err)
+	}
+
+	return nil
+}
+
+func (r ContainerRegistryImageImportResource) basic(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+%s
+
+resource "azurerm_container_registry_image_import" "test" {
+  container_registry_id = azurerm_container_registry.test.id
+  source_registry_uri   = "mcr.microsoft.com"
+  source_image          = "hello-world:latest"
+  target_tags           = ["hello-world:acctest"]
+}
+`, r.template(data))
+}
+
+func (r ContainerRegistryImageImportResource) requiresImport(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+%s
+
+resource "azurerm_container_registry_image_import" "import" {
+  container_registry_id = azurerm_container_registry_image_import.test.container_registry_id
+  source_registry_uri   = azurerm_container_registry_image_import.test.source_registry_uri
+  source_image          = azurerm_container_registry_image_import.test.source_image
+  target_tags           = azurerm_container_registry_image_import.test.target_tags
+}
+`, r.basic(data))
+}
+
+func (r ContainerRegistryImageImportResource) multipleTargets(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+%s
+
+resource "azurerm_container_registry_image_import" "test" {
+  container_registry_id        = azurerm_container_registry.test.id
+  source_registry_uri          = "mcr.microsoft.com"
+  source_image                 = "hello-world:latest"
+  target_tags                  = ["hello-world:acctest", "hello-world:latest"]
+  untagged_target_repositories = ["hello-world-untagged"]
+}
+`, r.template(data))
+}
+
+func (r ContainerRegistryImageImportResource) complete(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+%s
+
+resource "azurerm_container_registry_image_import" "test" {
+  container_registry_id        = azurerm_container_registry.test.id
+  source_registry_uri          = "mcr.microsoft.com"
+  source_image                 = "hello-world:latest"
+  target_tags                  = ["hello-world:acctest", "hello-world:latest"]
+  untagged_target_repositories = ["hello-world-untagged"]
+  force_enabled                = true
+}
+`, r.template(data))
+}
+
+func (r ContainerRegistryImageImportResource) sourceContainerRegistry(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+%s
+
+resource "azurerm_container_registry" "target" {
+  name                = "acctestacrtarget%d"
+  resource_group_name = azurerm_resource_group.test.name
+  location            = azurerm_resource_group.test.location
+  sku                 = "Basic"
+}
+
+resource "azurerm_container_registry_image_import" "source" {
+  container_registry_id = azurerm_container_registry.test.id
+  source_registry_uri   = "mcr.microsoft.com"
+  source_image          = "hello-world:latest"
+  target_tags           = ["hello-world:acctest"]
+}
+
+resource "azurerm_container_registry_image_import" "test" {
+  container_registry_id        = azurerm_container_registry.target.id
+  source_container_registry_id = azurerm_container_registry.test.id
+  source_image                 = azurerm_container_registry_image_import.source.target_tags.0
+  target_tags                  = ["hello-world:acctest"]
+}
+`, r.template(data), data.RandomInteger)
+}
+
+func (ContainerRegistryImageImportResource) template(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+provider "azurerm" {
+  features {}
+}
+
+resource "azurerm_resource_group" "test" {
+  name     = "acctestRG-acr-%[1]d"
+  location = "%[2]s"
+}
+
+resource "azurerm_container_registry" "test" {
+  name                = "acctestacr%[1]d"
+  resource_group_name = azurerm_resource_group.test.name
+  location            = azurerm_resource_group.test.location
+  sku                 = "Basic"
+}
+`, data.RandomInteger, data.Locations.Primary)
+}
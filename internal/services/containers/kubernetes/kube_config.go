@@ -37,6 +37,7 @@ type userItemAAD struct {
 
 type userAAD struct {
 	AuthProvider authProvider `yaml:"auth-provider"`
+	Exec         exec         `yaml:"exec,omitempty"`
 }
 
 type authProvider struct {
@@ -50,6 +51,18 @@ type configAzureAD struct {
 	TenantID    string `yaml:"tenant-id,omitempty"`
 }
 
+type exec struct {
+	APIVersion string    `yaml:"apiVersion,omitempty"`
+	Command    string    `yaml:"command,omitempty"`
+	Args       []string  `yaml:"args,omitempty"`
+	Env        []execEnv `yaml:"env,omitempty"`
+}
+
+type execEnv struct {
+	Name  string `yaml:"name"`
+	Value string `yaml:"value"`
+}
+
 type contextItem struct {
 	Name    string  `yaml:"name"`
 	Context context `yaml:"context"`
@@ -90,16 +103,8 @@ func ParseKubeConfig(config string) (*KubeConfig, error) {
 	if err := yaml.Unmarshal([]byte(config), &kubeConfig); err != nil {
 		return nil, fmt.Errorf("Failed to unmarshal YAML config with error %+v", err)
 	}
-	if len(kubeConfig.Clusters) == 0 || len(kubeConfig.Users) == 0 {
-		return nil, fmt.Errorf("Config %+v contains no valid clusters or users", kubeConfig)
-	}
-	u := kubeConfig.Users[0].User
-	if u.Token == "" && (u.ClientCertificteData == "" || u.ClientKeyData == "") {
-		return nil, fmt.Errorf("Config requires either token or certificate auth for user %+v", u)
-	}
-	c := kubeConfig.Clusters[0].Cluster
-	if c.Server == "" {
-		return nil, fmt.Errorf("Config has invalid or non existent server for cluster %+v", c)
+	if err := kubeConfig.validate(); err != nil {
+		return nil, err
 	}
 
 	return &kubeConfig, nil
@@ -114,14 +119,83 @@ func ParseKubeConfigAAD(config string) (*KubeConfigAAD, error) {
 	if err := yaml.Unmarshal([]byte(config), &kubeConfig); err != nil {
 		return nil, fmt.Errorf("Failed to unmarshal YAML config with error %+v", err)
 	}
+	if err := kubeConfig.validate(); err != nil {
+		return nil, err
+	}
+
+	return &kubeConfig, nil
+}
+
+// ParseKubeConfigEitherFormat parses the YAML once and determines the format from the first user: when it contains either
+// an `auth-provider` or an `exec` block (Azure Active Directory authentication) a KubeConfigAAD is returned, otherwise a KubeConfig
+func ParseKubeConfigEitherFormat(config string) (*KubeConfig, *KubeConfigAAD, error) {
+	if config == "" {
+		return nil, nil, fmt.Errorf("Cannot parse empty config")
+	}
+
+	var document yaml.Node
+	if err := yaml.Unmarshal([]byte(config), &document); err != nil {
+		return nil, nil, fmt.Errorf("Failed to unmarshal YAML config with error %+v", err)
+	}
+
+	var authentication struct {
+		Users []struct {
+			User struct {
+				AuthProvider *yaml.Node `yaml:"auth-provider"`
+				Exec         *yaml.Node `yaml:"exec"`
+			} `yaml:"user"`
+		} `yaml:"users"`
+	}
+	if err := document.Decode(&authentication); err != nil {
+		return nil, nil, fmt.Errorf("Failed to decode YAML config with error %+v", err)
+	}
+
+	if users := authentication.Users; len(users) > 0 && (users[0].User.AuthProvider != nil || users[0].User.Exec != nil) {
+		var kubeConfig KubeConfigAAD
+		if err := document.Decode(&kubeConfig); err != nil {
+			return nil, nil, fmt.Errorf("Failed to decode YAML config with error %+v", err)
+		}
+		if err := kubeConfig.validate(); err != nil {
+			return nil, nil, err
+		}
+		return nil, &kubeConfig, nil
+	}
+
+	var kubeConfig KubeConfig
+	if err := document.Decode(&kubeConfig); err != nil {
+		return nil, nil, fmt.Errorf("Failed to decode YAML config with error %+v", err)
+	}
+	if err := kubeConfig.validate(); err != nil {
+		return nil, nil, err
+	}
+	return &kubeConfig, nil, nil
+}
+
+func (kubeConfig KubeConfig) validate() error {
 	if len(kubeConfig.Clusters) == 0 || len(kubeConfig.Users) == 0 {
-		return nil, fmt.Errorf("Config %+v contains no valid clusters or users", kubeConfig)
+		return fmt.Errorf("Config %+v contains no valid clusters or users", kubeConfig)
+	}
+	u := kubeConfig.Users[0].User
+	if u.Token == "" && (u.ClientCertificteData == "" || u.ClientKeyData == "") {
+		return fmt.Errorf("Config requires either token or certificate auth for user %+v", u)
+	}
+	c := kubeConfig.Clusters[0].Cluster
+	if c.Server == "" {
+		return fmt.Errorf("Config has invalid or non existent server for cluster %+v", c)
+	}
+
+	return nil
+}
+
+func (kubeConfig KubeConfigAAD) validate() error {
+	if len(kubeConfig.Clusters) == 0 || len(kubeConfig.Users) == 0 {
+		return fmt.Errorf("Config %+v contains no valid clusters or users", kubeConfig)
 	}
 
 	c := kubeConfig.Clusters[0].Cluster
 	if c.Server == "" {
-		return nil, fmt.Errorf("Config has invalid or non existent server for cluster %+v", c)
+		return fmt.Errorf("Config has invalid or non existent server for cluster %+v", c)
 	}
 
-	return &kubeConfig, nil
+	return nil
 }
@@ -195,3 +195,109 @@ func LoadConfig(fileName string) string {
 
 	return string(bytes)
 }
+
+func TestParseKubeConfigAADWithExec(t *testing.T) {
+	expected := KubeConfigAAD{
+		KubeConfigBase: KubeConfigBase{
+			APIVersion: "v1",
+			Clusters: []clusterItem{
+				{
+					Name: "test-cluster",
+					Cluster: cluster{
+						ClusterAuthorityData: "test-cluster-authority-data",
+						Server:               "https://testcluster.org:443",
+					},
+				},
+			},
+			Contexts: []contextItem{
+				{
+					Name: "test-cluster",
+					Context: context{
+						Cluster: "test-cluster",
+						User:    "test-user",
+					},
+				},
+			},
+			CurrentContext: "test-cluster",
+			Kind:           "Config",
+			Preferences:    map[string]interface{}{},
+		},
+		Users: []userItemAAD{
+			{
+				Name: "test-user",
+				User: userAAD{
+					Exec: exec{
+						APIVersion: "client.authentication.k8s.io/v1beta1",
+						Command:    "kubelogin",
+						Args:       []string{"get-token", "--login", "devicecode", "--server-id", "test-server-id"},
+					},
+				},
+			},
+		},
+	}
+
+	result, err := ParseKubeConfigAAD(LoadConfig("user_with_exec.yml"))
+	if err != nil {
+		t.Fatalf("parsing config: %+v", err)
+	}
+
+	if !reflect.DeepEqual(expected, *result) {
+		t.Fatalf("expected '%+v' but got '%+v'", expected, *result)
+	}
+}
+
+func TestParseKubeConfigEitherFormat(t *testing.T) {
+	testCases := []struct {
+		sourceFile  string
+		expectAAD   bool
+		expectError bool
+	}{
+		{
+			sourceFile: "user_with_token.yml",
+			expectAAD:  false,
+		},
+		{
+			sourceFile: "user_with_cert.yml",
+			expectAAD:  false,
+		},
+		{
+			sourceFile: "user_with_exec.yml",
+			expectAAD:  true,
+		},
+		{
+			sourceFile: "user_with_auth_provider.yml",
+			expectAAD:  true,
+		},
+		{
+			sourceFile:  "user_with_no_auth.yml",
+			expectError: true,
+		},
+		{
+			sourceFile:  "cluster_with_no_server.yml",
+			expectError: true,
+		},
+	}
+
+	for _, test := range testCases {
+		kubeConfig, kubeConfigAAD, err := ParseKubeConfigEitherFormat(LoadConfig(test.sourceFile))
+		if test.expectError {
+			if err == nil {
+				t.Fatalf("expected an error parsing %q but didn't get one", test.sourceFile)
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatalf("parsing %q: %+v", test.sourceFile, err)
+		}
+
+		if test.expectAAD {
+			if kubeConfig != nil || kubeConfigAAD == nil {
+				t.Fatalf("expected %q to be parsed as an Azure Active Directory Kube Config", test.sourceFile)
+			}
+			continue
+		}
+		if kubeConfig == nil || kubeConfigAAD != nil {
+			t.Fatalf("expected %q to be parsed as a certificate/token Kube Config", test.sourceFile)
+		}
+	}
+}
@@ -0,0 +1,228 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package containers
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/go-azure-helpers/resourcemanager/commonids"
+	"github.com/hashicorp/go-azure-sdk/resource-manager/containerservice/2024-05-01/managedclusters"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/sdk"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/sdk/frameworkhelpers"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/services/containers/kubernetes"
+)
+
+var _ sdk.EphemeralResource = &KubernetesClusterCredentialsEphemeralResource{}
+
+func NewKubernetesClusterCredentialsEphemeralResource() ephemeral.EphemeralResource {
+	return &KubernetesClusterCredentialsEphemeralResource{}
+}
+
+type KubernetesClusterCredentialsEphemeralResource struct {
+	sdk.EphemeralResourceMetadata
+}
+
+type KubernetesClusterCredentialsEphemeralResourceModel struct {
+	KubernetesClusterId  types.String `tfsdk:"kubernetes_cluster_id"`
+	Admin                types.Bool   `tfsdk:"admin"`
+	KubeConfigRaw        types.String `tfsdk:"kube_config_raw"`
+	Host                 types.String `tfsdk:"host"`
+	Username             types.String `tfsdk:"username"`
+	ClusterCaCertificate types.String `tfsdk:"cluster_ca_certificate"`
+	ClientCertificate    types.String `tfsdk:"client_certificate"`
+	ClientKey            types.String `tfsdk:"client_key"`
+	Token                types.String `tfsdk:"token"`
+	ExecApiVersion       types.String `tfsdk:"exec_api_version"`
+	ExecCommand          types.String `tfsdk:"exec_command"`
+	ExecArgs             types.List   `tfsdk:"exec_args"`
+}
+
+func (e *KubernetesClusterCredentialsEphemeralResource) Metadata(_ context.Context, _ ephemeral.MetadataRequest, resp *ephemeral.MetadataResponse) {
+	resp.TypeName = "azurerm_kubernetes_cluster_credentials"
+}
+
+func (e *KubernetesClusterCredentialsEphemeralResource) Configure(_ context.Context, req ephemeral.ConfigureRequest, resp *ephemeral.ConfigureResponse) {
+	e.Defaults(req, resp)
+}
+
+func (e *KubernetesClusterCredentialsEphemeralResource) Schema(_ context.Context, _ ephemeral.SchemaRequest, resp *ephemeral.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Attributes: map[string]schema.Attribute{
+			"kubernetes_cluster_id": schema.StringAttribute{
+				Required: true,
+				Validators: []validator.String{
+					frameworkhelpers.WrappedStringValidator{
+						Func: commonids.ValidateKubernetesClusterID,
+					},
+				},
+			},
+
+			"admin": schema.BoolAttribute{
+				Optional: true,
+			},
+
+			"kube_config_raw": schema.StringAttribute{
+				Computed:  true,
+				Sensitive: true,
+			},
+
+			"host": schema.StringAttribute{
+				Computed: true,
+			},
+
+			"username": schema.StringAttribute{
+				Computed: true,
+			},
+
+			"cluster_ca_certificate": schema.StringAttribute{
+				Computed: true,
+			},
+
+			"client_certificate": schema.StringAttribute{
+				Computed:  true,
+				Sensitive: true,
+			},
+
+			"client_key": schema.StringAttribute{
+				Computed:  true,
+				Sensitive: true,
+			},
+
+			"token": schema.StringAttribute{
+				Computed:  true,
+				Sensitive: true,
+			},
+
+			"exec_api_version": schema.StringAttribute{
+				Computed: true,
+			},
+
+			"exec_command": schema.StringAttribute{
+				Computed: true,
+			},
+
+			"exec_args": schema.ListAttribute{
+				ElementType: types.StringType,
+				Computed:    true,
+			},
+		},
+	}
+}
+
+func (e *KubernetesClusterCredentialsEphemeralResource) Open(ctx context.Context, req ephemeral.OpenRequest, resp *ephemeral.OpenResponse) {
+	client := e.Client.Containers.KubernetesClustersClient
+	ctx, cancel := context.WithTimeout(ctx, time.Minute*5)
+	defer cancel()
+
+	var data KubernetesClusterCredentialsEphemeralResourceModel
+
+	if ok := e.DecodeOpen(ctx, req, resp, &data); !ok {
+		return
+	}
+
+	id, err := commonids.ParseKubernetesClusterID(data.KubernetesClusterId.ValueString())
+	if err != nil {
+		sdk.SetResponseErrorDiagnostic(resp, "", err)
+		return
+	}
+
+	var credentials *managedclusters.CredentialResults
+	configName := "clusterUser"
+	if data.Admin.ValueBool() {
+		configName = "clusterAdmin"
+		adminCredentials, err := client.ListClusterAdminCredentials(ctx, *id, managedclusters.ListClusterAdminCredentialsOperationOptions{})
+		if err != nil {
+			sdk.SetResponseErrorDiagnostic(resp, fmt.Sprintf("retrieving Admin Credentials for %s", id), err)
+			return
+		}
+		credentials = adminCredentials.Model
+	} else {
+		userCredentials, err := client.ListClusterUserCredentials(ctx, *id, managedclusters.ListClusterUserCredentialsOperationOptions{})
+		if err != nil {
+			sdk.SetResponseErrorDiagnostic(resp, fmt.Sprintf("retrieving User Credentials for %s", id), err)
+			return
+		}
+		credentials = userCredentials.Model
+	}
+
+	rawConfig := kubernetesClusterRawKubeConfig(credentials, configName)
+	if rawConfig == "" {
+		sdk.SetResponseErrorDiagnostic(resp, fmt.Sprintf("retrieving the %q Credentials for %s", configName, id), "the Kube Config was empty")
+		return
+	}
+
+	data.KubeConfigRaw = types.StringValue(rawConfig)
+	execArgs := make([]string, 0)
+
+	kubeConfig, kubeConfigAAD, err := kubernetes.ParseKubeConfigEitherFormat(rawConfig)
+	if err != nil {
+		sdk.SetResponseErrorDiagnostic(resp, fmt.Sprintf("parsing the %q Credentials for %s", configName, id), err)
+		return
+	}
+
+	// clusters using Azure Active Directory (Entra ID) authentication return either the `auth-provider` or `exec` format
+	if kubeConfigAAD != nil {
+		cluster := kubeConfigAAD.Clusters[0].Cluster
+		user := kubeConfigAAD.Users[0]
+
+		data.Host = types.StringValue(cluster.Server)
+		data.ClusterCaCertificate = types.StringValue(cluster.ClusterAuthorityData)
+		data.Username = types.StringValue(user.Name)
+		data.ClientCertificate = types.StringValue("")
+		data.ClientKey = types.StringValue("")
+		data.Token = types.StringValue("")
+		data.ExecApiVersion = types.StringValue(user.User.Exec.APIVersion)
+		data.ExecCommand = types.StringValue(user.User.Exec.Command)
+		if user.User.Exec.Args != nil {
+			execArgs = user.User.Exec.Args
+		}
+	} else {
+		cluster := kubeConfig.Clusters[0].Cluster
+		user := kubeConfig.Users[0]
+
+		data.Host = types.StringValue(cluster.Server)
+		data.ClusterCaCertificate = types.StringValue(cluster.ClusterAuthorityData)
+		data.Username = types.StringValue(user.Name)
+		data.ClientCertificate = types.StringValue(user.User.ClientCertificteData)
+		data.ClientKey = types.StringValue(user.User.ClientKeyData)
+		data.Token = types.StringValue(user.User.Token)
+		data.ExecApiVersion = types.StringValue("")
+		data.ExecCommand = types.StringValue("")
+	}
+
+	args, diags := types.ListValueFrom(ctx, types.StringType, execArgs)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	data.ExecArgs = args
+
+	resp.Diagnostics.Append(resp.Result.Set(ctx, &data)...)
+}
+
+// kubernetesClusterRawKubeConfig returns the decoded Kube Config with the specified name, or an empty string if it's not present
+func kubernetesClusterRawKubeConfig(model *managedclusters.CredentialResults, configName string) string {
+	if model == nil || model.Kubeconfigs == nil {
+		return ""
+	}
+
+	for _, c := range *model.Kubeconfigs {
+		if c.Name == nil || *c.Name != configName || c.Value == nil {
+			continue
+		}
+
+		if base64IsEncoded(*c.Value) {
+			return base64Decode(*c.Value)
+		}
+		return *c.Value
+	}
+
+	return ""
+}
@@ -0,0 +1,109 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package containers_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/go-version"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
+	"github.com/hashicorp/terraform-plugin-testing/statecheck"
+	"github.com/hashicorp/terraform-plugin-testing/tfjsonpath"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/acceptance"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/provider/framework"
+)
+
+type KubernetesClusterCredentialsEphemeral struct{}
+
+func TestAccEphemeralKubernetesClusterCredentials_basic(t *testing.T) {
+	data := acceptance.BuildTestData(t, "ephemeral.azurerm_kubernetes_cluster_credentials", "test")
+	r := KubernetesClusterCredentialsEphemeral{}
+
+	resource.Test(t, resource.TestCase{
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(version.Must(version.NewVersion("1.10.0-rc1"))),
+		},
+		ProtoV5ProviderFactories: framework.ProtoV5ProviderFactoriesInit(context.Background(), "azurerm"),
+		ProtoV6ProviderFactories: framework.ProtoV6ProviderFactoriesInit(context.Background(), "azurerm", "echo"),
+		Steps: []resource.TestStep{
+			{
+				Config: r.basic(data),
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue("echo.test", tfjsonpath.New("data").AtMapKey("host"), knownvalue.NotNull()),
+					statecheck.ExpectKnownValue("echo.test", tfjsonpath.New("data").AtMapKey("cluster_ca_certificate"), knownvalue.NotNull()),
+					statecheck.ExpectKnownValue("echo.test", tfjsonpath.New("data").AtMapKey("client_certificate"), knownvalue.NotNull()),
+					statecheck.ExpectKnownValue("echo.test", tfjsonpath.New("data").AtMapKey("exec_command"), knownvalue.StringExact("")),
+				},
+			},
+		},
+	})
+}
+
+func TestAccEphemeralKubernetesClusterCredentials_azureActiveDirectory(t *testing.T) {
+	data := acceptance.BuildTestData(t, "ephemeral.azurerm_kubernetes_cluster_credentials", "test")
+	r := KubernetesClusterCredentialsEphemeral{}
+
+	resource.Test(t, resource.TestCase{
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(version.Must(version.NewVersion("1.10.0-rc1"))),
+		},
+		ProtoV5ProviderFactories: framework.ProtoV5ProviderFactoriesInit(context.Background(), "azurerm"),
+		ProtoV6ProviderFactories: framework.ProtoV6ProviderFactoriesInit(context.Background(), "azurerm", "echo"),
+		Steps: []resource.TestStep{
+			{
+				Config: r.azureActiveDirectory(data, false),
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue("echo.test", tfjsonpath.New("data").AtMapKey("host"), knownvalue.NotNull()),
+					statecheck.ExpectKnownValue("echo.test", tfjsonpath.New("data").AtMapKey("exec_command"), knownvalue.StringExact("kubelogin")),
+					statecheck.ExpectKnownValue("echo.test", tfjsonpath.New("data").AtMapKey("token"), knownvalue.StringExact("")),
+				},
+			},
+			{
+				Config: r.azureActiveDirectory(data, true),
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue("echo.test", tfjsonpath.New("data").AtMapKey("host"), knownvalue.NotNull()),
+					statecheck.ExpectKnownValue("echo.test", tfjsonpath.New("data").AtMapKey("client_certificate"), knownvalue.NotNull()),
+					statecheck.ExpectKnownValue("echo.test", tfjsonpath.New("data").AtMapKey("exec_command"), knownvalue.StringExact("")),
+				},
+			},
+		},
+	})
+}
+
+func (KubernetesClusterCredentialsEphemeral) basic(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+%s
+
+ephemeral "azurerm_kubernetes_cluster_credentials" "test" {
+  kubernetes_cluster_id = azurerm_kubernetes_cluster.test.id
+}
+
+provider "echo" {
+  data = ephemeral.azurerm_kubernetes_cluster_credentials.test
+}
+
+resource "echo" "test" {}
+`, KubernetesClusterResource{}.basicVMSSConfig(data))
+}
+
+func (KubernetesClusterCredentialsEphemeral) azureActiveDirectory(data acceptance.TestData, admin bool) string {
+	return fmt.Sprintf(`
+%s
+
+ephemeral "azurerm_kubernetes_cluster_credentials" "test" {
+  kubernetes_cluster_id = azurerm_kubernetes_cluster.test.id
+  admin                 = %t
+}
+
+provider "echo" {
+  data = ephemeral.azurerm_kubernetes_cluster_credentials.test
+}
+
+resource "echo" "test" {}
+`, KubernetesClusterResource{}.roleBasedAccessControlAADManagedConfig(data, ""), admin)
+}
@@ -2552,12 +2552,18 @@ func resourceKubernetesClusterRead(d *pluginsdk.ResourceData, meta interface{})
 		return fmt.Errorf("retrieving %s: %+v", *id, err)
 	}
 
-	credentials, err := client.ListClusterUserCredentials(ctx, *id, managedclusters.ListClusterUserCredentialsOperationOptions{})
-	if err != nil {
-		return fmt.Errorf("retrieving User Credentials for %s: %+v", id, err)
-	}
-	if credentials.Model == nil {
-		return fmt.Errorf("retrieving User Credentials for %s: payload is empty", id)
+	// the credentials are only retrieved (and stored in the state) when the `persist_kube_config` feature is enabled
+	persistKubeConfig := meta.(*clients.Client).Features.KubernetesCluster.PersistKubeConfig
+
+	var credentials managedclusters.ListClusterUserCredentialsOperationResponse
+	if persistKubeConfig {
+		credentials, err = client.ListClusterUserCredentials(ctx, *id, managedclusters.ListClusterUserCredentialsOperationOptions{})
+		if err != nil {
+			return fmt.Errorf("retrieving User Credentials for %s: %+v", id, err)
+		}
+		if credentials.Model == nil {
+			return fmt.Errorf("retrieving User Credentials for %s: payload is empty", id)
+		}
 	}
 
 	d.Set("name", id.ManagedClusterName)
@@ -2783,7 +2789,7 @@ func resourceKubernetesClusterRead(d *pluginsdk.ResourceData, meta interface{})
 			// adminProfile is only available for RBAC enabled clusters with AAD and local account is not disabled
 			var adminKubeConfigRaw *string
 			adminKubeConfig := make([]interface{}, 0)
-			if props.AadProfile != nil && (props.DisableLocalAccounts == nil || !*props.DisableLocalAccounts) {
+			if persistKubeConfig && props.AadProfile != nil && (props.DisableLocalAccounts == nil || !*props.DisableLocalAccounts) {
 				adminCredentials, err := client.ListClusterAdminCredentials(ctx, *id, managedclusters.ListClusterAdminCredentialsOperationOptions{})
 				if err != nil {
 					return fmt.Errorf("retrieving Admin Credentials for %s: %+v", id, err)
@@ -2807,7 +2813,11 @@ func resourceKubernetesClusterRead(d *pluginsdk.ResourceData, meta interface{})
 			return fmt.Errorf("setting `identity`: %+v", err)
 		}
 
-		kubeConfigRaw, kubeConfig := flattenKubernetesClusterCredentials(credentials.Model, "clusterUser")
+		var kubeConfigRaw *string
+		kubeConfig := make([]interface{}, 0)
+		if persistKubeConfig {
+			kubeConfigRaw, kubeConfig = flattenKubernetesClusterCredentials(credentials.Model, "clusterUser")
+		}
 		d.Set("kube_config_raw", kubeConfigRaw)
 		if err := d.Set("kube_config", kubeConfig); err != nil {
 			return fmt.Errorf("setting `kube_config`: %+v", err)
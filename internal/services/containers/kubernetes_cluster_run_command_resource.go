@@ -0,0 +1,361 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package containers
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"encoding/base64"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/go-azure-helpers/lang/pointer"
+	"github.com/hashicorp/go-azure-helpers/lang/response"
+	"github.com/hashicorp/go-azure-helpers/resourcemanager/commonids"
+	"github.com/hashicorp/go-azure-sdk/resource-manager/containerservice/2024-05-01/managedclusters"
+	"github.com/hashicorp/go-azure-sdk/sdk/client"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/sdk"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/validation"
+)
+
+const (
+	// kubernetesClusterRunCommandAADServerScope is the scope of the Azure Kubernetes Service AAD Server application,
+	// a token for which is required to run a command on a cluster using managed Azure Active Directory integration
+	kubernetesClusterRunCommandAADServerScope = "6dae42f8-4368-4678-94ff-3960e28e3630/.default"
+
+	kubernetesClusterRunCommandStateRunning   = "Running"
+	kubernetesClusterRunCommandStateSucceeded = "Succeeded"
+	kubernetesClusterRunCommandStateFailed    = "Failed"
+)
+
+var _ sdk.ResourceWithCustomImporter = KubernetesClusterRunCommandResource{}
+
+// KubernetesClusterRunCommandResource runs a command on a Kubernetes Cluster when created, the command is run again
+// only when the resource is replaced (e.g. when `triggers` changes) and destroying it only removes it from the state
+type KubernetesClusterRunCommandResource struct{}
+
+type KubernetesClusterRunCommandModel struct {
+	KubernetesClusterId string            `tfschema:"kubernetes_cluster_id"`
+	Command             string            `tfschema:"command"`
+	Files               map[string]string `tfschema:"files"`
+	Triggers            map[string]string `tfschema:"triggers"`
+	ExitCode            int64             `tfschema:"exit_code"`
+	Logs                string            `tfschema:"logs"`
+}
+
+func (KubernetesClusterRunCommandResource) Arguments() map[string]*pluginsdk.Schema {
+	return map[string]*pluginsdk.Schema{
+		"kubernetes_cluster_id": {
+			Type:         pluginsdk.TypeString,
+			Required:     true,
+			ForceNew:     true,
+			ValidateFunc: commonids.ValidateKubernetesClusterID,
+		},
+
+		"command": {
+			Type:         pluginsdk.TypeString,
+			Required:     true,
+			ForceNew:     true,
+			ValidateFunc: validation.StringIsNotEmpty,
+		},
+
+		"files": {
+			Type:      pluginsdk.TypeMap,
+			Optional:  true,
+			ForceNew:  true,
+			Sensitive: true,
+			Elem: &pluginsdk.Schema{
+				Type: pluginsdk.TypeString,
+			},
+		},
+
+		"triggers": {
+			Type:     pluginsdk.TypeMap,
+			Optional: true,
+			ForceNew: true,
+			Elem: &pluginsdk.Schema{
+				Type: pluginsdk.TypeString,
+			},
+		},
+	}
+}
+
+func (KubernetesClusterRunCommandResource) Attributes() map[string]*pluginsdk.Schema {
+	return map[string]*pluginsdk.Schema{
+		"exit_code": {
+			Type:     pluginsdk.TypeInt,
+			Computed: true,
+		},
+
+		"logs": {
+			Type:      pluginsdk.TypeString,
+			Computed:  true,
+			Sensitive: true,
+		},
+	}
+}
+
+func (KubernetesClusterRunCommandResource) ModelObject() interface{} {
+	return &KubernetesClusterRunCommandModel{}
+}
+
+func (KubernetesClusterRunCommandResource) ResourceType() string {
+	return "azurerm_kubernetes_cluster_run_command"
+}
+
+func (KubernetesClusterRunCommandResource) IDValidationFunc() pluginsdk.SchemaValidateFunc {
+	return managedclusters.ValidateCommandResultID
+}
+
+func (KubernetesClusterRunCommandResource) Create() sdk.ResourceFunc {
+	return sdk.ResourceFunc{
+		Timeout: 30 * time.Minute,
+		Func: func(ctx context.Context, metadata sdk.ResourceMetaData) error {
+			client := metadata.Client.Containers.KubernetesClustersClient
+
+			var config KubernetesClusterRunCommandModel
+			if err := metadata.Decode(&config); err != nil {
+				return fmt.Errorf("decoding: %+v", err)
+			}
+
+			clusterId, err := commonids.ParseKubernetesClusterID(config.KubernetesClusterId)
+			if err != nil {
+				return err
+			}
+
+			existing, err := client.Get(ctx, *clusterId)
+			if err != nil {
+				return fmt.Errorf("retrieving %s: %+v", clusterId, err)
+			}
+
+			payload := managedclusters.RunCommandRequest{
+				Command: config.Command,
+			}
+
+			if len(config.Files) > 0 {
+				commandContext, err := kubernetesClusterRunCommandContext(config.Files)
+				if err != nil {
+					return fmt.Errorf("building the file context for the command on %s: %+v", clusterId, err)
+				}
+				payload.Context = commandContext
+			}
+
+			// clusters using managed Azure Active Directory integration require a token for the AKS AAD Server to run a command
+			if model := existing.Model; model != nil && model.Properties != nil && model.Properties.AadProfile != nil && pointer.From(model.Properties.AadProfile.Managed) {
+				token, err := metadata.Client.AccessToken(ctx, kubernetesClusterRunCommandAADServerScope)
+				if err != nil {
+					return fmt.Errorf("acquiring a token to run a command on %s: %+v", clusterId, err)
+				}
+				payload.ClusterToken = pointer.To(token.AccessToken)
+			}
+
+			id, result, err := kubernetesClusterRunCommand(ctx, client, *clusterId, payload)
+			if err != nil {
+				return fmt.Errorf("running command on %s: %+v", clusterId, err)
+			}
+
+			// a command which exits with a non-zero exit code isn't an error, the exit code is exposed instead - and only
+			// the exit code and logs are stored, since the command result is only retained by the API for a limited time
+			config.ExitCode = pointer.From(result.ExitCode)
+			config.Logs = pointer.From(result.Logs)
+
+			metadata.SetID(id)
+			return metadata.Encode(&config)
+		},
+	}
+}
+
+func (KubernetesClusterRunCommandResource) Read() sdk.ResourceFunc {
+	return sdk.ResourceFunc{
+		Timeout: 5 * time.Minute,
+		Func: func(ctx context.Context, metadata sdk.ResourceMetaData) error {
+			client := metadata.Client.Containers.KubernetesClustersClient
+
+			id, err := managedclusters.ParseCommandResultID(metadata.ResourceData.Id())
+			if err != nil {
+				return err
+			}
+
+			var state KubernetesClusterRunCommandModel
+			if err := metadata.Decode(&state); err != nil {
+				return fmt.Errorf("decoding: %+v", err)
+			}
+
+			// the command isn't run again when refreshing, however once the cluster is removed it's gone
+			clusterId := commonids.NewKubernetesClusterID(id.SubscriptionId, id.ResourceGroupName, id.ManagedClusterName)
+			existing, err := client.Get(ctx, clusterId)
+			if err != nil {
+				if response.WasNotFound(existing.HttpResponse) {
+					return metadata.MarkAsGone(id)
+				}
+				return fmt.Errorf("retrieving %s: %+v", clusterId, err)
+			}
+
+			state.KubernetesClusterId = clusterId.ID()
+
+			return metadata.Encode(&state)
+		},
+	}
+}
+
+func (KubernetesClusterRunCommandResource) CustomImporter() sdk.ResourceRunFunc {
+	return func(ctx context.Context, metadata sdk.ResourceMetaData) error {
+		// the command, files and exit code can't be reliably retrieved once the command has run, as such importing
+		// would plan a replacement - which would run the command again
+		return fmt.Errorf("the `azurerm_kubernetes_cluster_run_command` resource doesn't support import, since the command would be run again")
+	}
+}
+
+func (KubernetesClusterRunCommandResource) Delete() sdk.ResourceFunc {
+	return sdk.ResourceFunc{
+		Timeout: 5 * time.Minute,
+		Func: func(ctx context.Context, metadata sdk.ResourceMetaData) error {
+			// the command can't be undone, as such this only removes the resource from the state
+			return nil
+		},
+	}
+}
+
+// kubernetesClusterRunCommand submits the command and then polls the command result until it's completed.
+//
+// NOTE: the SDK method for this operation can't be used, since the LRO poller doesn't return the result - as such we
+// retrieve the Command Result ID from the `Location` header and poll that ourselves.
+func kubernetesClusterRunCommand(ctx context.Context, clusterClient *managedclusters.ManagedClustersClient, id commonids.KubernetesClusterId, input managedclusters.RunCommandRequest) (*managedclusters.CommandResultId, *managedclusters.CommandResultProperties, error) {
+	opts := client.RequestOptions{
+		ContentType: "application/json; charset=utf-8",
+		ExpectedStatusCodes: []int{
+			http.StatusAccepted,
+			http.StatusOK,
+		},
+		HttpMethod: http.MethodPost,
+		Path:       fmt.Sprintf("%s/runCommand", id.ID()),
+	}
+
+	req, err := clusterClient.Client.NewRequest(ctx, opts)
+	if err != nil {
+		return nil, nil, fmt.Errorf("building request: %+v", err)
+	}
+	if err := req.Marshal(input); err != nil {
+		return nil, nil, fmt.Errorf("marshalling request: %+v", err)
+	}
+
+	resp, err := req.Execute(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if resp.StatusCode == http.StatusOK {
+		var result managedclusters.RunCommandResult
+		if err := resp.Unmarshal(&result); err != nil {
+			return nil, nil, fmt.Errorf("unmarshalling the command result: %+v", err)
+		}
+		if pointer.From(result.Id) == "" {
+			return nil, nil, fmt.Errorf("the command result didn't include an ID")
+		}
+		commandResultId := managedclusters.NewCommandResultID(id.SubscriptionId, id.ResourceGroupName, id.ManagedClusterName, *result.Id)
+		properties, err := kubernetesClusterRunCommandResult(result.Properties)
+		if err != nil {
+			return nil, nil, err
+		}
+		return &commandResultId, properties, nil
+	}
+
+	location := resp.Header.Get("Location")
+	if location == "" {
+		return nil, nil, fmt.Errorf("no `Location` header was returned to poll")
+	}
+	pollingUrl, err := url.Parse(location)
+	if err != nil {
+		return nil, nil, fmt.Errorf("parsing the polling URL %q: %+v", location, err)
+	}
+	commandResultId, err := managedclusters.ParseCommandResultIDInsensitively(pollingUrl.Path)
+	if err != nil {
+		return nil, nil, fmt.Errorf("parsing the Command Result ID from the polling URL %q: %+v", location, err)
+	}
+
+	var properties *managedclusters.CommandResultProperties
+	deadline, _ := ctx.Deadline()
+	stateConf := &pluginsdk.StateChangeConf{
+		Pending: []string{kubernetesClusterRunCommandStateRunning},
+		Target:  []string{kubernetesClusterRunCommandStateSucceeded, kubernetesClusterRunCommandStateFailed},
+		Refresh: func() (interface{}, string, error) {
+			result, err := clusterClient.GetCommandResult(ctx, *commandResultId)
+			if err != nil {
+				return nil, "", fmt.Errorf("retrieving %s: %+v", commandResultId, err)
+			}
+
+			// whilst the command is running the API returns a 202 without a body
+			if result.Model == nil || result.Model.Properties == nil {
+				return result, kubernetesClusterRunCommandStateRunning, nil
+			}
+
+			properties = result.Model.Properties
+			switch state := pointer.From(properties.ProvisioningState); {
+			case strings.EqualFold(state, kubernetesClusterRunCommandStateSucceeded):
+				return result, kubernetesClusterRunCommandStateSucceeded, nil
+			case strings.EqualFold(state, kubernetesClusterRunCommandStateFailed):
+				return result, kubernetesClusterRunCommandStateFailed, nil
+			default:
+				return result, kubernetesClusterRunCommandStateRunning, nil
+			}
+		},
+		MinTimeout:   5 * time.Second,
+		PollInterval: 5 * time.Second,
+		Timeout:      time.Until(deadline),
+	}
+	if _, err := stateConf.WaitForStateContext(ctx); err != nil {
+		return nil, nil, fmt.Errorf("waiting for %s to complete: %+v", commandResultId, err)
+	}
+
+	result, err := kubernetesClusterRunCommandResult(properties)
+	if err != nil {
+		return nil, nil, err
+	}
+	return commandResultId, result, nil
+}
+
+func kubernetesClusterRunCommandResult(input *managedclusters.CommandResultProperties) (*managedclusters.CommandResultProperties, error) {
+	if input == nil {
+		return nil, fmt.Errorf("the command result was nil")
+	}
+
+	if strings.EqualFold(pointer.From(input.ProvisioningState), kubernetesClusterRunCommandStateFailed) {
+		return nil, fmt.Errorf("the command failed to run: %s", pointer.From(input.Reason))
+	}
+
+	return input, nil
+}
+
+// kubernetesClusterRunCommandContext returns the base64 encoded zip archive containing the specified files, which are
+// made available in the working directory of the command
+func kubernetesClusterRunCommandContext(files map[string]string) (*string, error) {
+	names := make([]string, 0, len(files))
+	for name := range files {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	buf := new(bytes.Buffer)
+	writer := zip.NewWriter(buf)
+	for _, name := range names {
+		f, err := writer.Create(name)
+		if err != nil {
+			return nil, fmt.Errorf("adding %q: %+v", name, err)
+		}
+		if _, err := f.Write([]byte(files[name])); err != nil {
+			return nil, fmt.Errorf("writing %q: %+v", name, err)
+		}
+	}
+	if err := writer.Close(); err != nil {
+		return nil, fmt.Errorf("closing the archive: %+v", err)
+	}
+
+	return pointer.To(base64.StdEncoding.EncodeToString(buf.Bytes())), nil
+}
@@ -0,0 +1,188 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package containers_test
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/go-azure-helpers/lang/pointer"
+	"github.com/hashicorp/go-azure-helpers/lang/response"
+	"github.com/hashicorp/go-azure-helpers/resourcemanager/commonids"
+	"github.com/hashicorp/go-azure-sdk/resource-manager/containerservice/2024-05-01/managedclusters"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/acceptance"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/acceptance/check"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/clients"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
+)
+
+type KubernetesClusterRunCommandResource struct{}
+
+func TestAccKubernetesClusterRunCommand_basic(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azurerm_kubernetes_cluster_run_command", "test")
+	r := KubernetesClusterRunCommandResource{}
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.basic(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("exit_code").HasValue("0"),
+				check.That(data.ResourceName).Key("logs").MatchesRegex(regexp.MustCompile("namespace/default")),
+			),
+		},
+	})
+}
+
+func TestAccKubernetesClusterRunCommand_files(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azurerm_kubernetes_cluster_run_command", "test")
+	r := KubernetesClusterRunCommandResource{}
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.files(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("exit_code").HasValue("0"),
+				check.That(data.ResourceName).Key("logs").MatchesRegex(regexp.MustCompile("configmap/acctest created")),
+			),
+		},
+	})
+}
+
+func TestAccKubernetesClusterRunCommand_triggers(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azurerm_kubernetes_cluster_run_command", "test")
+	r := KubernetesClusterRunCommandResource{}
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.triggers(data, "first"),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("exit_code").HasValue("0"),
+			),
+		},
+		{
+			Config: r.triggers(data, "second"),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("exit_code").HasValue("0"),
+			),
+		},
+	})
+}
+
+func TestAccKubernetesClusterRunCommand_nonZeroExitCode(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azurerm_kubernetes_cluster_run_command", "test")
+	r := KubernetesClusterRunCommandResource{}
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.command(data, "kubectl get namespace does-not-exist"),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("exit_code").HasValue("1"),
+			),
+		},
+	})
+}
+
+func TestAccKubernetesClusterRunCommand_azureActiveDirectory(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azurerm_kubernetes_cluster_run_command", "test")
+	r := KubernetesClusterRunCommandResource{}
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.azureActiveDirectory(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("exit_code").HasValue("0"),
+			),
+		},
+	})
+}
+
+func (KubernetesClusterRunCommandResource) Exists(ctx context.Context, clients *clients.Client, state *pluginsdk.InstanceState) (*bool, error) {
+	id, err := managedclusters.ParseCommandResultID(state.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	// the command result is only retained for a limited time, as such this exists for as long as the cluster does
+	clusterId := commonids.NewKubernetesClusterID(id.SubscriptionId, id.ResourceGroupName, id.ManagedClusterName)
+	resp, err := clients.Containers.KubernetesClustersClient.Get(ctx, clusterId)
+	if err != nil {
+		if response.WasNotFound(resp.HttpResponse) {
+			return pointer.To(false), nil
+		}
+		return nil, fmt.Errorf("retrieving %s: %+v", clusterId, err)
+	}
+	return pointer.To(resp.Model != nil), nil
+}
+
+func (r KubernetesClusterRunCommandResource) basic(data acceptance.TestData) string {
+	return r.command(data, "kubectl get namespace default -o name")
+}
+
+func (KubernetesClusterRunCommandResource) command(data acceptance.TestData, command string) string {
+	return fmt.Sprintf(`
+%s
+
+resource "azurerm_kubernetes_cluster_run_command" "test" {
+  kubernetes_cluster_id = azurerm_kubernetes_cluster.test.id
+  command               = "%s"
+}
+`, KubernetesClusterResource{}.basicVMSSConfig(data), command)
+}
+
+func (KubernetesClusterRunCommandResource) files(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+%s
+
+resource "azurerm_kubernetes_cluster_run_command" "test" {
+  kubernetes_cluster_id = azurerm_kubernetes_cluster.test.id
+  command               = "kubectl apply -f configmap.yaml"
+
+  files = {
+    "configmap.yaml" = <<YAML
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: acctest
+  namespace: default
+data:
+  hello: world
+YAML
+  }
+}
+`, KubernetesClusterResource{}.basicVMSSConfig(data))
+}
+
+func (KubernetesClusterRunCommandResource) triggers(data acceptance.TestData, value string) string {
+	return fmt.Sprintf(`
+%s
+
+resource "azurerm_kubernetes_cluster_run_command" "test" {
+  kubernetes_cluster_id = azurerm_kubernetes_cluster.test.id
+  command               = "kubectl get namespace default -o name"
+
+  triggers = {
+    value = "%s"
+  }
+}
+`, KubernetesClusterResource{}.basicVMSSConfig(data), value)
+}
+
+func (KubernetesClusterRunCommandResource) azureActiveDirectory(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+%s
+
+resource "azurerm_kubernetes_cluster_run_command" "test" {
+  kubernetes_cluster_id = azurerm_kubernetes_cluster.test.id
+  command               = "kubectl get namespace default -o name"
+}
+`, KubernetesClusterResource{}.roleBasedAccessControlAADManagedConfig(data, ""))
+}
@@ -4,6 +4,9 @@
 package containers
 
 import (
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/sdk"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
 )
@@ -13,8 +16,9 @@ type Registration struct {
 }
 
 var (
-	_ sdk.TypedServiceRegistration   = Registration{}
-	_ sdk.UntypedServiceRegistration = Registration{}
+	_ sdk.TypedServiceRegistration          = Registration{}
+	_ sdk.UntypedServiceRegistration        = Registration{}
+	_ sdk.FrameworkTypedServiceRegistration = Registration{}
 )
 
 // Name is the name of this Service
@@ -72,10 +76,12 @@ func (r Registration) Resources() []sdk.Resource {
 	resources := []sdk.Resource{
 		ContainerConnectedRegistryResource{},
 		ContainerRegistryCacheRule{},
+		ContainerRegistryImageImportResource{},
 		ContainerRegistryTaskResource{},
 		ContainerRegistryTaskScheduleResource{},
 		ContainerRegistryTokenPasswordResource{},
 		KubernetesClusterExtensionResource{},
+		KubernetesClusterRunCommandResource{},
 		KubernetesFleetManagerResource{},
 		KubernetesFleetUpdateRunResource{},
 		KubernetesFleetUpdateStrategyResource{},
@@ -84,3 +90,17 @@ func (r Registration) Resources() []sdk.Resource {
 	resources = append(resources, r.autoRegistration.Resources()...)
 	return resources
 }
+
+func (r Registration) FrameworkResources() []func() resource.Resource {
+	return []func() resource.Resource{}
+}
+
+func (r Registration) FrameworkDataSources() []func() datasource.DataSource {
+	return []func() datasource.DataSource{}
+}
+
+func (r Registration) EphemeralResources() []func() ephemeral.EphemeralResource {
+	return []func() ephemeral.EphemeralResource{
+		NewKubernetesClusterCredentialsEphemeralResource,
+	}
+}
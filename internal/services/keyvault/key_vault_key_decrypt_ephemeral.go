@@ -0,0 +1,130 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package keyvault
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/hashicorp/go-azure-helpers/lang/pointer"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/sdk"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/sdk/frameworkhelpers"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/validation"
+	"github.com/jackofallops/kermit/sdk/keyvault/7.4/keyvault"
+)
+
+var _ sdk.EphemeralResource = &KeyVaultKeyDecryptEphemeralResource{}
+
+func NewKeyVaultKeyDecryptEphemeralResource() ephemeral.EphemeralResource {
+	return &KeyVaultKeyDecryptEphemeralResource{}
+}
+
+type KeyVaultKeyDecryptEphemeralResource struct {
+	sdk.EphemeralResourceMetadata
+}
+
+type KeyVaultKeyDecryptEphemeralResourceModel struct {
+	KeyVaultKeyId         types.String `tfsdk:"key_vault_key_id"`
+	ManagedHSMKeyId       types.String `tfsdk:"managed_hsm_key_id"`
+	Algorithm             types.String `tfsdk:"algorithm"`
+	EncryptedData         types.String `tfsdk:"encrypted_data"`
+	PlainTextValue        types.String `tfsdk:"plain_text_value"`
+	DecodedPlainTextValue types.String `tfsdk:"decoded_plain_text_value"`
+}
+
+func (e *KeyVaultKeyDecryptEphemeralResource) Metadata(_ context.Context, _ ephemeral.MetadataRequest, resp *ephemeral.MetadataResponse) {
+	resp.TypeName = "azurerm_key_vault_key_decrypt"
+}
+
+func (e *KeyVaultKeyDecryptEphemeralResource) Configure(_ context.Context, req ephemeral.ConfigureRequest, resp *ephemeral.ConfigureResponse) {
+	e.Defaults(req, resp)
+}
+
+func (e *KeyVaultKeyDecryptEphemeralResource) Schema(_ context.Context, _ ephemeral.SchemaRequest, resp *ephemeral.SchemaResponse) {
+	attributes := keyVaultKeyOperationKeyAttributes()
+
+	attributes["algorithm"] = schema.StringAttribute{
+		Required: true,
+		Validators: []validator.String{
+			frameworkhelpers.WrappedStringValidator{
+				Func: validation.StringInSlice([]string{
+					string(keyvault.JSONWebKeyEncryptionAlgorithmRSA15),
+					string(keyvault.JSONWebKeyEncryptionAlgorithmRSAOAEP),
+					string(keyvault.JSONWebKeyEncryptionAlgorithmRSAOAEP256),
+				}, false),
+			},
+		},
+	}
+
+	attributes["encrypted_data"] = schema.StringAttribute{
+		Required: true,
+		Validators: []validator.String{
+			frameworkhelpers.WrappedStringValidator{
+				Func: validation.StringIsNotEmpty,
+			},
+		},
+	}
+
+	attributes["plain_text_value"] = schema.StringAttribute{
+		Computed:  true,
+		Sensitive: true,
+	}
+
+	attributes["decoded_plain_text_value"] = schema.StringAttribute{
+		Computed:  true,
+		Sensitive: true,
+	}
+
+	resp.Schema = schema.Schema{
+		Attributes: attributes,
+	}
+}
+
+func (e *KeyVaultKeyDecryptEphemeralResource) Open(ctx context.Context, req ephemeral.OpenRequest, resp *ephemeral.OpenResponse) {
+	ctx, cancel := context.WithTimeout(ctx, time.Minute*5)
+	defer cancel()
+
+	var data KeyVaultKeyDecryptEphemeralResourceModel
+
+	if ok := e.DecodeOpen(ctx, req, resp, &data); !ok {
+		return
+	}
+
+	key, err := expandKeyVaultKeyOperationKey(e.Client, data.KeyVaultKeyId, data.ManagedHSMKeyId)
+	if err != nil {
+		sdk.SetResponseErrorDiagnostic(resp, "", err)
+		return
+	}
+
+	params := keyvault.KeyOperationsParameters{
+		Algorithm: keyvault.JSONWebKeyEncryptionAlgorithm(data.Algorithm.ValueString()),
+		Value:     pointer.To(data.EncryptedData.ValueString()),
+	}
+	result, err := key.Client.Decrypt(ctx, key.BaseUri, key.Name, key.Version, params)
+	if err != nil {
+		sdk.SetResponseErrorDiagnostic(resp, fmt.Sprintf("decrypting value using Key %q", key.ID), err)
+		return
+	}
+	if result.Result == nil {
+		sdk.SetResponseErrorDiagnostic(resp, fmt.Sprintf("decrypting value using Key %q", key.ID), "`result` was nil")
+		return
+	}
+
+	data.PlainTextValue = types.StringValue(*result.Result)
+	data.DecodedPlainTextValue = types.StringValue("")
+	if decodedResult, err := base64.RawURLEncoding.DecodeString(*result.Result); err == nil {
+		data.DecodedPlainTextValue = types.StringValue(string(decodedResult))
+	} else {
+		log.Printf("[WARN] Failed to decode plain-text value: %+v", err)
+	}
+
+	resp.Diagnostics.Append(resp.Result.Set(ctx, &data)...)
+}
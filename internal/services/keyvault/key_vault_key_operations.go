@@ -0,0 +1,108 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package keyvault
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/clients"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/customermanagedkeys"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/sdk/frameworkhelpers"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/services/keyvault/validate"
+	managedHsmValidate "github.com/hashicorp/terraform-provider-azurerm/internal/services/managedhsm/validate"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/validation"
+	"github.com/jackofallops/kermit/sdk/keyvault/7.4/keyvault"
+)
+
+// keyVaultKeyOperationKeyAttributes returns the attributes used to specify the Key Vault Key or Managed HSM Key which
+// a cryptographic operation should be performed with
+func keyVaultKeyOperationKeyAttributes() map[string]schema.Attribute {
+	return map[string]schema.Attribute{
+		"key_vault_key_id": schema.StringAttribute{
+			Optional: true,
+			Validators: []validator.String{
+				frameworkhelpers.WrappedStringValidator{
+					Func: validate.NestedItemIdWithOptionalVersion,
+				},
+				stringvalidator.ExactlyOneOf(path.MatchRoot("managed_hsm_key_id")),
+			},
+		},
+
+		"managed_hsm_key_id": schema.StringAttribute{
+			Optional: true,
+			Validators: []validator.String{
+				frameworkhelpers.WrappedStringValidator{
+					Func: validation.Any(
+						managedHsmValidate.ManagedHSMDataPlaneVersionedKeyID,
+						managedHsmValidate.ManagedHSMDataPlaneVersionlessKeyID,
+					),
+				},
+			},
+		},
+	}
+}
+
+// keyVaultKeyOperationKey is the Key Vault Key or Managed HSM Key a cryptographic operation is performed with
+type keyVaultKeyOperationKey struct {
+	Client  *keyvault.BaseClient
+	ID      string
+	BaseUri string
+	Name    string
+	Version string
+}
+
+// expandKeyVaultKeyOperationKey parses the Key Vault Key or Managed HSM Key ID, returning the Data Plane client and
+// details of the key - when no version is specified the operation is performed using the latest version of the key
+func expandKeyVaultKeyOperationKey(client *clients.Client, keyVaultKeyId types.String, managedHSMKeyId types.String) (*keyVaultKeyOperationKey, error) {
+	input := map[string]interface{}{
+		"key_vault_key_id":   keyVaultKeyId.ValueString(),
+		"managed_hsm_key_id": managedHSMKeyId.ValueString(),
+	}
+	key, err := customermanagedkeys.ExpandKeyVaultOrManagedHSMKey(input, customermanagedkeys.VersionTypeAny, client.Account.Environment.KeyVault, client.Account.Environment.ManagedHSM)
+	if err != nil {
+		return nil, err
+	}
+	if !key.IsSet() {
+		return nil, fmt.Errorf("one of `key_vault_key_id` or `managed_hsm_key_id` must be specified")
+	}
+
+	result := keyVaultKeyOperationKey{
+		ID:      key.ID(),
+		BaseUri: key.BaseUri(),
+	}
+
+	switch {
+	case key.KeyVaultKeyId != nil:
+		result.Client = client.KeyVault.ManagementClient
+		result.Name = key.KeyVaultKeyId.Name
+		result.Version = key.KeyVaultKeyId.Version
+	case key.ManagedHSMKeyId != nil:
+		result.Client = client.ManagedHSMs.DataPlaneKeysClient
+		result.Name = key.ManagedHSMKeyId.KeyName
+		result.Version = key.ManagedHSMKeyId.KeyVersion
+	case key.ManagedHSMKeyVersionlessId != nil:
+		result.Client = client.ManagedHSMs.DataPlaneKeysClient
+		result.Name = key.ManagedHSMKeyVersionlessId.KeyName
+	}
+
+	return &result, nil
+}
+
+// keyVaultKeySignatureAlgorithms returns the algorithms which can be used to sign and verify a digest
+func keyVaultKeySignatureAlgorithms() []string {
+	algorithms := make([]string, 0)
+	for _, v := range keyvault.PossibleJSONWebKeySignatureAlgorithmValues() {
+		// `RSNULL` is reserved and can't be used
+		if v == keyvault.JSONWebKeySignatureAlgorithmRSNULL {
+			continue
+		}
+		algorithms = append(algorithms, string(v))
+	}
+	return algorithms
+}
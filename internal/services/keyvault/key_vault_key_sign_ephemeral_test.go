@@ -0,0 +1,63 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package keyvault_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/go-version"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
+	"github.com/hashicorp/terraform-plugin-testing/statecheck"
+	"github.com/hashicorp/terraform-plugin-testing/tfjsonpath"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/acceptance"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/provider/framework"
+)
+
+// keyVaultKeyTestDigest is the base64url encoded SHA-256 digest of `hello world`
+const keyVaultKeyTestDigest = "uU0nuZNNPgilLlLX2n2r-sSE7-N6U4DukIj3rOLvzek"
+
+type KeyVaultKeySignEphemeral struct{}
+
+func TestAccEphemeralKeyVaultKeySign_basic(t *testing.T) {
+	data := acceptance.BuildTestData(t, "ephemeral.azurerm_key_vault_key_sign", "test")
+	r := KeyVaultKeySignEphemeral{}
+
+	resource.Test(t, resource.TestCase{
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(version.Must(version.NewVersion("1.10.0-rc1"))),
+		},
+		ProtoV5ProviderFactories: framework.ProtoV5ProviderFactoriesInit(context.Background(), "azurerm"),
+		ProtoV6ProviderFactories: framework.ProtoV6ProviderFactoriesInit(context.Background(), "azurerm", "echo"),
+		Steps: []resource.TestStep{
+			{
+				Config: r.basic(data),
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue("echo.test", tfjsonpath.New("data").AtMapKey("signature"), knownvalue.NotNull()),
+				},
+			},
+		},
+	})
+}
+
+func (KeyVaultKeySignEphemeral) basic(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+%s
+
+ephemeral "azurerm_key_vault_key_sign" "test" {
+  key_vault_key_id = azurerm_key_vault_key.test.id
+  algorithm        = "RS256"
+  digest           = "%s"
+}
+
+provider "echo" {
+  data = ephemeral.azurerm_key_vault_key_sign.test
+}
+
+resource "echo" "test" {}
+`, KeyVaultKeyDecryptEphemeral{}.template(data), keyVaultKeyTestDigest)
+}
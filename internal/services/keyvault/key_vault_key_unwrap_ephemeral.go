@@ -0,0 +1,120 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package keyvault
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/go-azure-helpers/lang/pointer"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/sdk"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/sdk/frameworkhelpers"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/validation"
+	"github.com/jackofallops/kermit/sdk/keyvault/7.4/keyvault"
+)
+
+var _ sdk.EphemeralResource = &KeyVaultKeyUnwrapEphemeralResource{}
+
+func NewKeyVaultKeyUnwrapEphemeralResource() ephemeral.EphemeralResource {
+	return &KeyVaultKeyUnwrapEphemeralResource{}
+}
+
+type KeyVaultKeyUnwrapEphemeralResource struct {
+	sdk.EphemeralResourceMetadata
+}
+
+type KeyVaultKeyUnwrapEphemeralResourceModel struct {
+	KeyVaultKeyId   types.String `tfsdk:"key_vault_key_id"`
+	ManagedHSMKeyId types.String `tfsdk:"managed_hsm_key_id"`
+	Algorithm       types.String `tfsdk:"algorithm"`
+	WrappedKey      types.String `tfsdk:"wrapped_key"`
+	UnwrappedKey    types.String `tfsdk:"unwrapped_key"`
+}
+
+func (e *KeyVaultKeyUnwrapEphemeralResource) Metadata(_ context.Context, _ ephemeral.MetadataRequest, resp *ephemeral.MetadataResponse) {
+	resp.TypeName = "azurerm_key_vault_key_unwrap"
+}
+
+func (e *KeyVaultKeyUnwrapEphemeralResource) Configure(_ context.Context, req ephemeral.ConfigureRequest, resp *ephemeral.ConfigureResponse) {
+	e.Defaults(req, resp)
+}
+
+func (e *KeyVaultKeyUnwrapEphemeralResource) Schema(_ context.Context, _ ephemeral.SchemaRequest, resp *ephemeral.SchemaResponse) {
+	attributes := keyVaultKeyOperationKeyAttributes()
+
+	attributes["algorithm"] = schema.StringAttribute{
+		Required: true,
+		Validators: []validator.String{
+			frameworkhelpers.WrappedStringValidator{
+				Func: validation.StringInSlice([]string{
+					string(keyvault.JSONWebKeyEncryptionAlgorithmRSA15),
+					string(keyvault.JSONWebKeyEncryptionAlgorithmRSAOAEP),
+					string(keyvault.JSONWebKeyEncryptionAlgorithmRSAOAEP256),
+					// the AES Key Wrap algorithms are only supported by Managed HSM Keys
+					string(keyvault.JSONWebKeyEncryptionAlgorithmA128KW),
+					string(keyvault.JSONWebKeyEncryptionAlgorithmA192KW),
+					string(keyvault.JSONWebKeyEncryptionAlgorithmA256KW),
+				}, false),
+			},
+		},
+	}
+
+	attributes["wrapped_key"] = schema.StringAttribute{
+		Required: true,
+		Validators: []validator.String{
+			frameworkhelpers.WrappedStringValidator{
+				Func: validation.StringIsNotEmpty,
+			},
+		},
+	}
+
+	attributes["unwrapped_key"] = schema.StringAttribute{
+		Computed:  true,
+		Sensitive: true,
+	}
+
+	resp.Schema = schema.Schema{
+		Attributes: attributes,
+	}
+}
+
+func (e *KeyVaultKeyUnwrapEphemeralResource) Open(ctx context.Context, req ephemeral.OpenRequest, resp *ephemeral.OpenResponse) {
+	ctx, cancel := context.WithTimeout(ctx, time.Minute*5)
+	defer cancel()
+
+	var data KeyVaultKeyUnwrapEphemeralResourceModel
+
+	if ok := e.DecodeOpen(ctx, req, resp, &data); !ok {
+		return
+	}
+
+	key, err := expandKeyVaultKeyOperationKey(e.Client, data.KeyVaultKeyId, data.ManagedHSMKeyId)
+	if err != nil {
+		sdk.SetResponseErrorDiagnostic(resp, "", err)
+		return
+	}
+
+	params := keyvault.KeyOperationsParameters{
+		Algorithm: keyvault.JSONWebKeyEncryptionAlgorithm(data.Algorithm.ValueString()),
+		Value:     pointer.To(data.WrappedKey.ValueString()),
+	}
+	result, err := key.Client.UnwrapKey(ctx, key.BaseUri, key.Name, key.Version, params)
+	if err != nil {
+		sdk.SetResponseErrorDiagnostic(resp, fmt.Sprintf("unwrapping key using Key %q", key.ID), err)
+		return
+	}
+	if result.Result == nil {
+		sdk.SetResponseErrorDiagnostic(resp, fmt.Sprintf("unwrapping key using Key %q", key.ID), "`result` was nil")
+		return
+	}
+
+	data.UnwrappedKey = types.StringValue(*result.Result)
+
+	resp.Diagnostics.Append(resp.Result.Set(ctx, &data)...)
+}
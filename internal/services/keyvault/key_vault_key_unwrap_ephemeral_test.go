@@ -0,0 +1,68 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package keyvault_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/go-version"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
+	"github.com/hashicorp/terraform-plugin-testing/statecheck"
+	"github.com/hashicorp/terraform-plugin-testing/tfjsonpath"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/acceptance"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/provider/framework"
+)
+
+type KeyVaultKeyUnwrapEphemeral struct{}
+
+func TestAccEphemeralKeyVaultKeyUnwrap_basic(t *testing.T) {
+	data := acceptance.BuildTestData(t, "ephemeral.azurerm_key_vault_key_unwrap", "test")
+	r := KeyVaultKeyUnwrapEphemeral{}
+
+	resource.Test(t, resource.TestCase{
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(version.Must(version.NewVersion("1.10.0-rc1"))),
+		},
+		ProtoV5ProviderFactories: framework.ProtoV5ProviderFactoriesInit(context.Background(), "azurerm"),
+		ProtoV6ProviderFactories: framework.ProtoV6ProviderFactoriesInit(context.Background(), "azurerm", "echo"),
+		Steps: []resource.TestStep{
+			{
+				Config: r.basic(data),
+				ConfigStateChecks: []statecheck.StateCheck{
+					// the unwrapped key is returned base64url encoded without padding
+					statecheck.ExpectKnownValue("echo.test", tfjsonpath.New("data").AtMapKey("unwrapped_key"), knownvalue.StringExact("cmljay1hbmQtbW9ydHk")),
+				},
+			},
+		},
+	})
+}
+
+func (KeyVaultKeyUnwrapEphemeral) basic(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+%s
+
+# wrapping a key using an RSA key is equivalent to encrypting it
+data "azurerm_key_vault_encrypted_value" "test" {
+  key_vault_key_id = azurerm_key_vault_key.test.id
+  algorithm        = "RSA-OAEP-256"
+  plain_text_value = "cmljay1hbmQtbW9ydHk"
+}
+
+ephemeral "azurerm_key_vault_key_unwrap" "test" {
+  key_vault_key_id = azurerm_key_vault_key.test.id
+  algorithm        = "RSA-OAEP-256"
+  wrapped_key      = data.azurerm_key_vault_encrypted_value.test.encrypted_data
+}
+
+provider "echo" {
+  data = ephemeral.azurerm_key_vault_key_unwrap.test
+}
+
+resource "echo" "test" {}
+`, KeyVaultKeyDecryptEphemeral{}.template(data))
+}
@@ -0,0 +1,118 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package keyvault
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/go-azure-helpers/lang/pointer"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/sdk"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/sdk/frameworkhelpers"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/validation"
+	"github.com/jackofallops/kermit/sdk/keyvault/7.4/keyvault"
+)
+
+var _ sdk.EphemeralResource = &KeyVaultKeyVerifyEphemeralResource{}
+
+func NewKeyVaultKeyVerifyEphemeralResource() ephemeral.EphemeralResource {
+	return &KeyVaultKeyVerifyEphemeralResource{}
+}
+
+type KeyVaultKeyVerifyEphemeralResource struct {
+	sdk.EphemeralResourceMetadata
+}
+
+type KeyVaultKeyVerifyEphemeralResourceModel struct {
+	KeyVaultKeyId   types.String `tfsdk:"key_vault_key_id"`
+	ManagedHSMKeyId types.String `tfsdk:"managed_hsm_key_id"`
+	Algorithm       types.String `tfsdk:"algorithm"`
+	Digest          types.String `tfsdk:"digest"`
+	Signature       types.String `tfsdk:"signature"`
+	IsValid         types.Bool   `tfsdk:"is_valid"`
+}
+
+func (e *KeyVaultKeyVerifyEphemeralResource) Metadata(_ context.Context, _ ephemeral.MetadataRequest, resp *ephemeral.MetadataResponse) {
+	resp.TypeName = "azurerm_key_vault_key_verify"
+}
+
+func (e *KeyVaultKeyVerifyEphemeralResource) Configure(_ context.Context, req ephemeral.ConfigureRequest, resp *ephemeral.ConfigureResponse) {
+	e.Defaults(req, resp)
+}
+
+func (e *KeyVaultKeyVerifyEphemeralResource) Schema(_ context.Context, _ ephemeral.SchemaRequest, resp *ephemeral.SchemaResponse) {
+	attributes := keyVaultKeyOperationKeyAttributes()
+
+	attributes["algorithm"] = schema.StringAttribute{
+		Required: true,
+		Validators: []validator.String{
+			frameworkhelpers.WrappedStringValidator{
+				Func: validation.StringInSlice(keyVaultKeySignatureAlgorithms(), false),
+			},
+		},
+	}
+
+	attributes["digest"] = schema.StringAttribute{
+		Required: true,
+		Validators: []validator.String{
+			frameworkhelpers.WrappedStringValidator{
+				Func: validation.StringIsNotEmpty,
+			},
+		},
+	}
+
+	attributes["signature"] = schema.StringAttribute{
+		Required: true,
+		Validators: []validator.String{
+			frameworkhelpers.WrappedStringValidator{
+				Func: validation.StringIsNotEmpty,
+			},
+		},
+	}
+
+	attributes["is_valid"] = schema.BoolAttribute{
+		Computed: true,
+	}
+
+	resp.Schema = schema.Schema{
+		Attributes: attributes,
+	}
+}
+
+func (e *KeyVaultKeyVerifyEphemeralResource) Open(ctx context.Context, req ephemeral.OpenRequest, resp *ephemeral.OpenResponse) {
+	ctx, cancel := context.WithTimeout(ctx, time.Minute*5)
+	defer cancel()
+
+	var data KeyVaultKeyVerifyEphemeralResourceModel
+
+	if ok := e.DecodeOpen(ctx, req, resp, &data); !ok {
+		return
+	}
+
+	key, err := expandKeyVaultKeyOperationKey(e.Client, data.KeyVaultKeyId, data.ManagedHSMKeyId)
+	if err != nil {
+		sdk.SetResponseErrorDiagnostic(resp, "", err)
+		return
+	}
+
+	params := keyvault.KeyVerifyParameters{
+		Algorithm: keyvault.JSONWebKeySignatureAlgorithm(data.Algorithm.ValueString()),
+		Digest:    pointer.To(data.Digest.ValueString()),
+		Signature: pointer.To(data.Signature.ValueString()),
+	}
+	result, err := key.Client.Verify(ctx, key.BaseUri, key.Name, key.Version, params)
+	if err != nil {
+		sdk.SetResponseErrorDiagnostic(resp, fmt.Sprintf("verifying signature using Key %q", key.ID), err)
+		return
+	}
+
+	data.IsValid = types.BoolValue(pointer.From(result.Value))
+
+	resp.Diagnostics.Append(resp.Result.Set(ctx, &data)...)
+}
@@ -0,0 +1,97 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package keyvault_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/go-version"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
+	"github.com/hashicorp/terraform-plugin-testing/statecheck"
+	"github.com/hashicorp/terraform-plugin-testing/tfjsonpath"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/acceptance"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/provider/framework"
+)
+
+type KeyVaultKeyVerifyEphemeral struct{}
+
+func TestAccEphemeralKeyVaultKeyVerify_basic(t *testing.T) {
+	data := acceptance.BuildTestData(t, "ephemeral.azurerm_key_vault_key_verify", "test")
+	r := KeyVaultKeyVerifyEphemeral{}
+
+	resource.Test(t, resource.TestCase{
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(version.Must(version.NewVersion("1.10.0-rc1"))),
+		},
+		ProtoV5ProviderFactories: framework.ProtoV5ProviderFactoriesInit(context.Background(), "azurerm"),
+		ProtoV6ProviderFactories: framework.ProtoV6ProviderFactoriesInit(context.Background(), "azurerm", "echo"),
+		Steps: []resource.TestStep{
+			{
+				Config: r.basic(data),
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue("echo.test", tfjsonpath.New("data").AtMapKey("is_valid"), knownvalue.Bool(true)),
+				},
+			},
+		},
+	})
+}
+
+func TestAccEphemeralKeyVaultKeyVerify_invalidSignature(t *testing.T) {
+	data := acceptance.BuildTestData(t, "ephemeral.azurerm_key_vault_key_verify", "test")
+	r := KeyVaultKeyVerifyEphemeral{}
+
+	resource.Test(t, resource.TestCase{
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(version.Must(version.NewVersion("1.10.0-rc1"))),
+		},
+		ProtoV5ProviderFactories: framework.ProtoV5ProviderFactoriesInit(context.Background(), "azurerm"),
+		ProtoV6ProviderFactories: framework.ProtoV6ProviderFactoriesInit(context.Background(), "azurerm", "echo"),
+		Steps: []resource.TestStep{
+			{
+				Config: r.invalidSignature(data),
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue("echo.test", tfjsonpath.New("data").AtMapKey("is_valid"), knownvalue.Bool(false)),
+				},
+			},
+		},
+	})
+}
+
+func (KeyVaultKeyVerifyEphemeral) basic(data acceptance.TestData) string {
+	return KeyVaultKeyVerifyEphemeral{}.config(data, "RS256")
+}
+
+func (KeyVaultKeyVerifyEphemeral) invalidSignature(data acceptance.TestData) string {
+	// a signature created using `RS256` isn't valid for `PS256`
+	return KeyVaultKeyVerifyEphemeral{}.config(data, "PS256")
+}
+
+func (KeyVaultKeyVerifyEphemeral) config(data acceptance.TestData, verifyAlgorithm string) string {
+	return fmt.Sprintf(`
+%s
+
+ephemeral "azurerm_key_vault_key_sign" "test" {
+  key_vault_key_id = azurerm_key_vault_key.test.id
+  algorithm        = "RS256"
+  digest           = "%s"
+}
+
+ephemeral "azurerm_key_vault_key_verify" "test" {
+  key_vault_key_id = azurerm_key_vault_key.test.id
+  algorithm        = "%s"
+  digest           = ephemeral.azurerm_key_vault_key_sign.test.digest
+  signature        = ephemeral.azurerm_key_vault_key_sign.test.signature
+}
+
+provider "echo" {
+  data = ephemeral.azurerm_key_vault_key_verify.test
+}
+
+resource "echo" "test" {}
+`, KeyVaultKeyDecryptEphemeral{}.template(data), keyVaultKeyTestDigest, verifyAlgorithm)
+}
@@ -87,6 +87,10 @@ func (r Registration) FrameworkDataSources() []func() datasource.DataSource {
 func (r Registration) EphemeralResources() []func() ephemeral.EphemeralResource {
 	return []func() ephemeral.EphemeralResource{
 		NewKeyVaultCertificateEphemeralResource,
+		NewKeyVaultKeyDecryptEphemeralResource,
+		NewKeyVaultKeySignEphemeralResource,
+		NewKeyVaultKeyUnwrapEphemeralResource,
+		NewKeyVaultKeyVerifyEphemeralResource,
 		NewKeyVaultSecretEphemeralResource,
 	}
 }
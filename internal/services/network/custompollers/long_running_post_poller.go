@@ -0,0 +1,74 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package custompollers
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/hashicorp/go-azure-sdk/sdk/client"
+	"github.com/hashicorp/go-azure-sdk/sdk/client/pollers"
+	"github.com/hashicorp/go-azure-sdk/sdk/client/resourcemanager"
+)
+
+var _ pollers.PollerType = &longRunningPostPoller{}
+
+// longRunningPostPoller polls the `Location` returned from a long-running POST operation which returns a result once it
+// completes (such as the effective routes for a Network Interface, or the next hop from Network Watcher) - rather than
+// a `status`/`provisioningState` - which the default long-running-operation poller fails to handle, as such the result
+// can be retrieved from the latest response of the Poller.
+type longRunningPostPoller struct {
+	client     *resourcemanager.Client
+	pollingUrl *url.URL
+}
+
+func NewLongRunningPostPoller(client *resourcemanager.Client, pollingUrl *url.URL) *longRunningPostPoller {
+	return &longRunningPostPoller{
+		client:     client,
+		pollingUrl: pollingUrl,
+	}
+}
+
+func (p longRunningPostPoller) Poll(ctx context.Context) (*pollers.PollResult, error) {
+	opts := client.RequestOptions{
+		ContentType: "application/json; charset=utf-8",
+		ExpectedStatusCodes: []int{
+			http.StatusAccepted,
+			http.StatusOK,
+		},
+		HttpMethod: http.MethodGet,
+		Path:       p.pollingUrl.Path,
+	}
+
+	req, err := p.client.NewRequest(ctx, opts)
+	if err != nil {
+		return nil, fmt.Errorf("building request: %+v", err)
+	}
+	req.URL.RawQuery = p.pollingUrl.RawQuery
+
+	resp, err := req.Execute(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("polling %q: %+v", p.pollingUrl.String(), err)
+	}
+
+	result := pollers.PollResult{
+		HttpResponse: resp,
+		PollInterval: 10 * time.Second,
+		Status:       pollers.PollingStatusSucceeded,
+	}
+	if resp.StatusCode == http.StatusAccepted {
+		result.Status = pollers.PollingStatusInProgress
+		if v := resp.Header.Get("Retry-After"); v != "" {
+			if seconds, err := strconv.Atoi(v); err == nil {
+				result.PollInterval = time.Duration(seconds) * time.Second
+			}
+		}
+	}
+
+	return &result, nil
+}
@@ -0,0 +1,77 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package network
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/hashicorp/go-azure-sdk/sdk/client"
+	"github.com/hashicorp/go-azure-sdk/sdk/client/pollers"
+	"github.com/hashicorp/go-azure-sdk/sdk/client/resourcemanager"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/services/network/custompollers"
+)
+
+// performLongRunningPostWithResult performs the long-running POST operation at path (sending input as the body when
+// specified), unmarshalling the result returned once it completes into model.
+//
+// NOTE: the SDK methods for these operations can't be used, since the LRO poller expects the final response to contain
+// a `status` and doesn't return the result - so we poll the `Location` ourselves using a custom poller.
+func performLongRunningPostWithResult(ctx context.Context, c *resourcemanager.Client, path string, input interface{}, model interface{}) error {
+	opts := client.RequestOptions{
+		ContentType: "application/json; charset=utf-8",
+		ExpectedStatusCodes: []int{
+			http.StatusAccepted,
+			http.StatusOK,
+		},
+		HttpMethod: http.MethodPost,
+		Path:       path,
+	}
+
+	req, err := c.NewRequest(ctx, opts)
+	if err != nil {
+		return fmt.Errorf("building request: %+v", err)
+	}
+	if input != nil {
+		if err := req.Marshal(input); err != nil {
+			return fmt.Errorf("marshalling request: %+v", err)
+		}
+	}
+
+	resp, err := req.Execute(ctx)
+	if err != nil {
+		return err
+	}
+
+	if resp.StatusCode == http.StatusAccepted {
+		location := resp.Header.Get("Location")
+		if location == "" {
+			return fmt.Errorf("no `Location` header was returned to poll")
+		}
+		pollingUrl, err := url.Parse(location)
+		if err != nil {
+			return fmt.Errorf("parsing the polling URL %q: %+v", location, err)
+		}
+
+		pollerType := custompollers.NewLongRunningPostPoller(c, pollingUrl)
+		poller := pollers.NewPoller(pollerType, 10*time.Second, pollers.DefaultNumberOfDroppedConnectionsToAllow)
+		if err := poller.PollUntilDone(ctx); err != nil {
+			return fmt.Errorf("polling: %+v", err)
+		}
+
+		resp = poller.LatestResponse()
+		if resp == nil {
+			return fmt.Errorf("no response was returned once polling completed")
+		}
+	}
+
+	if err := resp.Unmarshal(model); err != nil {
+		return fmt.Errorf("unmarshalling the response: %+v", err)
+	}
+
+	return nil
+}
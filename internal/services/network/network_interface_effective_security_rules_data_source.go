@@ -0,0 +1,232 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package network
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/go-azure-helpers/lang/pointer"
+	"github.com/hashicorp/go-azure-helpers/resourcemanager/commonids"
+	"github.com/hashicorp/go-azure-sdk/resource-manager/network/2024-03-01/networkinterfaces"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/sdk"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
+)
+
+var _ sdk.DataSource = NetworkInterfaceEffectiveSecurityRulesDataSource{}
+
+type NetworkInterfaceEffectiveSecurityRulesDataSource struct{}
+
+type NetworkInterfaceEffectiveSecurityRulesDataSourceModel struct {
+	NetworkInterfaceId    string                                   `tfschema:"network_interface_id"`
+	NetworkSecurityGroups []NetworkInterfaceEffectiveSecurityGroup `tfschema:"network_security_group"`
+}
+
+type NetworkInterfaceEffectiveSecurityGroup struct {
+	Id                           string                                  `tfschema:"id"`
+	AssociatedNetworkInterfaceId string                                  `tfschema:"associated_network_interface_id"`
+	AssociatedSubnetId           string                                  `tfschema:"associated_subnet_id"`
+	SecurityRules                []NetworkInterfaceEffectiveSecurityRule `tfschema:"security_rule"`
+}
+
+type NetworkInterfaceEffectiveSecurityRule struct {
+	Name                               string   `tfschema:"name"`
+	Access                             string   `tfschema:"access"`
+	Direction                          string   `tfschema:"direction"`
+	Priority                           int64    `tfschema:"priority"`
+	Protocol                           string   `tfschema:"protocol"`
+	SourceAddressPrefixes              []string `tfschema:"source_address_prefixes"`
+	SourcePortRanges                   []string `tfschema:"source_port_ranges"`
+	DestinationAddressPrefixes         []string `tfschema:"destination_address_prefixes"`
+	DestinationPortRanges              []string `tfschema:"destination_port_ranges"`
+	ExpandedSourceAddressPrefixes      []string `tfschema:"expanded_source_address_prefixes"`
+	ExpandedDestinationAddressPrefixes []string `tfschema:"expanded_destination_address_prefixes"`
+}
+
+func (NetworkInterfaceEffectiveSecurityRulesDataSource) Arguments() map[string]*pluginsdk.Schema {
+	return map[string]*pluginsdk.Schema{
+		"network_interface_id": {
+			Type:         pluginsdk.TypeString,
+			Required:     true,
+			ValidateFunc: commonids.ValidateNetworkInterfaceID,
+		},
+	}
+}
+
+func (NetworkInterfaceEffectiveSecurityRulesDataSource) Attributes() map[string]*pluginsdk.Schema {
+	stringList := func() *pluginsdk.Schema {
+		return &pluginsdk.Schema{
+			Type:     pluginsdk.TypeList,
+			Computed: true,
+			Elem: &pluginsdk.Schema{
+				Type: pluginsdk.TypeString,
+			},
+		}
+	}
+
+	return map[string]*pluginsdk.Schema{
+		"network_security_group": {
+			Type:     pluginsdk.TypeList,
+			Computed: true,
+			Elem: &pluginsdk.Resource{
+				Schema: map[string]*pluginsdk.Schema{
+					"id": {
+						Type:     pluginsdk.TypeString,
+						Computed: true,
+					},
+
+					"associated_network_interface_id": {
+						Type:     pluginsdk.TypeString,
+						Computed: true,
+					},
+
+					"associated_subnet_id": {
+						Type:     pluginsdk.TypeString,
+						Computed: true,
+					},
+
+					"security_rule": {
+						Type:     pluginsdk.TypeList,
+						Computed: true,
+						Elem: &pluginsdk.Resource{
+							Schema: map[string]*pluginsdk.Schema{
+								"name": {
+									Type:     pluginsdk.TypeString,
+									Computed: true,
+								},
+
+								"access": {
+									Type:     pluginsdk.TypeString,
+									Computed: true,
+								},
+
+								"direction": {
+									Type:     pluginsdk.TypeString,
+									Computed: true,
+								},
+
+								"priority": {
+									Type:     pluginsdk.TypeInt,
+									Computed: true,
+								},
+
+								"protocol": {
+									Type:     pluginsdk.TypeString,
+									Computed: true,
+								},
+
+								"source_address_prefixes": stringList(),
+
+								"source_port_ranges": stringList(),
+
+								"destination_address_prefixes": stringList(),
+
+								"destination_port_ranges": stringList(),
+
+								"expanded_source_address_prefixes": stringList(),
+
+								"expanded_destination_address_prefixes": stringList(),
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (NetworkInterfaceEffectiveSecurityRulesDataSource) ModelObject() interface{} {
+	return &NetworkInterfaceEffectiveSecurityRulesDataSourceModel{}
+}
+
+func (NetworkInterfaceEffectiveSecurityRulesDataSource) ResourceType() string {
+	return "azurerm_network_interface_effective_security_rules"
+}
+
+func (NetworkInterfaceEffectiveSecurityRulesDataSource) Read() sdk.ResourceFunc {
+	return sdk.ResourceFunc{
+		Timeout: 30 * time.Minute,
+		Func: func(ctx context.Context, metadata sdk.ResourceMetaData) error {
+			client := metadata.Client.Network.NetworkInterfaces
+
+			var state NetworkInterfaceEffectiveSecurityRulesDataSourceModel
+			if err := metadata.Decode(&state); err != nil {
+				return fmt.Errorf("decoding: %+v", err)
+			}
+
+			id, err := commonids.ParseNetworkInterfaceID(state.NetworkInterfaceId)
+			if err != nil {
+				return err
+			}
+
+			groups := make([]networkinterfaces.EffectiveNetworkSecurityGroup, 0)
+			if err := retrieveNetworkInterfaceEffectiveValues(ctx, client, *id, "effectiveNetworkSecurityGroups", &groups); err != nil {
+				return fmt.Errorf("retrieving the effective network security groups for %s: %+v", id, err)
+			}
+
+			metadata.SetID(id)
+
+			state.NetworkInterfaceId = id.ID()
+			state.NetworkSecurityGroups = flattenNetworkInterfaceEffectiveSecurityGroups(groups)
+
+			return metadata.Encode(&state)
+		},
+	}
+}
+
+func flattenNetworkInterfaceEffectiveSecurityGroups(input []networkinterfaces.EffectiveNetworkSecurityGroup) []NetworkInterfaceEffectiveSecurityGroup {
+	output := make([]NetworkInterfaceEffectiveSecurityGroup, 0, len(input))
+	for _, v := range input {
+		group := NetworkInterfaceEffectiveSecurityGroup{
+			SecurityRules: make([]NetworkInterfaceEffectiveSecurityRule, 0),
+		}
+
+		if v.NetworkSecurityGroup != nil {
+			group.Id = pointer.From(v.NetworkSecurityGroup.Id)
+		}
+		if association := v.Association; association != nil {
+			if association.NetworkInterface != nil {
+				group.AssociatedNetworkInterfaceId = pointer.From(association.NetworkInterface.Id)
+			}
+			if association.Subnet != nil {
+				group.AssociatedSubnetId = pointer.From(association.Subnet.Id)
+			}
+		}
+
+		if v.EffectiveSecurityRules != nil {
+			for _, rule := range *v.EffectiveSecurityRules {
+				group.SecurityRules = append(group.SecurityRules, NetworkInterfaceEffectiveSecurityRule{
+					Name:                               pointer.From(rule.Name),
+					Access:                             string(pointer.From(rule.Access)),
+					Direction:                          string(pointer.From(rule.Direction)),
+					Priority:                           pointer.From(rule.Priority),
+					Protocol:                           string(pointer.From(rule.Protocol)),
+					SourceAddressPrefixes:              combineEffectiveSecurityRuleValues(rule.SourceAddressPrefix, rule.SourceAddressPrefixes),
+					SourcePortRanges:                   combineEffectiveSecurityRuleValues(rule.SourcePortRange, rule.SourcePortRanges),
+					DestinationAddressPrefixes:         combineEffectiveSecurityRuleValues(rule.DestinationAddressPrefix, rule.DestinationAddressPrefixes),
+					DestinationPortRanges:              combineEffectiveSecurityRuleValues(rule.DestinationPortRange, rule.DestinationPortRanges),
+					ExpandedSourceAddressPrefixes:      pointer.From(rule.ExpandedSourceAddressPrefix),
+					ExpandedDestinationAddressPrefixes: pointer.From(rule.ExpandedDestinationAddressPrefix),
+				})
+			}
+		}
+
+		output = append(output, group)
+	}
+	return output
+}
+
+// combineEffectiveSecurityRuleValues combines the singular and plural forms of a value (e.g. `sourcePortRange` and
+// `sourcePortRanges`) returned for an effective security rule, only one of which is typically populated
+func combineEffectiveSecurityRuleValues(single *string, multiple *[]string) []string {
+	output := make([]string, 0)
+	if single != nil && *single != "" {
+		output = append(output, *single)
+	}
+	if multiple != nil {
+		output = append(output, *multiple...)
+	}
+	return output
+}
@@ -0,0 +1,44 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package network_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-provider-azurerm/internal/acceptance"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/acceptance/check"
+)
+
+type NetworkInterfaceEffectiveSecurityRulesDataSource struct{}
+
+func TestAccDataSourceNetworkInterfaceEffectiveSecurityRules_basic(t *testing.T) {
+	data := acceptance.BuildTestData(t, "data.azurerm_network_interface_effective_security_rules", "test")
+	r := NetworkInterfaceEffectiveSecurityRulesDataSource{}
+
+	data.DataSourceTest(t, []acceptance.TestStep{
+		{
+			Config: r.basic(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).Key("network_security_group.#").HasValue("1"),
+				check.That(data.ResourceName).Key("network_security_group.0.id").Exists(),
+				check.That(data.ResourceName).Key("network_security_group.0.associated_subnet_id").Exists(),
+				check.That(data.ResourceName).Key("network_security_group.0.security_rule.#").Exists(),
+				check.That(data.ResourceName).Key("network_security_group.0.security_rule.0.access").Exists(),
+			),
+		},
+	})
+}
+
+func (NetworkInterfaceEffectiveSecurityRulesDataSource) basic(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+%s
+
+data "azurerm_network_interface_effective_security_rules" "test" {
+  network_interface_id = azurerm_network_interface.test.id
+
+  depends_on = [azurerm_linux_virtual_machine.test]
+}
+`, NetworkInterfaceEffectiveRoutesDataSource{}.template(data))
+}
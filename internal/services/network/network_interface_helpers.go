@@ -4,6 +4,10 @@
 package network
 
 import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/go-azure-helpers/resourcemanager/commonids"
 	"github.com/hashicorp/go-azure-sdk/resource-manager/network/2024-03-01/networkinterfaces"
 )
 
@@ -45,3 +49,15 @@ func updateNetworkInterfaceIPConfiguration(config networkinterfaces.NetworkInter
 
 	return &output
 }
+
+// retrieveNetworkInterfaceEffectiveValues performs the long-running POST operation (e.g. `effectiveRouteTable`) against
+// the Network Interface, unmarshalling the `value` returned once it completes into model.
+func retrieveNetworkInterfaceEffectiveValues(ctx context.Context, nicClient *networkinterfaces.NetworkInterfacesClient, id commonids.NetworkInterfaceId, operation string, model interface{}) error {
+	result := struct {
+		Value interface{} `json:"value"`
+	}{
+		Value: model,
+	}
+
+	return performLongRunningPostWithResult(ctx, nicClient.Client, fmt.Sprintf("%s/%s", id.ID(), operation), nil, &result)
+}
@@ -0,0 +1,300 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package network
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/go-azure-helpers/lang/pointer"
+	"github.com/hashicorp/go-azure-sdk/resource-manager/network/2024-03-01/networkwatchers"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/sdk"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/validation"
+)
+
+var _ sdk.DataSource = NetworkWatcherConnectivityDataSource{}
+
+type NetworkWatcherConnectivityDataSource struct{}
+
+type NetworkWatcherConnectivityDataSourceModel struct {
+	NetworkWatcherId      string                          `tfschema:"network_watcher_id"`
+	SourceResourceId      string                          `tfschema:"source_resource_id"`
+	SourcePort            int64                           `tfschema:"source_port"`
+	DestinationAddress    string                          `tfschema:"destination_address"`
+	DestinationResourceId string                          `tfschema:"destination_resource_id"`
+	DestinationPort       int64                           `tfschema:"destination_port"`
+	Protocol              string                          `tfschema:"protocol"`
+	PreferredIPVersion    string                          `tfschema:"preferred_ip_version"`
+	ConnectionStatus      string                          `tfschema:"connection_status"`
+	AverageLatencyInMs    int64                           `tfschema:"average_latency_in_ms"`
+	MinimumLatencyInMs    int64                           `tfschema:"minimum_latency_in_ms"`
+	MaximumLatencyInMs    int64                           `tfschema:"maximum_latency_in_ms"`
+	ProbesSent            int64                           `tfschema:"probes_sent"`
+	ProbesFailed          int64                           `tfschema:"probes_failed"`
+	Hops                  []NetworkWatcherConnectivityHop `tfschema:"hop"`
+}
+
+type NetworkWatcherConnectivityHop struct {
+	Id         string                            `tfschema:"id"`
+	Type       string                            `tfschema:"type"`
+	Address    string                            `tfschema:"address"`
+	ResourceId string                            `tfschema:"resource_id"`
+	NextHopIds []string                          `tfschema:"next_hop_ids"`
+	Issues     []NetworkWatcherConnectivityIssue `tfschema:"issue"`
+}
+
+type NetworkWatcherConnectivityIssue struct {
+	Origin   string `tfschema:"origin"`
+	Severity string `tfschema:"severity"`
+	Type     string `tfschema:"type"`
+}
+
+func (NetworkWatcherConnectivityDataSource) Arguments() map[string]*pluginsdk.Schema {
+	return map[string]*pluginsdk.Schema{
+		"network_watcher_id": {
+			Type:         pluginsdk.TypeString,
+			Required:     true,
+			ValidateFunc: networkwatchers.ValidateNetworkWatcherID,
+		},
+
+		"source_resource_id": {
+			Type:         pluginsdk.TypeString,
+			Required:     true,
+			ValidateFunc: validation.StringIsNotEmpty,
+		},
+
+		"source_port": {
+			Type:         pluginsdk.TypeInt,
+			Optional:     true,
+			ValidateFunc: validation.IsPortNumber,
+		},
+
+		"destination_address": {
+			Type:         pluginsdk.TypeString,
+			Optional:     true,
+			ValidateFunc: validation.StringIsNotEmpty,
+			ExactlyOneOf: []string{"destination_address", "destination_resource_id"},
+		},
+
+		"destination_resource_id": {
+			Type:         pluginsdk.TypeString,
+			Optional:     true,
+			ValidateFunc: validation.StringIsNotEmpty,
+			ExactlyOneOf: []string{"destination_address", "destination_resource_id"},
+		},
+
+		"destination_port": {
+			Type:         pluginsdk.TypeInt,
+			Optional:     true,
+			ValidateFunc: validation.IsPortNumber,
+		},
+
+		"protocol": {
+			Type:         pluginsdk.TypeString,
+			Optional:     true,
+			ValidateFunc: validation.StringInSlice(networkwatchers.PossibleValuesForProtocol(), false),
+		},
+
+		"preferred_ip_version": {
+			Type:         pluginsdk.TypeString,
+			Optional:     true,
+			ValidateFunc: validation.StringInSlice(networkwatchers.PossibleValuesForIPVersion(), false),
+		},
+	}
+}
+
+func (NetworkWatcherConnectivityDataSource) Attributes() map[string]*pluginsdk.Schema {
+	return map[string]*pluginsdk.Schema{
+		"connection_status": {
+			Type:     pluginsdk.TypeString,
+			Computed: true,
+		},
+
+		"average_latency_in_ms": {
+			Type:     pluginsdk.TypeInt,
+			Computed: true,
+		},
+
+		"minimum_latency_in_ms": {
+			Type:     pluginsdk.TypeInt,
+			Computed: true,
+		},
+
+		"maximum_latency_in_ms": {
+			Type:     pluginsdk.TypeInt,
+			Computed: true,
+		},
+
+		"probes_sent": {
+			Type:     pluginsdk.TypeInt,
+			Computed: true,
+		},
+
+		"probes_failed": {
+			Type:     pluginsdk.TypeInt,
+			Computed: true,
+		},
+
+		"hop": {
+			Type:     pluginsdk.TypeList,
+			Computed: true,
+			Elem: &pluginsdk.Resource{
+				Schema: map[string]*pluginsdk.Schema{
+					"id": {
+						Type:     pluginsdk.TypeString,
+						Computed: true,
+					},
+
+					"type": {
+						Type:     pluginsdk.TypeString,
+						Computed: true,
+					},
+
+					"address": {
+						Type:     pluginsdk.TypeString,
+						Computed: true,
+					},
+
+					"resource_id": {
+						Type:     pluginsdk.TypeString,
+						Computed: true,
+					},
+
+					"next_hop_ids": {
+						Type:     pluginsdk.TypeList,
+						Computed: true,
+						Elem: &pluginsdk.Schema{
+							Type: pluginsdk.TypeString,
+						},
+					},
+
+					"issue": {
+						Type:     pluginsdk.TypeList,
+						Computed: true,
+						Elem: &pluginsdk.Resource{
+							Schema: map[string]*pluginsdk.Schema{
+								"origin": {
+									Type:     pluginsdk.TypeString,
+									Computed: true,
+								},
+
+								"severity": {
+									Type:     pluginsdk.TypeString,
+									Computed: true,
+								},
+
+								"type": {
+									Type:     pluginsdk.TypeString,
+									Computed: true,
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (NetworkWatcherConnectivityDataSource) ModelObject() interface{} {
+	return &NetworkWatcherConnectivityDataSourceModel{}
+}
+
+func (NetworkWatcherConnectivityDataSource) ResourceType() string {
+	return "azurerm_network_watcher_connectivity"
+}
+
+func (NetworkWatcherConnectivityDataSource) Read() sdk.ResourceFunc {
+	return sdk.ResourceFunc{
+		Timeout: 30 * time.Minute,
+		Func: func(ctx context.Context, metadata sdk.ResourceMetaData) error {
+			client := metadata.Client.Network.NetworkWatchers
+
+			var state NetworkWatcherConnectivityDataSourceModel
+			if err := metadata.Decode(&state); err != nil {
+				return fmt.Errorf("decoding: %+v", err)
+			}
+
+			id, err := networkwatchers.ParseNetworkWatcherID(state.NetworkWatcherId)
+			if err != nil {
+				return err
+			}
+
+			payload := networkwatchers.ConnectivityParameters{
+				Source: networkwatchers.ConnectivitySource{
+					ResourceId: state.SourceResourceId,
+				},
+				Destination: networkwatchers.ConnectivityDestination{},
+			}
+			if state.SourcePort != 0 {
+				payload.Source.Port = pointer.To(state.SourcePort)
+			}
+			if state.DestinationAddress != "" {
+				payload.Destination.Address = pointer.To(state.DestinationAddress)
+			}
+			if state.DestinationResourceId != "" {
+				payload.Destination.ResourceId = pointer.To(state.DestinationResourceId)
+			}
+			if state.DestinationPort != 0 {
+				payload.Destination.Port = pointer.To(state.DestinationPort)
+			}
+			if state.Protocol != "" {
+				payload.Protocol = pointer.To(networkwatchers.Protocol(state.Protocol))
+			}
+			if state.PreferredIPVersion != "" {
+				payload.PreferredIPVersion = pointer.To(networkwatchers.IPVersion(state.PreferredIPVersion))
+			}
+
+			var result networkwatchers.ConnectivityInformation
+			if err := performLongRunningPostWithResult(ctx, client.Client, fmt.Sprintf("%s/connectivityCheck", id.ID()), payload, &result); err != nil {
+				return fmt.Errorf("checking the connectivity using %s: %+v", id, err)
+			}
+
+			metadata.SetID(id)
+
+			state.ConnectionStatus = string(pointer.From(result.ConnectionStatus))
+			state.AverageLatencyInMs = pointer.From(result.AvgLatencyInMs)
+			state.MinimumLatencyInMs = pointer.From(result.MinLatencyInMs)
+			state.MaximumLatencyInMs = pointer.From(result.MaxLatencyInMs)
+			state.ProbesSent = pointer.From(result.ProbesSent)
+			state.ProbesFailed = pointer.From(result.ProbesFailed)
+			state.Hops = flattenNetworkWatcherConnectivityHops(result.Hops)
+
+			return metadata.Encode(&state)
+		},
+	}
+}
+
+func flattenNetworkWatcherConnectivityHops(input *[]networkwatchers.ConnectivityHop) []NetworkWatcherConnectivityHop {
+	output := make([]NetworkWatcherConnectivityHop, 0)
+	if input == nil {
+		return output
+	}
+
+	for _, v := range *input {
+		issues := make([]NetworkWatcherConnectivityIssue, 0)
+		if v.Issues != nil {
+			for _, issue := range *v.Issues {
+				issues = append(issues, NetworkWatcherConnectivityIssue{
+					Origin:   string(pointer.From(issue.Origin)),
+					Severity: string(pointer.From(issue.Severity)),
+					Type:     string(pointer.From(issue.Type)),
+				})
+			}
+		}
+
+		output = append(output, NetworkWatcherConnectivityHop{
+			Id:         pointer.From(v.Id),
+			Type:       pointer.From(v.Type),
+			Address:    pointer.From(v.Address),
+			ResourceId: pointer.From(v.ResourceId),
+			NextHopIds: pointer.From(v.NextHopIds),
+			Issues:     issues,
+		})
+	}
+
+	return output
+}
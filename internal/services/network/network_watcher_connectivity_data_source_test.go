@@ -0,0 +1,155 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package network_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-provider-azurerm/internal/acceptance"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/acceptance/check"
+)
+
+type NetworkWatcherConnectivityDataSource struct{}
+
+func testAccDataSourceNetworkWatcherConnectivity_basic(t *testing.T) {
+	data := acceptance.BuildTestData(t, "data.azurerm_network_watcher_connectivity", "test")
+	r := NetworkWatcherConnectivityDataSource{}
+
+	data.DataSourceTestInSequence(t, []acceptance.TestStep{
+		{
+			Config: r.basic(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).Key("connection_status").HasValue("Reachable"),
+				check.That(data.ResourceName).Key("probes_sent").Exists(),
+				check.That(data.ResourceName).Key("hop.#").Exists(),
+			),
+		},
+	})
+}
+
+func testAccDataSourceNetworkWatcherConnectivity_address(t *testing.T) {
+	data := acceptance.BuildTestData(t, "data.azurerm_network_watcher_connectivity", "test")
+	r := NetworkWatcherConnectivityDataSource{}
+
+	data.DataSourceTestInSequence(t, []acceptance.TestStep{
+		{
+			Config: r.address(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).Key("connection_status").Exists(),
+				check.That(data.ResourceName).Key("hop.#").Exists(),
+			),
+		},
+	})
+}
+
+func (r NetworkWatcherConnectivityDataSource) basic(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+%s
+
+data "azurerm_network_watcher_connectivity" "test" {
+  network_watcher_id      = azurerm_network_watcher.test.id
+  source_resource_id      = azurerm_linux_virtual_machine.test.id
+  destination_resource_id = azurerm_linux_virtual_machine.test.id
+  destination_port        = 22
+  protocol                = "Tcp"
+
+  depends_on = [azurerm_virtual_machine_extension.test]
+}
+`, r.template(data))
+}
+
+func (r NetworkWatcherConnectivityDataSource) address(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+%s
+
+data "azurerm_network_watcher_connectivity" "test" {
+  network_watcher_id   = azurerm_network_watcher.test.id
+  source_resource_id   = azurerm_linux_virtual_machine.test.id
+  destination_address  = "www.bing.com"
+  destination_port     = 443
+  protocol             = "Tcp"
+  preferred_ip_version = "IPv4"
+
+  depends_on = [azurerm_virtual_machine_extension.test]
+}
+`, r.template(data))
+}
+
+func (NetworkWatcherConnectivityDataSource) template(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+provider "azurerm" {
+  features {}
+}
+
+resource "azurerm_resource_group" "test" {
+  name     = "acctestRG-watcher-%[1]d"
+  location = "%[2]s"
+}
+
+resource "azurerm_network_watcher" "test" {
+  name                = "acctestnw-%[1]d"
+  location            = azurerm_resource_group.test.location
+  resource_group_name = azurerm_resource_group.test.name
+}
+
+resource "azurerm_virtual_network" "test" {
+  name                = "acctestvn-%[1]d"
+  address_space       = ["10.0.0.0/16"]
+  location            = azurerm_resource_group.test.location
+  resource_group_name = azurerm_resource_group.test.name
+}
+
+resource "azurerm_subnet" "test" {
+  name                 = "internal"
+  resource_group_name  = azurerm_resource_group.test.name
+  virtual_network_name = azurerm_virtual_network.test.name
+  address_prefixes     = ["10.0.2.0/24"]
+}
+
+resource "azurerm_network_interface" "test" {
+  name                = "acctestnic-%[1]d"
+  location            = azurerm_resource_group.test.location
+  resource_group_name = azurerm_resource_group.test.name
+
+  ip_configuration {
+    name                          = "internal"
+    subnet_id                     = azurerm_subnet.test.id
+    private_ip_address_allocation = "Dynamic"
+  }
+}
+
+resource "azurerm_linux_virtual_machine" "test" {
+  name                            = "acctestvm-%[1]d"
+  location                        = azurerm_resource_group.test.location
+  resource_group_name             = azurerm_resource_group.test.name
+  size                            = "Standard_F2"
+  admin_username                  = "adminuser"
+  admin_password                  = "P@$$w0rd1234!"
+  disable_password_authentication = false
+  network_interface_ids           = [azurerm_network_interface.test.id]
+
+  os_disk {
+    caching              = "ReadWrite"
+    storage_account_type = "Standard_LRS"
+  }
+
+  source_image_reference {
+    publisher = "Canonical"
+    offer     = "0001-com-ubuntu-server-jammy"
+    sku       = "22_04-lts"
+    version   = "latest"
+  }
+}
+
+resource "azurerm_virtual_machine_extension" "test" {
+  name                       = "network-watcher"
+  virtual_machine_id         = azurerm_linux_virtual_machine.test.id
+  publisher                  = "Microsoft.Azure.NetworkWatcher"
+  type                       = "NetworkWatcherAgentLinux"
+  type_handler_version       = "1.4"
+  auto_upgrade_minor_version = true
+}
+`, data.RandomInteger, data.Locations.Primary)
+}
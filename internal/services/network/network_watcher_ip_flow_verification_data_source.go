@@ -0,0 +1,159 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package network
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/go-azure-helpers/lang/pointer"
+	"github.com/hashicorp/go-azure-helpers/resourcemanager/commonids"
+	"github.com/hashicorp/go-azure-sdk/resource-manager/network/2024-03-01/networkwatchers"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/sdk"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/validation"
+)
+
+var _ sdk.DataSource = NetworkWatcherIPFlowVerificationDataSource{}
+
+type NetworkWatcherIPFlowVerificationDataSource struct{}
+
+type NetworkWatcherIPFlowVerificationDataSourceModel struct {
+	NetworkWatcherId         string `tfschema:"network_watcher_id"`
+	TargetResourceId         string `tfschema:"target_resource_id"`
+	TargetNetworkInterfaceId string `tfschema:"target_network_interface_id"`
+	Direction                string `tfschema:"direction"`
+	Protocol                 string `tfschema:"protocol"`
+	LocalIPAddress           string `tfschema:"local_ip_address"`
+	LocalPort                string `tfschema:"local_port"`
+	RemoteIPAddress          string `tfschema:"remote_ip_address"`
+	RemotePort               string `tfschema:"remote_port"`
+	Access                   string `tfschema:"access"`
+	RuleName                 string `tfschema:"rule_name"`
+}
+
+func (NetworkWatcherIPFlowVerificationDataSource) Arguments() map[string]*pluginsdk.Schema {
+	return map[string]*pluginsdk.Schema{
+		"network_watcher_id": {
+			Type:         pluginsdk.TypeString,
+			Required:     true,
+			ValidateFunc: networkwatchers.ValidateNetworkWatcherID,
+		},
+
+		"target_resource_id": {
+			Type:         pluginsdk.TypeString,
+			Required:     true,
+			ValidateFunc: validation.StringIsNotEmpty,
+		},
+
+		"direction": {
+			Type:         pluginsdk.TypeString,
+			Required:     true,
+			ValidateFunc: validation.StringInSlice(networkwatchers.PossibleValuesForDirection(), false),
+		},
+
+		"protocol": {
+			Type:         pluginsdk.TypeString,
+			Required:     true,
+			ValidateFunc: validation.StringInSlice(networkwatchers.PossibleValuesForIPFlowProtocol(), false),
+		},
+
+		"local_ip_address": {
+			Type:         pluginsdk.TypeString,
+			Required:     true,
+			ValidateFunc: validation.IsIPAddress,
+		},
+
+		"local_port": {
+			Type:         pluginsdk.TypeString,
+			Required:     true,
+			ValidateFunc: validation.StringIsNotEmpty,
+		},
+
+		"remote_ip_address": {
+			Type:         pluginsdk.TypeString,
+			Required:     true,
+			ValidateFunc: validation.IsIPAddress,
+		},
+
+		"remote_port": {
+			Type:         pluginsdk.TypeString,
+			Required:     true,
+			ValidateFunc: validation.StringIsNotEmpty,
+		},
+
+		"target_network_interface_id": {
+			Type:         pluginsdk.TypeString,
+			Optional:     true,
+			ValidateFunc: commonids.ValidateNetworkInterfaceID,
+		},
+	}
+}
+
+func (NetworkWatcherIPFlowVerificationDataSource) Attributes() map[string]*pluginsdk.Schema {
+	return map[string]*pluginsdk.Schema{
+		"access": {
+			Type:     pluginsdk.TypeString,
+			Computed: true,
+		},
+
+		"rule_name": {
+			Type:     pluginsdk.TypeString,
+			Computed: true,
+		},
+	}
+}
+
+func (NetworkWatcherIPFlowVerificationDataSource) ModelObject() interface{} {
+	return &NetworkWatcherIPFlowVerificationDataSourceModel{}
+}
+
+func (NetworkWatcherIPFlowVerificationDataSource) ResourceType() string {
+	return "azurerm_network_watcher_ip_flow_verification"
+}
+
+func (NetworkWatcherIPFlowVerificationDataSource) Read() sdk.ResourceFunc {
+	return sdk.ResourceFunc{
+		Timeout: 30 * time.Minute,
+		Func: func(ctx context.Context, metadata sdk.ResourceMetaData) error {
+			client := metadata.Client.Network.NetworkWatchers
+
+			var state NetworkWatcherIPFlowVerificationDataSourceModel
+			if err := metadata.Decode(&state); err != nil {
+				return fmt.Errorf("decoding: %+v", err)
+			}
+
+			id, err := networkwatchers.ParseNetworkWatcherID(state.NetworkWatcherId)
+			if err != nil {
+				return err
+			}
+
+			payload := networkwatchers.VerificationIPFlowParameters{
+				TargetResourceId: state.TargetResourceId,
+				Direction:        networkwatchers.Direction(state.Direction),
+				Protocol:         networkwatchers.IPFlowProtocol(state.Protocol),
+				LocalIPAddress:   state.LocalIPAddress,
+				LocalPort:        state.LocalPort,
+				RemoteIPAddress:  state.RemoteIPAddress,
+				RemotePort:       state.RemotePort,
+			}
+			if state.TargetNetworkInterfaceId != "" {
+				payload.TargetNicResourceId = pointer.To(state.TargetNetworkInterfaceId)
+			}
+
+			var result networkwatchers.VerificationIPFlowResult
+			if err := performLongRunningPostWithResult(ctx, client.Client, fmt.Sprintf("%s/ipFlowVerify", id.ID()), payload, &result); err != nil {
+				return fmt.Errorf("verifying the IP flow using %s: %+v", id, err)
+			}
+
+			metadata.SetID(id)
+
+			state.Access = string(pointer.From(result.Access))
+			state.RuleName = pointer.From(result.RuleName)
+
+			return metadata.Encode(&state)
+		},
+	}
+}
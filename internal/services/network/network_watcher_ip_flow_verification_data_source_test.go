@@ -0,0 +1,48 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package network_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-provider-azurerm/internal/acceptance"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/acceptance/check"
+)
+
+type NetworkWatcherIPFlowVerificationDataSource struct{}
+
+func testAccDataSourceNetworkWatcherIPFlowVerification_basic(t *testing.T) {
+	data := acceptance.BuildTestData(t, "data.azurerm_network_watcher_ip_flow_verification", "test")
+	r := NetworkWatcherIPFlowVerificationDataSource{}
+
+	data.DataSourceTestInSequence(t, []acceptance.TestStep{
+		{
+			Config: r.basic(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).Key("access").HasValue("Allow"),
+				check.That(data.ResourceName).Key("rule_name").Exists(),
+			),
+		},
+	})
+}
+
+func (NetworkWatcherIPFlowVerificationDataSource) basic(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+%s
+
+data "azurerm_network_watcher_ip_flow_verification" "test" {
+  network_watcher_id = azurerm_network_watcher.test.id
+  target_resource_id = azurerm_linux_virtual_machine.test.id
+  direction          = "Outbound"
+  protocol           = "TCP"
+  local_ip_address   = azurerm_network_interface.test.private_ip_address
+  local_port         = "*"
+  remote_ip_address  = "13.107.21.200"
+  remote_port        = "443"
+
+  depends_on = [azurerm_virtual_machine_extension.test]
+}
+`, NetworkWatcherConnectivityDataSource{}.template(data))
+}
@@ -0,0 +1,43 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package network_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-provider-azurerm/internal/acceptance"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/acceptance/check"
+)
+
+type NetworkWatcherNextHopDataSource struct{}
+
+func testAccDataSourceNetworkWatcherNextHop_basic(t *testing.T) {
+	data := acceptance.BuildTestData(t, "data.azurerm_network_watcher_next_hop", "test")
+	r := NetworkWatcherNextHopDataSource{}
+
+	data.DataSourceTestInSequence(t, []acceptance.TestStep{
+		{
+			Config: r.basic(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).Key("next_hop_type").HasValue("Internet"),
+			),
+		},
+	})
+}
+
+func (NetworkWatcherNextHopDataSource) basic(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+%s
+
+data "azurerm_network_watcher_next_hop" "test" {
+  network_watcher_id     = azurerm_network_watcher.test.id
+  target_resource_id     = azurerm_linux_virtual_machine.test.id
+  source_ip_address      = azurerm_network_interface.test.private_ip_address
+  destination_ip_address = "13.107.21.200"
+
+  depends_on = [azurerm_virtual_machine_extension.test]
+}
+`, NetworkWatcherConnectivityDataSource{}.template(data))
+}
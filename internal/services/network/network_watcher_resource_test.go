@@ -76,6 +76,12 @@ func TestAccNetworkWatcher(t *testing.T) {
 			"requiresImport":             testAccVirtualMachineScaleSetPacketCapture_requiresImport,
 			"machineScope":               testAccVirtualMachineScaleSetPacketCapture_machineScope,
 		},
+		"Diagnostics": {
+			"connectivity":        testAccDataSourceNetworkWatcherConnectivity_basic,
+			"connectivityAddress": testAccDataSourceNetworkWatcherConnectivity_address,
+			"ipFlowVerification":  testAccDataSourceNetworkWatcherIPFlowVerification_basic,
+			"nextHop":             testAccDataSourceNetworkWatcherNextHop_basic,
+		},
 		"FlowLog": {
 			"basic":                   testAccNetworkWatcherFlowLog_basic,
 			"basicWithVirtualNetwork": testAccNetworkWatcherFlowLog_basicWithVirtualNetwork,
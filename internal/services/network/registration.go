@@ -38,6 +38,11 @@ func (r Registration) DataSources() []sdk.DataSource {
 		ManagerConnectivityConfigurationDataSource{},
 		VPNServerConfigurationDataSource{},
 		VirtualNetworkPeeringDataSource{},
+		NetworkInterfaceEffectiveRoutesDataSource{},
+		NetworkInterfaceEffectiveSecurityRulesDataSource{},
+		NetworkWatcherConnectivityDataSource{},
+		NetworkWatcherIPFlowVerificationDataSource{},
+		NetworkWatcherNextHopDataSource{},
 	}
 }
 
@@ -0,0 +1,122 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package network
+
+import (
+	"fmt"
+	"net/netip"
+
+	"github.com/hashicorp/go-azure-sdk/resource-manager/network/2024-03-01/virtualnetworks"
+)
+
+// nextAvailableSubnetPrefix returns the first IPv4 block of the specified prefix length within the address space
+// of the Virtual Network which doesn't overlap any of the existing Subnets
+func nextAvailableSubnetPrefix(vnet virtualnetworks.VirtualNetwork, prefixLength int) (string, error) {
+	if vnet.Properties == nil || vnet.Properties.AddressSpace == nil || vnet.Properties.AddressSpace.AddressPrefixes == nil {
+		return "", fmt.Errorf("the Virtual Network has no address space")
+	}
+
+	used := make([]string, 0)
+	if vnet.Properties.Subnets != nil {
+		for _, subnet := range *vnet.Properties.Subnets {
+			if subnet.Properties == nil {
+				continue
+			}
+			if subnet.Properties.AddressPrefix != nil {
+				used = append(used, *subnet.Properties.AddressPrefix)
+			}
+			if subnet.Properties.AddressPrefixes != nil {
+				used = append(used, *subnet.Properties.AddressPrefixes...)
+			}
+		}
+	}
+
+	return findAvailablePrefix(*vnet.Properties.AddressSpace.AddressPrefixes, used, prefixLength)
+}
+
+// findAvailablePrefix returns the first block of the specified prefix length within the address spaces (in the order
+// they're specified) which doesn't overlap any of the used prefixes. IPv6 address spaces are ignored.
+func findAvailablePrefix(addressSpaces []string, used []string, prefixLength int) (string, error) {
+	usedPrefixes := make([]netip.Prefix, 0, len(used))
+	for _, v := range used {
+		prefix, err := netip.ParsePrefix(v)
+		if err != nil {
+			return "", fmt.Errorf("parsing the existing subnet prefix %q: %+v", v, err)
+		}
+		usedPrefixes = append(usedPrefixes, prefix.Masked())
+	}
+
+	for _, v := range addressSpaces {
+		space, err := netip.ParsePrefix(v)
+		if err != nil {
+			return "", fmt.Errorf("parsing the address space %q: %+v", v, err)
+		}
+		space = space.Masked()
+		if !space.Addr().Is4() || prefixLength < space.Bits() {
+			continue
+		}
+
+		candidate := netip.PrefixFrom(space.Addr(), prefixLength)
+		for space.Contains(candidate.Addr()) {
+			overlapping := false
+			for _, u := range usedPrefixes {
+				if !u.Overlaps(candidate) {
+					continue
+				}
+
+				overlapping = true
+				// skip past the used prefix when it's larger than the candidate, otherwise to the next block
+				if u.Bits() < candidate.Bits() {
+					candidate = netip.PrefixFrom(lastAddress(u), prefixLength).Masked()
+				}
+				break
+			}
+			if !overlapping {
+				return candidate.String(), nil
+			}
+
+			next := lastAddress(candidate).Next()
+			if !next.IsValid() {
+				break
+			}
+			candidate = netip.PrefixFrom(next, prefixLength)
+		}
+	}
+
+	return "", fmt.Errorf("no free /%d block is available within the address space %v", prefixLength, addressSpaces)
+}
+
+// subnetPrefixLength returns the prefix length of the Subnet when it has a single IPv4 address prefix, as is the case
+// when the address prefix was allocated using `prefix_length`
+func subnetPrefixLength(addressPrefixes []interface{}) (int, bool) {
+	if len(addressPrefixes) != 1 {
+		return 0, false
+	}
+
+	v, ok := addressPrefixes[0].(string)
+	if !ok {
+		return 0, false
+	}
+	prefix, err := netip.ParsePrefix(v)
+	if err != nil || !prefix.Addr().Is4() {
+		return 0, false
+	}
+
+	return prefix.Bits(), true
+}
+
+// lastAddress returns the last address within the (IPv4) prefix
+func lastAddress(prefix netip.Prefix) netip.Addr {
+	b := prefix.Masked().Addr().As4()
+	hostBits := 32 - prefix.Bits()
+	for i := 3; i >= 0 && hostBits > 0; i-- {
+		bits := hostBits
+		if bits > 8 {
+			bits = 8
+		}
+		b[i] |= byte(1<<bits - 1)
+		hostBits -= bits
+	}
+	return netip.AddrFrom4(b)
+}
@@ -0,0 +1,145 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package network
+
+import (
+	"testing"
+)
+
+func TestFindAvailablePrefix(t *testing.T) {
+	testData := []struct {
+		name          string
+		addressSpaces []string
+		used          []string
+		prefixLength  int
+		expected      string
+		expectError   bool
+	}{
+		{
+			name:          "empty virtual network",
+			addressSpaces: []string{"10.0.0.0/16"},
+			prefixLength:  24,
+			expected:      "10.0.0.0/24",
+		},
+		{
+			name:          "first block used",
+			addressSpaces: []string{"10.0.0.0/16"},
+			used:          []string{"10.0.0.0/24"},
+			prefixLength:  24,
+			expected:      "10.0.1.0/24",
+		},
+		{
+			name:          "gap between used blocks",
+			addressSpaces: []string{"10.0.0.0/16"},
+			used:          []string{"10.0.0.0/24", "10.0.2.0/24"},
+			prefixLength:  24,
+			expected:      "10.0.1.0/24",
+		},
+		{
+			name:          "smaller used block",
+			addressSpaces: []string{"10.0.0.0/16"},
+			used:          []string{"10.0.0.64/26"},
+			prefixLength:  24,
+			expected:      "10.0.1.0/24",
+		},
+		{
+			name:          "larger used block",
+			addressSpaces: []string{"10.0.0.0/16"},
+			used:          []string{"10.0.0.0/20"},
+			prefixLength:  26,
+			expected:      "10.0.16.0/26",
+		},
+		{
+			name:          "smaller block fits alongside a used block",
+			addressSpaces: []string{"10.0.0.0/16"},
+			used:          []string{"10.0.0.0/26"},
+			prefixLength:  26,
+			expected:      "10.0.0.64/26",
+		},
+		{
+			name:          "second address space",
+			addressSpaces: []string{"10.0.0.0/24", "ace:cab:deca::/48", "10.1.0.0/16"},
+			used:          []string{"10.0.0.0/25", "10.0.0.128/25", "ace:cab:deca::/64"},
+			prefixLength:  24,
+			expected:      "10.1.0.0/24",
+		},
+		{
+			name:          "prefix larger than the address space",
+			addressSpaces: []string{"10.0.0.0/24"},
+			prefixLength:  16,
+			expectError:   true,
+		},
+		{
+			name:          "address space exhausted",
+			addressSpaces: []string{"10.0.0.0/24"},
+			used:          []string{"10.0.0.0/25", "10.0.0.128/26"},
+			prefixLength:  25,
+			expectError:   true,
+		},
+		{
+			name:          "end of the IPv4 address space",
+			addressSpaces: []string{"255.255.255.0/24"},
+			used:          []string{"255.255.255.0/25"},
+			prefixLength:  25,
+			expected:      "255.255.255.128/25",
+		},
+	}
+
+	for _, v := range testData {
+		t.Run(v.name, func(t *testing.T) {
+			actual, err := findAvailablePrefix(v.addressSpaces, v.used, v.prefixLength)
+			if v.expectError {
+				if err == nil {
+					t.Fatalf("expected an error but got %q", actual)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %+v", err)
+			}
+			if actual != v.expected {
+				t.Fatalf("expected %q but got %q", v.expected, actual)
+			}
+		})
+	}
+}
+
+func TestSubnetPrefixLength(t *testing.T) {
+	testData := []struct {
+		name            string
+		addressPrefixes []interface{}
+		expected        int
+		expectOk        bool
+	}{
+		{
+			name:            "single IPv4 prefix",
+			addressPrefixes: []interface{}{"10.0.2.0/24"},
+			expected:        24,
+			expectOk:        true,
+		},
+		{
+			name:            "single IPv6 prefix",
+			addressPrefixes: []interface{}{"ace:cab:deca::/64"},
+		},
+		{
+			name:            "multiple prefixes",
+			addressPrefixes: []interface{}{"10.0.2.0/24", "ace:cab:deca::/64"},
+		},
+		{
+			name: "no prefixes",
+		},
+	}
+
+	for _, v := range testData {
+		t.Run(v.name, func(t *testing.T) {
+			actual, ok := subnetPrefixLength(v.addressPrefixes)
+			if ok != v.expectOk {
+				t.Fatalf("expected ok to be %t but got %t", v.expectOk, ok)
+			}
+			if actual != v.expected {
+				t.Fatalf("expected %d but got %d", v.expected, actual)
+			}
+		})
+	}
+}
@@ -16,6 +16,7 @@ import (
 	"github.com/hashicorp/go-azure-helpers/resourcemanager/commonschema"
 	"github.com/hashicorp/go-azure-sdk/resource-manager/network/2023-11-01/serviceendpointpolicies"
 	"github.com/hashicorp/go-azure-sdk/resource-manager/network/2024-03-01/subnets"
+	"github.com/hashicorp/go-azure-sdk/resource-manager/network/2024-03-01/virtualnetworks"
 	"github.com/hashicorp/terraform-provider-azurerm/helpers/tf"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/clients"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/features"
@@ -131,15 +132,27 @@ func resourceSubnet() *pluginsdk.Resource {
 			},
 
 			"address_prefixes": {
-				Type:     pluginsdk.TypeList,
-				Required: true,
-				MinItems: 1,
+				Type:         pluginsdk.TypeList,
+				Optional:     true,
+				Computed:     true,
+				MinItems:     1,
+				ExactlyOneOf: []string{"address_prefixes", "prefix_length"},
 				Elem: &pluginsdk.Schema{
 					Type:         pluginsdk.TypeString,
 					ValidateFunc: validation.StringIsNotEmpty,
 				},
 			},
 
+			// this is Computed so that it's derived from the allocated address prefix, e.g. when imported
+			"prefix_length": {
+				Type:         pluginsdk.TypeInt,
+				Optional:     true,
+				Computed:     true,
+				ForceNew:     true,
+				ExactlyOneOf: []string{"address_prefixes", "prefix_length"},
+				ValidateFunc: validation.IntBetween(8, 29),
+			},
+
 			"service_endpoints": {
 				Type:     pluginsdk.TypeSet,
 				Optional: true,
@@ -319,7 +332,9 @@ func resourceSubnetCreate(d *pluginsdk.ResourceData, meta interface{}) error {
 		return tf.ImportAsExistsError("azurerm_subnet", id.ID())
 	}
 
-	locks.ByName(id.VirtualNetworkName, VirtualNetworkResourceName)
+	if err := locks.ByNameWithContext(ctx, id.VirtualNetworkName, VirtualNetworkResourceName); err != nil {
+		return fmt.Errorf("locking Virtual Network for %s: %+v", id, err)
+	}
 	defer locks.UnlockByName(id.VirtualNetworkName, VirtualNetworkResourceName)
 
 	properties := subnets.SubnetPropertiesFormat{}
@@ -329,6 +344,23 @@ func resourceSubnetCreate(d *pluginsdk.ResourceData, meta interface{}) error {
 			addressPrefixes = append(addressPrefixes, item.(string))
 		}
 		properties.AddressPrefixes = &addressPrefixes
+	} else if prefixLength, ok := d.GetOk("prefix_length"); ok {
+		// the Virtual Network is locked above, so the block chosen can't be allocated to another Subnet concurrently
+		vnetId := commonids.NewVirtualNetworkID(id.SubscriptionId, id.ResourceGroupName, id.VirtualNetworkName)
+		vnet, err := vnetClient.Get(ctx, vnetId, virtualnetworks.DefaultGetOperationOptions())
+		if err != nil {
+			return fmt.Errorf("retrieving %s: %+v", vnetId, err)
+		}
+		if vnet.Model == nil {
+			return fmt.Errorf("retrieving %s: `model` was nil", vnetId)
+		}
+
+		addressPrefix, err := nextAvailableSubnetPrefix(*vnet.Model, prefixLength.(int))
+		if err != nil {
+			return fmt.Errorf("allocating an address prefix for %s: %+v", id, err)
+		}
+		log.Printf("[DEBUG] Allocated the address prefix %q for %s", addressPrefix, id)
+		properties.AddressPrefixes = &[]string{addressPrefix}
 	}
 	if properties.AddressPrefixes != nil && len(*properties.AddressPrefixes) == 1 {
 		properties.AddressPrefix = &(*properties.AddressPrefixes)[0]
@@ -474,10 +506,14 @@ func resourceSubnetUpdate(d *pluginsdk.ResourceData, meta interface{}) error {
 		return err
 	}
 
-	locks.ByName(id.VirtualNetworkName, VirtualNetworkResourceName)
+	if err := locks.ByNameWithContext(ctx, id.VirtualNetworkName, VirtualNetworkResourceName); err != nil {
+		return fmt.Errorf("locking Virtual Network for %s: %+v", *id, err)
+	}
 	defer locks.UnlockByName(id.VirtualNetworkName, VirtualNetworkResourceName)
 
-	locks.ByName(id.SubnetName, SubnetResourceName)
+	if err := locks.ByNameWithContext(ctx, id.SubnetName, SubnetResourceName); err != nil {
+		return fmt.Errorf("locking %s: %+v", *id, err)
+	}
 	defer locks.UnlockByName(id.SubnetName, SubnetResourceName)
 
 	existing, err := client.Get(ctx, *id, subnets.DefaultGetOperationOptions())
@@ -656,6 +692,10 @@ func resourceSubnetRead(d *pluginsdk.ResourceData, meta interface{}) error {
 				d.Set("address_prefixes", props.AddressPrefixes)
 			}
 
+			if prefixLength, ok := subnetPrefixLength(d.Get("address_prefixes").([]interface{})); ok {
+				d.Set("prefix_length", prefixLength)
+			}
+
 			defaultOutboundAccessEnabled := true
 			if props.DefaultOutboundAccess != nil {
 				defaultOutboundAccessEnabled = *props.DefaultOutboundAccess
@@ -701,10 +741,14 @@ func resourceSubnetDelete(d *pluginsdk.ResourceData, meta interface{}) error {
 		return err
 	}
 
-	locks.ByName(id.VirtualNetworkName, VirtualNetworkResourceName)
+	if err := locks.ByNameWithContext(ctx, id.VirtualNetworkName, VirtualNetworkResourceName); err != nil {
+		return fmt.Errorf("locking Virtual Network for %s: %+v", *id, err)
+	}
 	defer locks.UnlockByName(id.VirtualNetworkName, VirtualNetworkResourceName)
 
-	locks.ByName(id.SubnetName, SubnetResourceName)
+	if err := locks.ByNameWithContext(ctx, id.SubnetName, SubnetResourceName); err != nil {
+		return fmt.Errorf("locking %s: %+v", *id, err)
+	}
 	defer locks.UnlockByName(id.SubnetName, SubnetResourceName)
 
 	if err := client.DeleteThenPoll(ctx, *id); err != nil {
@@ -22,6 +22,28 @@ import (
 
 type SubnetResource struct{}
 
+func TestAccSubnet_prefixLength(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azurerm_subnet", "test")
+	r := SubnetResource{}
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.prefixLength(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("address_prefixes.#").HasValue("1"),
+				check.That("azurerm_subnet.test2").Key("address_prefixes.#").HasValue("1"),
+			),
+		},
+		{
+			// the allocated address prefixes must remain stable
+			Config:   r.prefixLength(data),
+			PlanOnly: true,
+		},
+		data.ImportStep(),
+	})
+}
+
 func TestAccSubnet_basic(t *testing.T) {
 	data := acceptance.BuildTestData(t, "azurerm_subnet", "test")
 	r := SubnetResource{}
@@ -560,6 +582,37 @@ resource "azurerm_subnet" "test2" {
 `, r.template(data))
 }
 
+func (r SubnetResource) prefixLength(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+%s
+
+resource "azurerm_subnet" "existing" {
+  name                 = "existing"
+  resource_group_name  = azurerm_resource_group.test.name
+  virtual_network_name = azurerm_virtual_network.test.name
+  address_prefixes     = ["10.0.0.0/24"]
+}
+
+resource "azurerm_subnet" "test" {
+  name                 = "internal"
+  resource_group_name  = azurerm_resource_group.test.name
+  virtual_network_name = azurerm_virtual_network.test.name
+  prefix_length        = 24
+
+  depends_on = [azurerm_subnet.existing]
+}
+
+resource "azurerm_subnet" "test2" {
+  name                 = "internal2"
+  resource_group_name  = azurerm_resource_group.test.name
+  virtual_network_name = azurerm_virtual_network.test.name
+  prefix_length        = 26
+
+  depends_on = [azurerm_subnet.existing]
+}
+`, r.template(data))
+}
+
 func (r SubnetResource) delegation(data acceptance.TestData) string {
 	return fmt.Sprintf(`
 %s
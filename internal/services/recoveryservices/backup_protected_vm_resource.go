@@ -23,6 +23,7 @@ import (
 	"github.com/hashicorp/terraform-provider-azurerm/helpers/azure"
 	"github.com/hashicorp/terraform-provider-azurerm/helpers/tf"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/clients"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/features"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/services/recoveryservices/validate"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/suppress"
@@ -111,7 +112,10 @@ func resourceRecoveryServicesBackupProtectedVMCreate(d *pluginsdk.ResourceData,
 		}
 
 		if isSoftDeleted {
-			if meta.(*clients.Client).Features.RecoveryServicesVault.RecoverSoftDeletedBackupProtectedVM {
+			// TODO: remove the deprecated `recover_soft_deleted_backup_protected_vm` feature in v5.0
+			recoverSoftDeleted := meta.(*clients.Client).Features.RecoveryServicesVault.RecoverSoftDeletedBackupProtectedVM ||
+				meta.(*clients.Client).Features.SoftDelete.For(features.SoftDeleteServiceRecoveryServicesVault).RecoverSoftDeleted
+			if recoverSoftDeleted {
 				err = resourceRecoveryServicesVaultBackupProtectedVMRecoverSoftDeleted(ctx, client, opClient, id)
 				if err != nil {
 					return fmt.Errorf("recovering soft deleted %s: %+v", id, err)
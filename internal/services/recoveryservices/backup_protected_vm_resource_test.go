@@ -1116,8 +1116,10 @@ resource "azurerm_backup_protected_vm" "test" {
 	return fmt.Sprintf(`
 provider "azurerm" {
   features {
-    recovery_services_vaults {
-      recover_soft_deleted_backup_protected_vm = true
+    soft_delete {
+      recovery_services_vault {
+        recover_soft_deleted = true
+      }
     }
   }
 }
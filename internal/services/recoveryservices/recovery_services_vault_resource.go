@@ -24,6 +24,7 @@ import (
 	"github.com/hashicorp/go-azure-sdk/resource-manager/recoveryservicessiterecovery/2024-04-01/replicationvaultsetting"
 	"github.com/hashicorp/terraform-provider-azurerm/helpers/tf"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/clients"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/features"
 	keyvaultValidate "github.com/hashicorp/terraform-provider-azurerm/internal/services/keyvault/validate"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/services/recoveryservices/validate"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
@@ -673,7 +674,10 @@ func resourceRecoveryServicesVaultDelete(d *pluginsdk.ResourceData, meta interfa
 		return err
 	}
 
-	if meta.(*clients.Client).Features.RecoveryService.PurgeProtectedItemsFromVaultOnDestroy {
+	// TODO: remove the deprecated `purge_protected_items_from_vault_on_destroy` feature in v5.0
+	purgeProtectedItemsOnDestroy := meta.(*clients.Client).Features.RecoveryService.PurgeProtectedItemsFromVaultOnDestroy ||
+		meta.(*clients.Client).Features.SoftDelete.For(features.SoftDeleteServiceRecoveryServicesVault).PurgeOnDestroy
+	if purgeProtectedItemsOnDestroy {
 		log.Printf("[DEBUG] Purging Protected Items from %s", id.String())
 
 		vaultId := backupprotecteditems.NewVaultID(id.SubscriptionId, id.ResourceGroupName, id.VaultName)
@@ -52,6 +52,18 @@ func TestAccResourceGroup_disappears(t *testing.T) {
 	})
 }
 
+func TestAccResourceGroup_tagsDrift(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azurerm_resource_group", "test")
+	testResource := ResourceGroupResource{}
+	data.ResourceTest(t, testResource, []acceptance.TestStep{
+		data.DriftStep(acceptance.DriftStepData{
+			Config:          testResource.withTagsConfig,
+			Mutation:        testResource.updateTagsOutsideTerraform,
+			ExpectedChanges: []string{"tags.environment"},
+		}),
+	})
+}
+
 func TestAccResourceGroup_withTags(t *testing.T) {
 	data := acceptance.BuildTestData(t, "azurerm_resource_group", "test")
 	testResource := ResourceGroupResource{}
@@ -197,6 +209,25 @@ func (t ResourceGroupResource) createNetworkOutsideTerraform(name string) func(c
 	}
 }
 
+func (t ResourceGroupResource) updateTagsOutsideTerraform(ctx context.Context, clients *clients.Client, state *pluginsdk.InstanceState) error {
+	id, err := commonids.ParseResourceGroupIDInsensitively(state.ID)
+	if err != nil {
+		return err
+	}
+
+	payload := resourcegroups.ResourceGroupPatchable{
+		Tags: &map[string]string{
+			"environment": "Drifted",
+			"cost_center": "MSFT",
+		},
+	}
+	if _, err := clients.Resource.ResourceGroupsClient.Update(ctx, *id, payload); err != nil {
+		return fmt.Errorf("updating the tags for %s: %+v", *id, err)
+	}
+
+	return nil
+}
+
 func (t ResourceGroupResource) basicConfig(data acceptance.TestData) string {
 	return fmt.Sprintf(`
 provider "azurerm" {
@@ -169,6 +169,9 @@ func (sbu BlobUpload) uploadBlockBlob(ctx context.Context) error {
 	if sbu.ContentMD5 != "" {
 		input.ContentMD5 = pointer.To(sbu.ContentMD5)
 	}
+	if sbu.CacheControl != "" {
+		input.CacheControl = pointer.To(sbu.CacheControl)
+	}
 	if sbu.EncryptionScope != "" {
 		input.EncryptionScope = pointer.To(sbu.EncryptionScope)
 	}
@@ -0,0 +1,102 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package storage
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+
+	"github.com/hashicorp/go-azure-helpers/storage"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/features"
+)
+
+// accountSharedAccessSignature contains the values used to compute an Account (rather than a Service) SAS
+type accountSharedAccessSignature struct {
+	ConnectionString string
+	HttpsOnly        bool
+	IPAddresses      string
+	SignedVersion    string
+	ResourceTypes    string
+	Services         string
+	Start            string
+	Expiry           string
+	Permissions      string
+}
+
+// containerSharedAccessSignature contains the values used to compute a Service SAS for a Blob Container
+type containerSharedAccessSignature struct {
+	ConnectionString   string
+	ContainerName      string
+	HttpsOnly          bool
+	IPAddress          string
+	Start              string
+	Expiry             string
+	Permissions        string
+	CacheControl       string
+	ContentDisposition string
+	ContentEncoding    string
+	ContentLanguage    string
+	ContentType        string
+}
+
+func defaultAccountSharedAccessSignatureSignedVersion() string {
+	if features.FourPointOhBeta() {
+		// TODO: Update the document as well
+		return "2022-11-02"
+	}
+	return "2017-07-29"
+}
+
+func computeAccountSharedAccessSignature(input accountSharedAccessSignature) (string, error) {
+	// Parse the connection string
+	kvp, err := storage.ParseAccountSASConnectionString(input.ConnectionString)
+	if err != nil {
+		return "", err
+	}
+
+	// Create the string to sign with the key...
+
+	// Details on how to do this are here:
+	// https://docs.microsoft.com/en-us/rest/api/storageservices/Constructing-an-Account-SAS
+	accountName := kvp[connStringAccountNameKey]
+	accountKey := kvp[connStringAccountKeyKey]
+	signedProtocol := "https,http"
+	if input.HttpsOnly {
+		signedProtocol = "https"
+	}
+
+	// TODO: implement support for signedEncryptionScope
+	signedEncryptionScope := ""
+
+	return storage.ComputeAccountSASToken(accountName, accountKey, input.Permissions, input.Services, input.ResourceTypes,
+		input.Start, input.Expiry, signedProtocol, input.IPAddresses, input.SignedVersion, signedEncryptionScope)
+}
+
+func computeContainerSharedAccessSignature(input containerSharedAccessSignature) (string, error) {
+	// Parse the connection string
+	kvp, err := storage.ParseAccountSASConnectionString(input.ConnectionString)
+	if err != nil {
+		return "", err
+	}
+
+	// Create the string to sign with the key...
+	accountName := kvp[connStringAccountNameKey]
+	accountKey := kvp[connStringAccountKeyKey]
+	signedProtocol := "https,http"
+	if input.HttpsOnly {
+		signedProtocol = "https"
+	}
+	signedIdentifier := ""
+	signedSnapshotTime := ""
+
+	return storage.ComputeContainerSASToken(input.Permissions, input.Start, input.Expiry, accountName, accountKey,
+		input.ContainerName, signedIdentifier, input.IPAddress, signedProtocol, signedSnapshotTime, input.CacheControl,
+		input.ContentDisposition, input.ContentEncoding, input.ContentLanguage, input.ContentType)
+}
+
+// sharedAccessSignatureID returns a stable identifier for a Shared Access Signature, without exposing the token itself
+func sharedAccessSignatureID(sasToken string) string {
+	tokenHash := sha256.Sum256([]byte(sasToken))
+	return hex.EncodeToString(tokenHash[:])
+}
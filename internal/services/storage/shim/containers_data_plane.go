@@ -6,9 +6,12 @@ package shim
 import (
 	"context"
 	"fmt"
+	"net/http"
 
 	"github.com/hashicorp/go-azure-helpers/lang/pointer"
 	"github.com/hashicorp/go-azure-helpers/lang/response"
+	"github.com/hashicorp/go-azure-sdk/sdk/client"
+	"github.com/hashicorp/go-azure-sdk/sdk/odata"
 	"github.com/tombuildsstuff/giovanni/storage/2023-11-03/blob/containers"
 )
 
@@ -69,6 +72,61 @@ func (w DataPlaneStorageContainerWrapper) Get(ctx context.Context, containerName
 	}, nil
 }
 
+// ListBlobs returns all the blobs within the container whose name starts with the specified prefix, or nil if the container doesn't exist
+func (w DataPlaneStorageContainerWrapper) ListBlobs(ctx context.Context, containerName string, prefix string) (*[]containers.BlobDetails, error) {
+	result := make([]containers.BlobDetails, 0)
+
+	input := containers.ListBlobsInput{}
+	if prefix != "" {
+		input.Prefix = pointer.To(prefix)
+	}
+
+	for {
+		resp, err := w.client.ListBlobs(ctx, containerName, input)
+		if err != nil {
+			if response.WasNotFound(resp.HttpResponse) {
+				return nil, nil
+			}
+			return nil, err
+		}
+
+		result = append(result, resp.Blobs.Blobs...)
+
+		if resp.NextMarker == nil || *resp.NextMarker == "" {
+			break
+		}
+		input.Marker = resp.NextMarker
+	}
+
+	return &result, nil
+}
+
+// Restore restores the specified version of a soft-deleted container, along with the blobs within it
+func (w DataPlaneStorageContainerWrapper) Restore(ctx context.Context, containerName string, deletedVersion string) error {
+	opts := client.RequestOptions{
+		ExpectedStatusCodes: []int{
+			http.StatusCreated,
+		},
+		HttpMethod: http.MethodPut,
+		OptionsObject: restoreContainerOptions{
+			containerName:  containerName,
+			deletedVersion: deletedVersion,
+		},
+		Path: fmt.Sprintf("/%s", containerName),
+	}
+
+	req, err := w.client.Client.NewRequest(ctx, opts)
+	if err != nil {
+		return fmt.Errorf("building request: %+v", err)
+	}
+
+	if _, err = req.Execute(ctx); err != nil {
+		return fmt.Errorf("executing request: %+v", err)
+	}
+
+	return nil
+}
+
 func (w DataPlaneStorageContainerWrapper) UpdateAccessLevel(ctx context.Context, containerName string, level containers.AccessLevel) error {
 	input := containers.SetAccessControlInput{
 		AccessLevel: level,
@@ -84,3 +142,27 @@ func (w DataPlaneStorageContainerWrapper) UpdateMetaData(ctx context.Context, co
 	_, err := w.client.SetMetaData(ctx, containerName, input)
 	return err
 }
+
+// restoreContainerOptions defines the options for the Restore Container operation, which isn't supported by Giovanni
+type restoreContainerOptions struct {
+	containerName  string
+	deletedVersion string
+}
+
+func (o restoreContainerOptions) ToHeaders() *client.Headers {
+	headers := &client.Headers{}
+	headers.Append("x-ms-deleted-container-name", o.containerName)
+	headers.Append("x-ms-deleted-container-version", o.deletedVersion)
+	return headers
+}
+
+func (o restoreContainerOptions) ToOData() *odata.Query {
+	return nil
+}
+
+func (o restoreContainerOptions) ToQuery() *client.QueryParams {
+	out := &client.QueryParams{}
+	out.Append("restype", "container")
+	out.Append("comp", "undelete")
+	return out
+}
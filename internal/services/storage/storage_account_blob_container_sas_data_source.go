@@ -4,11 +4,8 @@
 package storage
 
 import (
-	"crypto/sha256"
-	"encoding/hex"
 	"time"
 
-	"github.com/hashicorp/go-azure-helpers/storage"
 	"github.com/hashicorp/terraform-provider-azurerm/helpers/validate"
 	storageValidate "github.com/hashicorp/terraform-provider-azurerm/internal/services/storage/validate"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
@@ -150,35 +147,26 @@ func dataSourceStorageContainerSasRead(d *pluginsdk.ResourceData, _ interface{})
 	contentLanguage := d.Get("content_language").(string)
 	contentType := d.Get("content_type").(string)
 
-	permissions := BuildContainerPermissionsString(permissionsIface[0].(map[string]interface{}))
-
-	// Parse the connection string
-	kvp, err := storage.ParseAccountSASConnectionString(connString)
-	if err != nil {
-		return err
-	}
-
-	// Create the string to sign with the key...
-	accountName := kvp[connStringAccountNameKey]
-	accountKey := kvp[connStringAccountKeyKey]
-	signedProtocol := "https,http"
-	if httpsOnly {
-		signedProtocol = "https"
-	}
-	signedIp := ip
-	signedIdentifier := ""
-	signedSnapshotTime := ""
-
-	sasToken, err := storage.ComputeContainerSASToken(permissions, start, expiry, accountName, accountKey,
-		containerName, signedIdentifier, signedIp, signedProtocol, signedSnapshotTime, cacheControl,
-		contentDisposition, contentEncoding, contentLanguage, contentType)
+	sasToken, err := computeContainerSharedAccessSignature(containerSharedAccessSignature{
+		ConnectionString:   connString,
+		ContainerName:      containerName,
+		HttpsOnly:          httpsOnly,
+		IPAddress:          ip,
+		Start:              start,
+		Expiry:             expiry,
+		Permissions:        BuildContainerPermissionsString(permissionsIface[0].(map[string]interface{})),
+		CacheControl:       cacheControl,
+		ContentDisposition: contentDisposition,
+		ContentEncoding:    contentEncoding,
+		ContentLanguage:    contentLanguage,
+		ContentType:        contentType,
+	})
 	if err != nil {
 		return err
 	}
 
 	d.Set("sas", sasToken)
-	tokenHash := sha256.Sum256([]byte(sasToken))
-	d.SetId(hex.EncodeToString(tokenHash[:]))
+	d.SetId(sharedAccessSignatureID(sasToken))
 
 	return nil
 }
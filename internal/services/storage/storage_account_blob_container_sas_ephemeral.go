@@ -0,0 +1,190 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package storage
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-provider-azurerm/helpers/validate"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/sdk"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/sdk/frameworkhelpers"
+	storageValidate "github.com/hashicorp/terraform-provider-azurerm/internal/services/storage/validate"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/validation"
+)
+
+var _ sdk.EphemeralResource = &StorageAccountBlobContainerSasEphemeralResource{}
+
+func NewStorageAccountBlobContainerSasEphemeralResource() ephemeral.EphemeralResource {
+	return &StorageAccountBlobContainerSasEphemeralResource{}
+}
+
+type StorageAccountBlobContainerSasEphemeralResource struct {
+	sdk.EphemeralResourceMetadata
+}
+
+type StorageAccountBlobContainerSasEphemeralResourceModel struct {
+	ConnectionString   types.String `tfsdk:"connection_string"`
+	ContainerName      types.String `tfsdk:"container_name"`
+	HttpsOnly          types.Bool   `tfsdk:"https_only"`
+	IPAddress          types.String `tfsdk:"ip_address"`
+	Start              types.String `tfsdk:"start"`
+	Expiry             types.String `tfsdk:"expiry"`
+	Permissions        types.List   `tfsdk:"permissions"`
+	CacheControl       types.String `tfsdk:"cache_control"`
+	ContentDisposition types.String `tfsdk:"content_disposition"`
+	ContentEncoding    types.String `tfsdk:"content_encoding"`
+	ContentLanguage    types.String `tfsdk:"content_language"`
+	ContentType        types.String `tfsdk:"content_type"`
+	Sas                types.String `tfsdk:"sas"`
+}
+
+type StorageAccountBlobContainerSasPermissionsModel struct {
+	Read   types.Bool `tfsdk:"read"`
+	Add    types.Bool `tfsdk:"add"`
+	Create types.Bool `tfsdk:"create"`
+	Write  types.Bool `tfsdk:"write"`
+	Delete types.Bool `tfsdk:"delete"`
+	List   types.Bool `tfsdk:"list"`
+}
+
+func (e *StorageAccountBlobContainerSasEphemeralResource) Metadata(_ context.Context, _ ephemeral.MetadataRequest, resp *ephemeral.MetadataResponse) {
+	resp.TypeName = "azurerm_storage_account_blob_container_sas"
+}
+
+func (e *StorageAccountBlobContainerSasEphemeralResource) Configure(_ context.Context, req ephemeral.ConfigureRequest, resp *ephemeral.ConfigureResponse) {
+	e.Defaults(req, resp)
+}
+
+func (e *StorageAccountBlobContainerSasEphemeralResource) Schema(_ context.Context, _ ephemeral.SchemaRequest, resp *ephemeral.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Attributes: map[string]schema.Attribute{
+			"connection_string": schema.StringAttribute{
+				Required:  true,
+				Sensitive: true,
+				Validators: []validator.String{
+					frameworkhelpers.WrappedStringValidator{
+						Func: validation.StringIsNotEmpty,
+					},
+				},
+			},
+
+			"container_name": schema.StringAttribute{
+				Required: true,
+				Validators: []validator.String{
+					frameworkhelpers.WrappedStringValidator{
+						Func: validation.StringIsNotEmpty,
+					},
+				},
+			},
+
+			"https_only": schema.BoolAttribute{
+				Optional: true,
+			},
+
+			"ip_address": schema.StringAttribute{
+				Optional: true,
+				Validators: []validator.String{
+					frameworkhelpers.WrappedStringValidator{
+						Func: storageValidate.SharedAccessSignatureIP,
+					},
+				},
+			},
+
+			"start": schema.StringAttribute{
+				Required: true,
+				Validators: []validator.String{
+					frameworkhelpers.WrappedStringValidator{
+						Func: validate.ISO8601DateTime,
+					},
+				},
+			},
+
+			"expiry": schema.StringAttribute{
+				Required: true,
+				Validators: []validator.String{
+					frameworkhelpers.WrappedStringValidator{
+						Func: validate.ISO8601DateTime,
+					},
+				},
+			},
+
+			"cache_control": schema.StringAttribute{
+				Optional: true,
+			},
+
+			"content_disposition": schema.StringAttribute{
+				Optional: true,
+			},
+
+			"content_encoding": schema.StringAttribute{
+				Optional: true,
+			},
+
+			"content_language": schema.StringAttribute{
+				Optional: true,
+			},
+
+			"content_type": schema.StringAttribute{
+				Optional: true,
+			},
+
+			"sas": schema.StringAttribute{
+				Computed:  true,
+				Sensitive: true,
+			},
+		},
+
+		Blocks: map[string]schema.Block{
+			"permissions": sharedAccessSignatureBoolBlock("read", "add", "create", "write", "delete", "list"),
+		},
+	}
+}
+
+func (e *StorageAccountBlobContainerSasEphemeralResource) Open(ctx context.Context, req ephemeral.OpenRequest, resp *ephemeral.OpenResponse) {
+	var data StorageAccountBlobContainerSasEphemeralResourceModel
+
+	if ok := e.DecodeOpen(ctx, req, resp, &data); !ok {
+		return
+	}
+
+	var permissions []StorageAccountBlobContainerSasPermissionsModel
+	resp.Diagnostics.Append(data.Permissions.ElementsAs(ctx, &permissions, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	sasToken, err := computeContainerSharedAccessSignature(containerSharedAccessSignature{
+		ConnectionString: data.ConnectionString.ValueString(),
+		ContainerName:    data.ContainerName.ValueString(),
+		HttpsOnly:        data.HttpsOnly.IsNull() || data.HttpsOnly.ValueBool(),
+		IPAddress:        data.IPAddress.ValueString(),
+		Start:            data.Start.ValueString(),
+		Expiry:           data.Expiry.ValueString(),
+		Permissions: BuildContainerPermissionsString(map[string]interface{}{
+			"read":   permissions[0].Read.ValueBool(),
+			"add":    permissions[0].Add.ValueBool(),
+			"create": permissions[0].Create.ValueBool(),
+			"write":  permissions[0].Write.ValueBool(),
+			"delete": permissions[0].Delete.ValueBool(),
+			"list":   permissions[0].List.ValueBool(),
+		}),
+		CacheControl:       data.CacheControl.ValueString(),
+		ContentDisposition: data.ContentDisposition.ValueString(),
+		ContentEncoding:    data.ContentEncoding.ValueString(),
+		ContentLanguage:    data.ContentLanguage.ValueString(),
+		ContentType:        data.ContentType.ValueString(),
+	})
+	if err != nil {
+		sdk.SetResponseErrorDiagnostic(resp, "computing Blob Container Shared Access Signature", err)
+		return
+	}
+
+	data.Sas = types.StringValue(sasToken)
+
+	resp.Diagnostics.Append(resp.Result.Set(ctx, &data)...)
+}
@@ -4,13 +4,9 @@
 package storage
 
 import (
-	"crypto/sha256"
-	"encoding/hex"
 	"time"
 
-	"github.com/hashicorp/go-azure-helpers/storage"
 	"github.com/hashicorp/terraform-provider-azurerm/helpers/validate"
-	"github.com/hashicorp/terraform-provider-azurerm/internal/features"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/validation"
 )
@@ -23,11 +19,6 @@ const (
 // This is an ACCOUNT SAS : https://docs.microsoft.com/en-us/rest/api/storageservices/Constructing-an-Account-SAS
 // not Service SAS
 func dataSourceStorageAccountSharedAccessSignature() *pluginsdk.Resource {
-	sasSignedVersion := "2017-07-29"
-	if features.FourPointOhBeta() {
-		// TODO: Update the document as well
-		sasSignedVersion = "2022-11-02"
-	}
 	return &pluginsdk.Resource{
 		Read: dataSourceStorageAccountSasRead,
 
@@ -60,7 +51,7 @@ func dataSourceStorageAccountSharedAccessSignature() *pluginsdk.Resource {
 			"signed_version": {
 				Type:     pluginsdk.TypeString,
 				Optional: true,
-				Default:  sasSignedVersion,
+				Default:  defaultAccountSharedAccessSignatureSignedVersion(),
 			},
 
 			"resource_types": {
@@ -209,39 +200,23 @@ func dataSourceStorageAccountSasRead(d *pluginsdk.ResourceData, _ interface{}) e
 	expiry := d.Get("expiry").(string)
 	permissionsIface := d.Get("permissions").([]interface{})
 
-	resourceTypes := BuildResourceTypesString(resourceTypesIface[0].(map[string]interface{}))
-	services := BuildServicesString(servicesIface[0].(map[string]interface{}))
-	permissions := BuildPermissionsString(permissionsIface[0].(map[string]interface{}))
-
-	// Parse the connection string
-	kvp, err := storage.ParseAccountSASConnectionString(connString)
-	if err != nil {
-		return err
-	}
-
-	// Create the string to sign with the key...
-
-	// Details on how to do this are here:
-	// https://docs.microsoft.com/en-us/rest/api/storageservices/Constructing-an-Account-SAS
-	accountName := kvp[connStringAccountNameKey]
-	accountKey := kvp[connStringAccountKeyKey]
-	signedProtocol := "https,http"
-	if httpsOnly {
-		signedProtocol = "https"
-	}
-
-	// TODO: implement support for signedEncryptionScope
-	signedEncryptionScope := ""
-
-	sasToken, err := storage.ComputeAccountSASToken(accountName, accountKey, permissions, services, resourceTypes,
-		start, expiry, signedProtocol, ipAddresses, signedVersion, signedEncryptionScope)
+	sasToken, err := computeAccountSharedAccessSignature(accountSharedAccessSignature{
+		ConnectionString: connString,
+		HttpsOnly:        httpsOnly,
+		IPAddresses:      ipAddresses,
+		SignedVersion:    signedVersion,
+		ResourceTypes:    BuildResourceTypesString(resourceTypesIface[0].(map[string]interface{})),
+		Services:         BuildServicesString(servicesIface[0].(map[string]interface{})),
+		Start:            start,
+		Expiry:           expiry,
+		Permissions:      BuildPermissionsString(permissionsIface[0].(map[string]interface{})),
+	})
 	if err != nil {
 		return err
 	}
 
 	d.Set("sas", sasToken)
-	tokenHash := sha256.Sum256([]byte(sasToken))
-	d.SetId(hex.EncodeToString(tokenHash[:]))
+	d.SetId(sharedAccessSignatureID(sasToken))
 
 	return nil
 }
@@ -0,0 +1,228 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package storage
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-provider-azurerm/helpers/validate"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/sdk"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/sdk/frameworkhelpers"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/validation"
+)
+
+var _ sdk.EphemeralResource = &StorageAccountSasEphemeralResource{}
+
+func NewStorageAccountSasEphemeralResource() ephemeral.EphemeralResource {
+	return &StorageAccountSasEphemeralResource{}
+}
+
+type StorageAccountSasEphemeralResource struct {
+	sdk.EphemeralResourceMetadata
+}
+
+type StorageAccountSasEphemeralResourceModel struct {
+	ConnectionString types.String `tfsdk:"connection_string"`
+	HttpsOnly        types.Bool   `tfsdk:"https_only"`
+	IPAddresses      types.String `tfsdk:"ip_addresses"`
+	SignedVersion    types.String `tfsdk:"signed_version"`
+	ResourceTypes    types.List   `tfsdk:"resource_types"`
+	Services         types.List   `tfsdk:"services"`
+	Start            types.String `tfsdk:"start"`
+	Expiry           types.String `tfsdk:"expiry"`
+	Permissions      types.List   `tfsdk:"permissions"`
+	Sas              types.String `tfsdk:"sas"`
+}
+
+type StorageAccountSasResourceTypesModel struct {
+	Service   types.Bool `tfsdk:"service"`
+	Container types.Bool `tfsdk:"container"`
+	Object    types.Bool `tfsdk:"object"`
+}
+
+type StorageAccountSasServicesModel struct {
+	Blob  types.Bool `tfsdk:"blob"`
+	Queue types.Bool `tfsdk:"queue"`
+	Table types.Bool `tfsdk:"table"`
+	File  types.Bool `tfsdk:"file"`
+}
+
+type StorageAccountSasPermissionsModel struct {
+	Read    types.Bool `tfsdk:"read"`
+	Write   types.Bool `tfsdk:"write"`
+	Delete  types.Bool `tfsdk:"delete"`
+	List    types.Bool `tfsdk:"list"`
+	Add     types.Bool `tfsdk:"add"`
+	Create  types.Bool `tfsdk:"create"`
+	Update  types.Bool `tfsdk:"update"`
+	Process types.Bool `tfsdk:"process"`
+	Tag     types.Bool `tfsdk:"tag"`
+	Filter  types.Bool `tfsdk:"filter"`
+}
+
+func (e *StorageAccountSasEphemeralResource) Metadata(_ context.Context, _ ephemeral.MetadataRequest, resp *ephemeral.MetadataResponse) {
+	resp.TypeName = "azurerm_storage_account_sas"
+}
+
+func (e *StorageAccountSasEphemeralResource) Configure(_ context.Context, req ephemeral.ConfigureRequest, resp *ephemeral.ConfigureResponse) {
+	e.Defaults(req, resp)
+}
+
+func (e *StorageAccountSasEphemeralResource) Schema(_ context.Context, _ ephemeral.SchemaRequest, resp *ephemeral.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Attributes: map[string]schema.Attribute{
+			"connection_string": schema.StringAttribute{
+				Required:  true,
+				Sensitive: true,
+				Validators: []validator.String{
+					frameworkhelpers.WrappedStringValidator{
+						Func: validation.StringIsNotEmpty,
+					},
+				},
+			},
+
+			"https_only": schema.BoolAttribute{
+				Optional: true,
+			},
+
+			"ip_addresses": schema.StringAttribute{
+				Optional: true,
+				Validators: []validator.String{
+					frameworkhelpers.WrappedStringValidator{
+						Func: validation.Any(
+							validation.IsIPv4Address,
+							validation.IsIPv4Range,
+						),
+					},
+				},
+			},
+
+			"signed_version": schema.StringAttribute{
+				Optional: true,
+				Computed: true,
+			},
+
+			// Always in UTC and must be ISO-8601 format
+			"start": schema.StringAttribute{
+				Required: true,
+				Validators: []validator.String{
+					frameworkhelpers.WrappedStringValidator{
+						Func: validate.ISO8601DateTime,
+					},
+				},
+			},
+
+			// Always in UTC and must be ISO-8601 format
+			"expiry": schema.StringAttribute{
+				Required: true,
+				Validators: []validator.String{
+					frameworkhelpers.WrappedStringValidator{
+						Func: validate.ISO8601DateTime,
+					},
+				},
+			},
+
+			"sas": schema.StringAttribute{
+				Computed:  true,
+				Sensitive: true,
+			},
+		},
+
+		Blocks: map[string]schema.Block{
+			"resource_types": sharedAccessSignatureBoolBlock("service", "container", "object"),
+
+			"services": sharedAccessSignatureBoolBlock("blob", "queue", "table", "file"),
+
+			"permissions": sharedAccessSignatureBoolBlock("read", "write", "delete", "list", "add", "create", "update", "process", "tag", "filter"),
+		},
+	}
+}
+
+func (e *StorageAccountSasEphemeralResource) Open(ctx context.Context, req ephemeral.OpenRequest, resp *ephemeral.OpenResponse) {
+	var data StorageAccountSasEphemeralResourceModel
+
+	if ok := e.DecodeOpen(ctx, req, resp, &data); !ok {
+		return
+	}
+
+	var resourceTypes []StorageAccountSasResourceTypesModel
+	resp.Diagnostics.Append(data.ResourceTypes.ElementsAs(ctx, &resourceTypes, false)...)
+	var services []StorageAccountSasServicesModel
+	resp.Diagnostics.Append(data.Services.ElementsAs(ctx, &services, false)...)
+	var permissions []StorageAccountSasPermissionsModel
+	resp.Diagnostics.Append(data.Permissions.ElementsAs(ctx, &permissions, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if data.SignedVersion.IsNull() || data.SignedVersion.IsUnknown() {
+		data.SignedVersion = types.StringValue(defaultAccountSharedAccessSignatureSignedVersion())
+	}
+
+	sasToken, err := computeAccountSharedAccessSignature(accountSharedAccessSignature{
+		ConnectionString: data.ConnectionString.ValueString(),
+		HttpsOnly:        data.HttpsOnly.IsNull() || data.HttpsOnly.ValueBool(),
+		IPAddresses:      data.IPAddresses.ValueString(),
+		SignedVersion:    data.SignedVersion.ValueString(),
+		ResourceTypes: BuildResourceTypesString(map[string]interface{}{
+			"service":   resourceTypes[0].Service.ValueBool(),
+			"container": resourceTypes[0].Container.ValueBool(),
+			"object":    resourceTypes[0].Object.ValueBool(),
+		}),
+		Services: BuildServicesString(map[string]interface{}{
+			"blob":  services[0].Blob.ValueBool(),
+			"queue": services[0].Queue.ValueBool(),
+			"table": services[0].Table.ValueBool(),
+			"file":  services[0].File.ValueBool(),
+		}),
+		Start:  data.Start.ValueString(),
+		Expiry: data.Expiry.ValueString(),
+		Permissions: BuildPermissionsString(map[string]interface{}{
+			"read":    permissions[0].Read.ValueBool(),
+			"write":   permissions[0].Write.ValueBool(),
+			"delete":  permissions[0].Delete.ValueBool(),
+			"list":    permissions[0].List.ValueBool(),
+			"add":     permissions[0].Add.ValueBool(),
+			"create":  permissions[0].Create.ValueBool(),
+			"update":  permissions[0].Update.ValueBool(),
+			"process": permissions[0].Process.ValueBool(),
+			"tag":     permissions[0].Tag.ValueBool(),
+			"filter":  permissions[0].Filter.ValueBool(),
+		}),
+	})
+	if err != nil {
+		sdk.SetResponseErrorDiagnostic(resp, "computing Account Shared Access Signature", err)
+		return
+	}
+
+	data.Sas = types.StringValue(sasToken)
+
+	resp.Diagnostics.Append(resp.Result.Set(ctx, &data)...)
+}
+
+// sharedAccessSignatureBoolBlock returns a required block containing an optional boolean attribute for each of the specified names,
+// which are treated as `false` when omitted
+func sharedAccessSignatureBoolBlock(names ...string) schema.ListNestedBlock {
+	attributes := make(map[string]schema.Attribute, len(names))
+	for _, name := range names {
+		attributes[name] = schema.BoolAttribute{
+			Optional: true,
+		}
+	}
+
+	return schema.ListNestedBlock{
+		Validators: []validator.List{
+			listvalidator.IsRequired(),
+			listvalidator.SizeBetween(1, 1),
+		},
+		NestedObject: schema.NestedBlockObject{
+			Attributes: attributes,
+		},
+	}
+}
@@ -0,0 +1,204 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package storage
+
+import (
+	"crypto/md5"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"io/fs"
+	"mime"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+)
+
+// storageBlobDirectoryFile is a file within the source directory which should be uploaded as a blob
+type storageBlobDirectoryFile struct {
+	// Path is the path to the file on disk
+	Path string
+
+	// ContentMD5 is the hex encoded MD5 hash of the contents of the file
+	ContentMD5 string
+}
+
+// storageBlobDirectoryFiles returns the files within the source directory matching the include and exclude patterns,
+// keyed by the name of the blob each file should be uploaded as
+func storageBlobDirectoryFiles(sourceDirectory, prefix string, include, exclude []string) (map[string]storageBlobDirectoryFile, error) {
+	files := make(map[string]storageBlobDirectoryFile)
+
+	err := filepath.WalkDir(sourceDirectory, func(filePath string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if entry.IsDir() {
+			return nil
+		}
+
+		relativePath, err := filepath.Rel(sourceDirectory, filePath)
+		if err != nil {
+			return err
+		}
+		relativePath = filepath.ToSlash(relativePath)
+
+		matched, err := storageBlobDirectoryShouldInclude(relativePath, include, exclude)
+		if err != nil {
+			return err
+		}
+		if !matched {
+			return nil
+		}
+
+		// symlinks are followed, but anything else which isn't a regular file (e.g. a named pipe) is skipped
+		info, err := os.Stat(filePath)
+		if err != nil {
+			return err
+		}
+		if !info.Mode().IsRegular() {
+			return nil
+		}
+
+		contentMD5, err := storageBlobDirectoryFileMD5(filePath)
+		if err != nil {
+			return fmt.Errorf("hashing %q: %+v", filePath, err)
+		}
+
+		files[storageBlobDirectoryBlobName(prefix, relativePath)] = storageBlobDirectoryFile{
+			Path:       filePath,
+			ContentMD5: contentMD5,
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("reading the source directory %q: %+v", sourceDirectory, err)
+	}
+
+	return files, nil
+}
+
+func storageBlobDirectoryFileMD5(filePath string) (string, error) {
+	file, err := os.Open(filePath)
+	if err != nil {
+		return "", err
+	}
+	defer file.Close()
+
+	hash := md5.New()
+	if _, err := io.Copy(hash, file); err != nil {
+		return "", err
+	}
+
+	return hex.EncodeToString(hash.Sum(nil)), nil
+}
+
+func storageBlobDirectoryBlobName(prefix, relativePath string) string {
+	if prefix == "" {
+		return relativePath
+	}
+	return fmt.Sprintf("%s/%s", prefix, relativePath)
+}
+
+// storageBlobDirectoryShouldInclude returns whether the file at the (slash separated) relative path matches at least
+// one of the include patterns (when any are specified) and none of the exclude patterns
+func storageBlobDirectoryShouldInclude(relativePath string, include, exclude []string) (bool, error) {
+	for _, pattern := range exclude {
+		matched, err := storageBlobDirectoryMatch(pattern, relativePath)
+		if err != nil {
+			return false, err
+		}
+		if matched {
+			return false, nil
+		}
+	}
+
+	if len(include) == 0 {
+		return true, nil
+	}
+
+	for _, pattern := range include {
+		matched, err := storageBlobDirectoryMatch(pattern, relativePath)
+		if err != nil {
+			return false, err
+		}
+		if matched {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+// storageBlobDirectoryMatch matches a slash separated path against a glob pattern, supporting the syntax of `path.Match`
+// within each segment in addition to `**` - which matches zero or more directories
+func storageBlobDirectoryMatch(pattern, name string) (bool, error) {
+	return storageBlobDirectoryMatchSegments(strings.Split(pattern, "/"), strings.Split(name, "/"))
+}
+
+func storageBlobDirectoryMatchSegments(pattern, name []string) (bool, error) {
+	for len(pattern) > 0 {
+		if pattern[0] == "**" {
+			for i := 0; i <= len(name); i++ {
+				matched, err := storageBlobDirectoryMatchSegments(pattern[1:], name[i:])
+				if err != nil || matched {
+					return matched, err
+				}
+			}
+			return false, nil
+		}
+
+		if len(name) == 0 {
+			return false, nil
+		}
+
+		matched, err := path.Match(pattern[0], name[0])
+		if err != nil || !matched {
+			return false, err
+		}
+
+		pattern = pattern[1:]
+		name = name[1:]
+	}
+
+	return len(name) == 0, nil
+}
+
+// storageBlobDirectoryContentType returns the content type for the blob based on its file extension, using the
+// mapping specified in `content_types` before falling back to the well-known content type for the extension
+func storageBlobDirectoryContentType(blobName string, contentTypes map[string]string) string {
+	extension := strings.ToLower(path.Ext(blobName))
+
+	for k, v := range contentTypes {
+		if strings.EqualFold(k, extension) || strings.EqualFold(fmt.Sprintf(".%s", k), extension) {
+			return v
+		}
+	}
+
+	if contentType := mime.TypeByExtension(extension); extension != "" && contentType != "" {
+		return contentType
+	}
+
+	return "application/octet-stream"
+}
+
+// validateStorageBlobDirectoryPattern validates the glob pattern used to include or exclude files
+func validateStorageBlobDirectoryPattern(input interface{}, key string) (warnings []string, errors []error) {
+	v, ok := input.(string)
+	if !ok {
+		errors = append(errors, fmt.Errorf("expected %q to be a string", key))
+		return
+	}
+
+	if v == "" {
+		errors = append(errors, fmt.Errorf("%q must not be empty", key))
+		return
+	}
+
+	if _, err := storageBlobDirectoryMatch(v, v); err != nil {
+		errors = append(errors, fmt.Errorf("%q is not a valid pattern: %+v", key, err))
+	}
+
+	return
+}
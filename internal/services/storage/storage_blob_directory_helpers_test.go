@@ -0,0 +1,98 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package storage
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestStorageBlobDirectoryMatch(t *testing.T) {
+	testcases := []struct {
+		Pattern  string
+		Name     string
+		Expected bool
+	}{
+		{Pattern: "*.html", Name: "index.html", Expected: true},
+		{Pattern: "*.html", Name: "docs/index.html", Expected: false},
+		{Pattern: "**/*.html", Name: "index.html", Expected: true},
+		{Pattern: "**/*.html", Name: "docs/guides/index.html", Expected: true},
+		{Pattern: "docs/**", Name: "docs/guides/index.html", Expected: true},
+		{Pattern: "docs/**", Name: "images/logo.png", Expected: false},
+		{Pattern: "docs/**/*.md", Name: "docs/readme.md", Expected: true},
+		{Pattern: "docs/**/*.md", Name: "docs/readme.txt", Expected: false},
+		{Pattern: "**/.git/**", Name: "sub/.git/config", Expected: true},
+		{Pattern: "images/logo.???", Name: "images/logo.png", Expected: true},
+	}
+
+	for _, tc := range testcases {
+		actual, err := storageBlobDirectoryMatch(tc.Pattern, tc.Name)
+		if err != nil {
+			t.Fatalf("matching %q against %q: %+v", tc.Name, tc.Pattern, err)
+		}
+		if actual != tc.Expected {
+			t.Errorf("expected %q matching %q to be %t but got %t", tc.Name, tc.Pattern, tc.Expected, actual)
+		}
+	}
+}
+
+func TestStorageBlobDirectoryContentType(t *testing.T) {
+	testcases := []struct {
+		Name         string
+		ContentTypes map[string]string
+		Expected     string
+	}{
+		{Name: "index.html", Expected: "text/html; charset=utf-8"},
+		{Name: "data/config.JSON", Expected: "application/json"},
+		{Name: "LICENSE", Expected: "application/octet-stream"},
+		{Name: "app.wasm", ContentTypes: map[string]string{".wasm": "application/wasm"}, Expected: "application/wasm"},
+		{Name: "page.html", ContentTypes: map[string]string{"html": "text/html"}, Expected: "text/html"},
+	}
+
+	for _, tc := range testcases {
+		if actual := storageBlobDirectoryContentType(tc.Name, tc.ContentTypes); actual != tc.Expected {
+			t.Errorf("expected the content type for %q to be %q but got %q", tc.Name, tc.Expected, actual)
+		}
+	}
+}
+
+func TestStorageBlobDirectoryFiles(t *testing.T) {
+	dir := t.TempDir()
+	for name, contents := range map[string]string{
+		"index.html":       "hello",
+		"css/site.css":     "body {}",
+		"drafts/todo.html": "wip",
+	} {
+		path := filepath.Join(dir, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(contents), 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	files, err := storageBlobDirectoryFiles(dir, "site", []string{"**/*.html", "**/*.css"}, []string{"drafts/**"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := map[string]string{
+		"site/index.html":   "5d41402abc4b2a76b9719d911017c592",
+		"site/css/site.css": "fcdce6b6d6e2175f6406869882f6f1ce",
+	}
+	if len(files) != len(expected) {
+		t.Fatalf("expected %d files but got %d: %+v", len(expected), len(files), files)
+	}
+	for name, contentMD5 := range expected {
+		file, ok := files[name]
+		if !ok {
+			t.Fatalf("expected %q to be present in %+v", name, files)
+		}
+		if file.ContentMD5 != contentMD5 {
+			t.Errorf("expected the Content MD5 for %q to be %q but got %q", name, contentMD5, file.ContentMD5)
+		}
+	}
+}
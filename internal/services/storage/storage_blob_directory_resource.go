@@ -0,0 +1,620 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package storage
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/go-azure-helpers/lang/pointer"
+	"github.com/hashicorp/go-azure-helpers/lang/response"
+	"github.com/hashicorp/go-azure-helpers/resourcemanager/commonids"
+	"github.com/hashicorp/go-azure-helpers/resourcemanager/resourceids"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/sdk"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/validation"
+	"github.com/tombuildsstuff/giovanni/storage/2023-11-03/blob/blobs"
+	"golang.org/x/sync/errgroup"
+)
+
+type StorageBlobDirectoryResource struct{}
+
+var (
+	_ sdk.ResourceWithUpdate        = StorageBlobDirectoryResource{}
+	_ sdk.ResourceWithCustomizeDiff = StorageBlobDirectoryResource{}
+)
+
+type StorageBlobDirectoryResourceModel struct {
+	StorageContainerId string            `tfschema:"storage_container_id"`
+	Prefix             string            `tfschema:"prefix"`
+	SourceDirectory    string            `tfschema:"source_directory"`
+	Include            []string          `tfschema:"include"`
+	Exclude            []string          `tfschema:"exclude"`
+	ContentTypes       map[string]string `tfschema:"content_types"`
+	CacheControl       string            `tfschema:"cache_control"`
+	Parallelism        int64             `tfschema:"parallelism"`
+	Files              map[string]string `tfschema:"files"`
+}
+
+func (r StorageBlobDirectoryResource) Arguments() map[string]*pluginsdk.Schema {
+	return map[string]*pluginsdk.Schema{
+		"storage_container_id": {
+			Type:         pluginsdk.TypeString,
+			Required:     true,
+			ForceNew:     true,
+			ValidateFunc: commonids.ValidateStorageContainerID,
+		},
+
+		"source_directory": {
+			Type:         pluginsdk.TypeString,
+			Required:     true,
+			ValidateFunc: validation.StringIsNotEmpty,
+		},
+
+		"prefix": {
+			Type:     pluginsdk.TypeString,
+			Optional: true,
+			ForceNew: true,
+			ValidateFunc: validation.StringMatch(
+				regexp.MustCompile(`^[^/](.*[^/])?$`),
+				"`prefix` must not start or end with a `/`",
+			),
+		},
+
+		"include": {
+			Type:     pluginsdk.TypeSet,
+			Optional: true,
+			Elem: &pluginsdk.Schema{
+				Type:         pluginsdk.TypeString,
+				ValidateFunc: validateStorageBlobDirectoryPattern,
+			},
+		},
+
+		"exclude": {
+			Type:     pluginsdk.TypeSet,
+			Optional: true,
+			Elem: &pluginsdk.Schema{
+				Type:         pluginsdk.TypeString,
+				ValidateFunc: validateStorageBlobDirectoryPattern,
+			},
+		},
+
+		"content_types": {
+			Type:     pluginsdk.TypeMap,
+			Optional: true,
+			Elem: &pluginsdk.Schema{
+				Type:         pluginsdk.TypeString,
+				ValidateFunc: validation.StringIsNotEmpty,
+			},
+		},
+
+		"cache_control": {
+			Type:         pluginsdk.TypeString,
+			Optional:     true,
+			ValidateFunc: validation.StringIsNotEmpty,
+		},
+
+		"parallelism": {
+			Type:         pluginsdk.TypeInt,
+			Optional:     true,
+			Default:      8,
+			ValidateFunc: validation.IntAtLeast(1),
+		},
+	}
+}
+
+func (r StorageBlobDirectoryResource) Attributes() map[string]*pluginsdk.Schema {
+	return map[string]*pluginsdk.Schema{
+		"files": {
+			Type:     pluginsdk.TypeMap,
+			Computed: true,
+			Elem: &pluginsdk.Schema{
+				Type: pluginsdk.TypeString,
+			},
+		},
+	}
+}
+
+func (r StorageBlobDirectoryResource) ModelObject() interface{} {
+	return &StorageBlobDirectoryResourceModel{}
+}
+
+func (r StorageBlobDirectoryResource) ResourceType() string {
+	return "azurerm_storage_blob_directory"
+}
+
+func (r StorageBlobDirectoryResource) IDValidationFunc() pluginsdk.SchemaValidateFunc {
+	return validateStorageBlobDirectoryID
+}
+
+func (r StorageBlobDirectoryResource) Create() sdk.ResourceFunc {
+	return sdk.ResourceFunc{
+		Timeout: 60 * time.Minute,
+		Func: func(ctx context.Context, metadata sdk.ResourceMetaData) error {
+			storageClient := metadata.Client.Storage
+
+			var model StorageBlobDirectoryResourceModel
+			if err := metadata.Decode(&model); err != nil {
+				return fmt.Errorf("decoding: %+v", err)
+			}
+
+			containerId, err := commonids.ParseStorageContainerID(model.StorageContainerId)
+			if err != nil {
+				return err
+			}
+
+			id := NewStorageBlobDirectoryID(*containerId, model.Prefix)
+
+			account, err := storageClient.FindAccount(ctx, containerId.SubscriptionId, containerId.StorageAccountName)
+			if err != nil {
+				return fmt.Errorf("retrieving Account %q for %s: %+v", containerId.StorageAccountName, id, err)
+			}
+			if account == nil {
+				return fmt.Errorf("locating Storage Account %q", containerId.StorageAccountName)
+			}
+
+			containersClient, err := storageClient.ContainersDataPlaneClient(ctx, *account, storageClient.DataPlaneOperationSupportingAnyAuthMethod())
+			if err != nil {
+				return fmt.Errorf("building Containers Client: %+v", err)
+			}
+
+			// any existing blobs with the prefix would otherwise be overwritten and then managed by both resources
+			listPrefix := ""
+			if model.Prefix != "" {
+				listPrefix = fmt.Sprintf("%s/", model.Prefix)
+			}
+			existing, err := containersClient.ListBlobs(ctx, containerId.ContainerName, listPrefix)
+			if err != nil {
+				return fmt.Errorf("checking for existing %s: %+v", id, err)
+			}
+			if existing == nil {
+				return fmt.Errorf("%s was not found", containerId)
+			}
+			if len(*existing) > 0 {
+				return metadata.ResourceRequiresImport(r.ResourceType(), id)
+			}
+
+			blobsClient, err := storageClient.BlobsDataPlaneClient(ctx, *account, storageClient.DataPlaneOperationSupportingAnyAuthMethod())
+			if err != nil {
+				return fmt.Errorf("building Blobs Client: %+v", err)
+			}
+
+			files, err := storageBlobDirectoryFiles(model.SourceDirectory, model.Prefix, model.Include, model.Exclude)
+			if err != nil {
+				return err
+			}
+
+			uploader := storageBlobDirectoryUploader{
+				client:        blobsClient,
+				accountName:   containerId.StorageAccountName,
+				containerName: containerId.ContainerName,
+				cacheControl:  model.CacheControl,
+				contentTypes:  model.ContentTypes,
+				parallelism:   int(model.Parallelism),
+			}
+			if err := uploader.upload(ctx, files, storageBlobDirectorySortedNames(files)); err != nil {
+				return fmt.Errorf("uploading files for %s: %+v", id, err)
+			}
+
+			if err := metadata.ResourceData.Set("files", flattenStorageBlobDirectoryFiles(files)); err != nil {
+				return fmt.Errorf("setting `files`: %+v", err)
+			}
+
+			metadata.SetID(id)
+			return nil
+		},
+	}
+}
+
+func (r StorageBlobDirectoryResource) Read() sdk.ResourceFunc {
+	return sdk.ResourceFunc{
+		Timeout: 5 * time.Minute,
+		Func: func(ctx context.Context, metadata sdk.ResourceMetaData) error {
+			storageClient := metadata.Client.Storage
+
+			id, err := ParseStorageBlobDirectoryID(metadata.ResourceData.Id())
+			if err != nil {
+				return err
+			}
+
+			var state StorageBlobDirectoryResourceModel
+			if err := metadata.Decode(&state); err != nil {
+				return fmt.Errorf("decoding: %+v", err)
+			}
+
+			account, err := storageClient.FindAccount(ctx, id.ContainerId.SubscriptionId, id.ContainerId.StorageAccountName)
+			if err != nil {
+				return fmt.Errorf("retrieving Account %q for %s: %+v", id.ContainerId.StorageAccountName, id, err)
+			}
+			if account == nil {
+				log.Printf("[DEBUG] Unable to locate Account %q for %s - assuming removed & removing from state", id.ContainerId.StorageAccountName, id)
+				return metadata.MarkAsGone(id)
+			}
+
+			containersClient, err := storageClient.ContainersDataPlaneClient(ctx, *account, storageClient.DataPlaneOperationSupportingAnyAuthMethod())
+			if err != nil {
+				return fmt.Errorf("building Containers Client: %+v", err)
+			}
+
+			listPrefix := ""
+			if id.Prefix != "" {
+				listPrefix = fmt.Sprintf("%s/", id.Prefix)
+			}
+			existing, err := containersClient.ListBlobs(ctx, id.ContainerId.ContainerName, listPrefix)
+			if err != nil {
+				return fmt.Errorf("listing blobs for %s: %+v", id, err)
+			}
+			if existing == nil {
+				log.Printf("[DEBUG] %s was not found - assuming removed & removing from state", id.ContainerId)
+				return metadata.MarkAsGone(id)
+			}
+
+			remote := make(map[string]string)
+			for _, blob := range *existing {
+				contentMD5 := ""
+				if blob.Properties != nil {
+					if v := pointer.From(blob.Properties.ContentMD5); v != "" {
+						if contentMD5, err = convertBase64ToHexEncoding(v); err != nil {
+							return fmt.Errorf("converting the Content MD5 for the blob %q: %+v", blob.Name, err)
+						}
+					}
+				}
+				remote[blob.Name] = contentMD5
+			}
+
+			files := make(map[string]string)
+			if state.SourceDirectory == "" {
+				// when importing, all the blobs with the prefix are adopted - any which aren't present in the source
+				// directory are removed during the next apply
+				files = remote
+				state.Parallelism = 8
+			} else {
+				// otherwise only the blobs managed by this resource are tracked, any other blobs sharing the prefix are left as-is
+				for name := range state.Files {
+					if contentMD5, ok := remote[name]; ok {
+						files[name] = contentMD5
+					}
+				}
+			}
+
+			state.StorageContainerId = id.ContainerId.ID()
+			state.Prefix = id.Prefix
+			state.Files = files
+
+			return metadata.Encode(&state)
+		},
+	}
+}
+
+func (r StorageBlobDirectoryResource) Update() sdk.ResourceFunc {
+	return sdk.ResourceFunc{
+		Timeout: 60 * time.Minute,
+		Func: func(ctx context.Context, metadata sdk.ResourceMetaData) error {
+			storageClient := metadata.Client.Storage
+
+			id, err := ParseStorageBlobDirectoryID(metadata.ResourceData.Id())
+			if err != nil {
+				return err
+			}
+
+			var model StorageBlobDirectoryResourceModel
+			if err := metadata.Decode(&model); err != nil {
+				return fmt.Errorf("decoding: %+v", err)
+			}
+
+			account, err := storageClient.FindAccount(ctx, id.ContainerId.SubscriptionId, id.ContainerId.StorageAccountName)
+			if err != nil {
+				return fmt.Errorf("retrieving Account %q for %s: %+v", id.ContainerId.StorageAccountName, id, err)
+			}
+			if account == nil {
+				return fmt.Errorf("locating Storage Account %q", id.ContainerId.StorageAccountName)
+			}
+
+			blobsClient, err := storageClient.BlobsDataPlaneClient(ctx, *account, storageClient.DataPlaneOperationSupportingAnyAuthMethod())
+			if err != nil {
+				return fmt.Errorf("building Blobs Client: %+v", err)
+			}
+
+			files, err := storageBlobDirectoryFiles(model.SourceDirectory, id.Prefix, model.Include, model.Exclude)
+			if err != nil {
+				return err
+			}
+
+			oldFilesRaw, _ := metadata.ResourceData.GetChange("files")
+			oldFiles := oldFilesRaw.(map[string]interface{})
+
+			// the properties of every blob need updating when the content type mapping or cache control changes, otherwise
+			// only the files which are new or whose contents have changed are uploaded
+			uploadAll := metadata.ResourceData.HasChanges("content_types", "cache_control")
+			toUpload := make([]string, 0)
+			for _, name := range storageBlobDirectorySortedNames(files) {
+				if existing, ok := oldFiles[name]; uploadAll || !ok || existing.(string) != files[name].ContentMD5 {
+					toUpload = append(toUpload, name)
+				}
+			}
+
+			uploader := storageBlobDirectoryUploader{
+				client:        blobsClient,
+				accountName:   id.ContainerId.StorageAccountName,
+				containerName: id.ContainerId.ContainerName,
+				cacheControl:  model.CacheControl,
+				contentTypes:  model.ContentTypes,
+				parallelism:   int(model.Parallelism),
+			}
+			if err := uploader.upload(ctx, files, toUpload); err != nil {
+				return fmt.Errorf("uploading files for %s: %+v", id, err)
+			}
+
+			toDelete := make([]string, 0)
+			for name := range oldFiles {
+				if _, ok := files[name]; !ok {
+					toDelete = append(toDelete, name)
+				}
+			}
+			sort.Strings(toDelete)
+			if err := uploader.delete(ctx, toDelete); err != nil {
+				return fmt.Errorf("deleting removed files for %s: %+v", id, err)
+			}
+
+			if err := metadata.ResourceData.Set("files", flattenStorageBlobDirectoryFiles(files)); err != nil {
+				return fmt.Errorf("setting `files`: %+v", err)
+			}
+
+			return nil
+		},
+	}
+}
+
+func (r StorageBlobDirectoryResource) Delete() sdk.ResourceFunc {
+	return sdk.ResourceFunc{
+		Timeout: 60 * time.Minute,
+		Func: func(ctx context.Context, metadata sdk.ResourceMetaData) error {
+			storageClient := metadata.Client.Storage
+
+			id, err := ParseStorageBlobDirectoryID(metadata.ResourceData.Id())
+			if err != nil {
+				return err
+			}
+
+			var state StorageBlobDirectoryResourceModel
+			if err := metadata.Decode(&state); err != nil {
+				return fmt.Errorf("decoding: %+v", err)
+			}
+
+			account, err := storageClient.FindAccount(ctx, id.ContainerId.SubscriptionId, id.ContainerId.StorageAccountName)
+			if err != nil {
+				return fmt.Errorf("retrieving Account %q for %s: %+v", id.ContainerId.StorageAccountName, id, err)
+			}
+			if account == nil {
+				return nil
+			}
+
+			blobsClient, err := storageClient.BlobsDataPlaneClient(ctx, *account, storageClient.DataPlaneOperationSupportingAnyAuthMethod())
+			if err != nil {
+				return fmt.Errorf("building Blobs Client: %+v", err)
+			}
+
+			names := make([]string, 0, len(state.Files))
+			for name := range state.Files {
+				names = append(names, name)
+			}
+			sort.Strings(names)
+
+			uploader := storageBlobDirectoryUploader{
+				client:        blobsClient,
+				accountName:   id.ContainerId.StorageAccountName,
+				containerName: id.ContainerId.ContainerName,
+				parallelism:   int(state.Parallelism),
+			}
+			if err := uploader.delete(ctx, names); err != nil {
+				return fmt.Errorf("deleting %s: %+v", id, err)
+			}
+
+			return nil
+		},
+	}
+}
+
+func (r StorageBlobDirectoryResource) CustomizeDiff() sdk.ResourceFunc {
+	return sdk.ResourceFunc{
+		Timeout: 5 * time.Minute,
+		Func: func(ctx context.Context, metadata sdk.ResourceMetaData) error {
+			diff := metadata.ResourceDiff
+			for _, key := range []string{"source_directory", "prefix", "include", "exclude"} {
+				if !diff.NewValueKnown(key) {
+					return nil
+				}
+			}
+
+			include := make([]string, 0)
+			for _, v := range diff.Get("include").(*pluginsdk.Set).List() {
+				include = append(include, v.(string))
+			}
+			exclude := make([]string, 0)
+			for _, v := range diff.Get("exclude").(*pluginsdk.Set).List() {
+				exclude = append(exclude, v.(string))
+			}
+
+			// the contents of the source directory are compared with the files tracked in the state, so that any files
+			// which have been added, changed or removed (either locally or from the container) are shown in the plan
+			files, err := storageBlobDirectoryFiles(diff.Get("source_directory").(string), diff.Get("prefix").(string), include, exclude)
+			if err != nil {
+				return err
+			}
+
+			existing := diff.Get("files").(map[string]interface{})
+			changed := len(existing) != len(files)
+			for name, file := range files {
+				if v, ok := existing[name]; !ok || v.(string) != file.ContentMD5 {
+					changed = true
+					break
+				}
+			}
+
+			if changed {
+				if err := diff.SetNew("files", flattenStorageBlobDirectoryFiles(files)); err != nil {
+					return fmt.Errorf("setting `files`: %+v", err)
+				}
+			}
+
+			return nil
+		},
+	}
+}
+
+// storageBlobDirectoryUploader uploads and deletes the blobs for a directory, performing up to `parallelism` operations concurrently
+type storageBlobDirectoryUploader struct {
+	client        *blobs.Client
+	accountName   string
+	containerName string
+	cacheControl  string
+	contentTypes  map[string]string
+	parallelism   int
+}
+
+func (u storageBlobDirectoryUploader) upload(ctx context.Context, files map[string]storageBlobDirectoryFile, names []string) error {
+	group, ctx := errgroup.WithContext(ctx)
+	group.SetLimit(u.limit())
+
+	for _, name := range names {
+		file := files[name]
+		group.Go(func() error {
+			// Azure uses a Base64 encoded representation of the standard MD5 sum of the file
+			contentMD5, err := convertHexToBase64Encoding(file.ContentMD5)
+			if err != nil {
+				return err
+			}
+
+			input := BlobUpload{
+				Client:        u.client,
+				AccountName:   u.accountName,
+				ContainerName: u.containerName,
+				BlobName:      name,
+
+				BlobType:     "Block",
+				CacheControl: u.cacheControl,
+				ContentType:  storageBlobDirectoryContentType(name, u.contentTypes),
+				ContentMD5:   contentMD5,
+				Source:       file.Path,
+			}
+			if err := input.Create(ctx); err != nil {
+				return fmt.Errorf("uploading %q to the blob %q: %+v", file.Path, name, err)
+			}
+
+			log.Printf("[DEBUG] Uploaded %q to the blob %q (Container %q / Account %q)", file.Path, name, u.containerName, u.accountName)
+			return nil
+		})
+	}
+
+	return group.Wait()
+}
+
+func (u storageBlobDirectoryUploader) delete(ctx context.Context, names []string) error {
+	group, ctx := errgroup.WithContext(ctx)
+	group.SetLimit(u.limit())
+
+	for _, name := range names {
+		group.Go(func() error {
+			input := blobs.DeleteInput{
+				DeleteSnapshots: true,
+			}
+			if resp, err := u.client.Delete(ctx, u.containerName, name, input); err != nil {
+				if response.WasNotFound(resp.HttpResponse) {
+					return nil
+				}
+				return fmt.Errorf("deleting the blob %q: %+v", name, err)
+			}
+
+			log.Printf("[DEBUG] Deleted the blob %q (Container %q / Account %q)", name, u.containerName, u.accountName)
+			return nil
+		})
+	}
+
+	return group.Wait()
+}
+
+func (u storageBlobDirectoryUploader) limit() int {
+	if u.parallelism < 1 {
+		return 1
+	}
+	return u.parallelism
+}
+
+func storageBlobDirectorySortedNames(files map[string]storageBlobDirectoryFile) []string {
+	names := make([]string, 0, len(files))
+	for name := range files {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func flattenStorageBlobDirectoryFiles(input map[string]storageBlobDirectoryFile) map[string]interface{} {
+	output := make(map[string]interface{})
+	for name, file := range input {
+		output[name] = file.ContentMD5
+	}
+	return output
+}
+
+var _ resourceids.Id = StorageBlobDirectoryId{}
+
+// StorageBlobDirectoryId identifies the blobs uploaded from a directory into a Storage Container under a prefix - where
+// an empty prefix means the blobs are uploaded to the root of the container
+type StorageBlobDirectoryId struct {
+	ContainerId commonids.StorageContainerId
+	Prefix      string
+}
+
+func NewStorageBlobDirectoryID(containerId commonids.StorageContainerId, prefix string) StorageBlobDirectoryId {
+	return StorageBlobDirectoryId{
+		ContainerId: containerId,
+		Prefix:      prefix,
+	}
+}
+
+func (id StorageBlobDirectoryId) ID() string {
+	return fmt.Sprintf("%s/blobDirectories/%s", id.ContainerId.ID(), id.Prefix)
+}
+
+func (id StorageBlobDirectoryId) String() string {
+	return fmt.Sprintf("Blob Directory %q (%s)", id.Prefix, id.ContainerId)
+}
+
+func ParseStorageBlobDirectoryID(input string) (*StorageBlobDirectoryId, error) {
+	containerId, prefix, ok := strings.Cut(input, "/blobDirectories/")
+	if !ok {
+		return nil, fmt.Errorf("parsing %q as a Storage Blob Directory ID: expected the format `{storageContainerId}/blobDirectories/{prefix}`", input)
+	}
+	if strings.HasPrefix(prefix, "/") || strings.HasSuffix(prefix, "/") {
+		return nil, fmt.Errorf("parsing %q as a Storage Blob Directory ID: the prefix must not start or end with a `/`", input)
+	}
+
+	parsedContainerId, err := commonids.ParseStorageContainerID(containerId)
+	if err != nil {
+		return nil, fmt.Errorf("parsing %q as a Storage Blob Directory ID: %+v", input, err)
+	}
+
+	id := NewStorageBlobDirectoryID(*parsedContainerId, prefix)
+	return &id, nil
+}
+
+func validateStorageBlobDirectoryID(input interface{}, key string) (warnings []string, errors []error) {
+	v, ok := input.(string)
+	if !ok {
+		errors = append(errors, fmt.Errorf("expected %q to be a string", key))
+		return
+	}
+
+	if _, err := ParseStorageBlobDirectoryID(v); err != nil {
+		errors = append(errors, err)
+	}
+
+	return
+}
@@ -0,0 +1,242 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package storage_test
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/hashicorp/go-azure-helpers/lang/pointer"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/acceptance"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/acceptance/check"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/clients"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/services/storage"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
+)
+
+type StorageBlobDirectoryResource struct {
+	sourceDirectory string
+}
+
+func TestAccStorageBlobDirectory_basic(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azurerm_storage_blob_directory", "test")
+	r := newStorageBlobDirectoryResource(t)
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.basic(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("files.%").HasValue("3"),
+				check.That(data.ResourceName).Key("files.index.html").HasValue("5d41402abc4b2a76b9719d911017c592"),
+			),
+		},
+		data.ImportStep("source_directory"),
+	})
+}
+
+func TestAccStorageBlobDirectory_requiresImport(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azurerm_storage_blob_directory", "test")
+	r := newStorageBlobDirectoryResource(t)
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.basic(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+			),
+		},
+		data.RequiresImportErrorStep(r.requiresImport),
+	})
+}
+
+func TestAccStorageBlobDirectory_complete(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azurerm_storage_blob_directory", "test")
+	r := newStorageBlobDirectoryResource(t)
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.complete(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("files.%").HasValue("2"),
+				check.That(data.ResourceName).Key("files.site/index.html").Exists(),
+				check.That(data.ResourceName).Key("files.site/css/site.css").Exists(),
+			),
+		},
+		data.ImportStep("source_directory", "include", "exclude", "content_types", "cache_control", "parallelism"),
+	})
+}
+
+func TestAccStorageBlobDirectory_update(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azurerm_storage_blob_directory", "test")
+	r := newStorageBlobDirectoryResource(t)
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.basic(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("files.%").HasValue("3"),
+			),
+		},
+		data.ImportStep("source_directory"),
+		{
+			PreConfig: func() {
+				r.writeFile(t, "index.html", "hello world")
+				r.writeFile(t, "js/app.js", "console.log('hello')")
+				r.removeFile(t, "drafts/todo.html")
+			},
+			Config: r.basic(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("files.%").HasValue("3"),
+				check.That(data.ResourceName).Key("files.index.html").HasValue("5eb63bbbe01eeed093cb22bb8f5acdc3"),
+				check.That(data.ResourceName).Key("files.js/app.js").Exists(),
+				check.That(data.ResourceName).Key("files.drafts/todo.html").DoesNotExist(),
+			),
+		},
+		data.ImportStep("source_directory"),
+		{
+			Config: r.complete(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+			),
+		},
+		data.ImportStep("source_directory", "include", "exclude", "content_types", "cache_control", "parallelism"),
+	})
+}
+
+func newStorageBlobDirectoryResource(t *testing.T) StorageBlobDirectoryResource {
+	r := StorageBlobDirectoryResource{
+		sourceDirectory: t.TempDir(),
+	}
+
+	r.writeFile(t, "index.html", "hello")
+	r.writeFile(t, "css/site.css", "body {}")
+	r.writeFile(t, "drafts/todo.html", "wip")
+
+	return r
+}
+
+func (r StorageBlobDirectoryResource) writeFile(t *testing.T, name, contents string) {
+	path := filepath.Join(r.sourceDirectory, filepath.FromSlash(name))
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		t.Fatalf("creating the directory for %q: %+v", path, err)
+	}
+	if err := os.WriteFile(path, []byte(contents), 0o600); err != nil {
+		t.Fatalf("writing %q: %+v", path, err)
+	}
+}
+
+func (r StorageBlobDirectoryResource) removeFile(t *testing.T, name string) {
+	path := filepath.Join(r.sourceDirectory, filepath.FromSlash(name))
+	if err := os.Remove(path); err != nil {
+		t.Fatalf("removing %q: %+v", path, err)
+	}
+}
+
+func (r StorageBlobDirectoryResource) Exists(ctx context.Context, client *clients.Client, state *pluginsdk.InstanceState) (*bool, error) {
+	id, err := storage.ParseStorageBlobDirectoryID(state.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	account, err := client.Storage.FindAccount(ctx, id.ContainerId.SubscriptionId, id.ContainerId.StorageAccountName)
+	if err != nil {
+		return nil, fmt.Errorf("retrieving Account %q for %s: %+v", id.ContainerId.StorageAccountName, id, err)
+	}
+	if account == nil {
+		return nil, fmt.Errorf("unable to locate Account %q for %s", id.ContainerId.StorageAccountName, id)
+	}
+
+	containersClient, err := client.Storage.ContainersDataPlaneClient(ctx, *account, client.Storage.DataPlaneOperationSupportingAnyAuthMethod())
+	if err != nil {
+		return nil, fmt.Errorf("building Containers Client: %+v", err)
+	}
+
+	prefix := ""
+	if id.Prefix != "" {
+		prefix = fmt.Sprintf("%s/", id.Prefix)
+	}
+	blobs, err := containersClient.ListBlobs(ctx, id.ContainerId.ContainerName, prefix)
+	if err != nil {
+		return nil, fmt.Errorf("listing blobs for %s: %+v", id, err)
+	}
+
+	return pointer.To(blobs != nil && len(*blobs) > 0), nil
+}
+
+func (r StorageBlobDirectoryResource) basic(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+%s
+
+resource "azurerm_storage_blob_directory" "test" {
+  storage_container_id = azurerm_storage_container.test.id
+  source_directory     = "%s"
+}
+`, r.template(data), filepath.ToSlash(r.sourceDirectory))
+}
+
+func (r StorageBlobDirectoryResource) requiresImport(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+%s
+
+resource "azurerm_storage_blob_directory" "import" {
+  storage_container_id = azurerm_storage_blob_directory.test.storage_container_id
+  source_directory     = azurerm_storage_blob_directory.test.source_directory
+  prefix               = azurerm_storage_blob_directory.test.prefix
+}
+`, r.basic(data))
+}
+
+func (r StorageBlobDirectoryResource) complete(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+%s
+
+resource "azurerm_storage_blob_directory" "test" {
+  storage_container_id = azurerm_storage_container.test.id
+  source_directory     = "%s"
+  prefix               = "site"
+  include              = ["**/*.html", "**/*.css"]
+  exclude              = ["drafts/**"]
+  cache_control        = "public, max-age=3600"
+  parallelism          = 2
+
+  content_types = {
+    ".css" = "text/css"
+  }
+}
+`, r.template(data), filepath.ToSlash(r.sourceDirectory))
+}
+
+func (r StorageBlobDirectoryResource) template(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+provider "azurerm" {
+  features {}
+}
+
+resource "azurerm_resource_group" "test" {
+  name     = "acctestRG-storage-%d"
+  location = "%s"
+}
+
+resource "azurerm_storage_account" "test" {
+  name                     = "acctestacc%s"
+  resource_group_name      = azurerm_resource_group.test.name
+  location                 = azurerm_resource_group.test.location
+  account_tier             = "Standard"
+  account_replication_type = "LRS"
+}
+
+resource "azurerm_storage_container" "test" {
+  name                  = "content"
+  storage_account_id    = azurerm_storage_account.test.id
+  container_access_type = "private"
+}
+`, data.RandomInteger, data.Locations.Primary, data.RandomString)
+}
@@ -7,13 +7,17 @@ import (
 	"context"
 	"fmt"
 	"log"
+	"net/http"
 	"strings"
 	"time"
 
 	"github.com/hashicorp/go-azure-helpers/lang/pointer"
 	"github.com/hashicorp/go-azure-helpers/lang/response"
+	"github.com/hashicorp/go-azure-sdk/sdk/client"
+	"github.com/hashicorp/go-azure-sdk/sdk/odata"
 	"github.com/hashicorp/terraform-provider-azurerm/helpers/tf"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/clients"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/features"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/services/storage/helpers"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/services/storage/migration"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/services/storage/validate"
@@ -215,6 +219,18 @@ func resourceStorageBlobCreate(d *pluginsdk.ResourceData, meta interface{}) erro
 		if !response.WasNotFound(props.HttpResponse) {
 			return tf.ImportAsExistsError("azurerm_storage_blob", id.ID())
 		}
+
+		if meta.(*clients.Client).Features.SoftDelete.For(features.SoftDeleteServiceStorageBlob).RecoverSoftDeleted {
+			// recovering the Blob also restores its snapshots, the configured content is then uploaded over it below
+			resp, err := blobsClient.Undelete(ctx, containerName, name)
+			if err != nil {
+				if !response.WasNotFound(resp.HttpResponse) {
+					return fmt.Errorf("recovering soft-deleted %s: %v", id, err)
+				}
+			} else {
+				log.Printf("[DEBUG] Recovered soft-deleted %s", id)
+			}
+		}
 	}
 
 	contentMD5Raw := d.Get("content_md5").(string)
@@ -458,5 +474,58 @@ func resourceStorageBlobDelete(d *pluginsdk.ResourceData, meta interface{}) erro
 		return fmt.Errorf("deleting %s: %v", id, err)
 	}
 
+	if meta.(*clients.Client).Features.SoftDelete.For(features.SoftDeleteServiceStorageBlob).PurgeOnDestroy {
+		log.Printf("[DEBUG] Purging soft-deleted %s", id)
+		if err := purgeSoftDeletedStorageBlob(ctx, blobsClient, id.ContainerName, id.BlobName); err != nil {
+			return fmt.Errorf("purging soft-deleted %s: %v", id, err)
+		}
+	}
+
 	return nil
 }
+
+// purgeSoftDeletedStorageBlob permanently deletes a soft-deleted Blob, which requires that permanent delete is enabled
+// within the Blob Delete Retention Policy for the Storage Account
+func purgeSoftDeletedStorageBlob(ctx context.Context, blobsClient *blobs.Client, containerName, blobName string) error {
+	opts := client.RequestOptions{
+		ExpectedStatusCodes: []int{
+			http.StatusAccepted,
+		},
+		HttpMethod:    http.MethodDelete,
+		OptionsObject: purgeBlobOptions{},
+		Path:          fmt.Sprintf("/%s/%s", containerName, blobName),
+	}
+
+	req, err := blobsClient.Client.NewRequest(ctx, opts)
+	if err != nil {
+		return fmt.Errorf("building request: %+v", err)
+	}
+
+	resp, err := req.Execute(ctx)
+	if err != nil {
+		// soft delete isn't enabled for the Storage Account, so the Blob has already been permanently deleted
+		if resp != nil && response.WasNotFound(resp.Response) {
+			return nil
+		}
+		return fmt.Errorf("executing request: %+v", err)
+	}
+
+	return nil
+}
+
+// purgeBlobOptions defines the options for permanently deleting a Blob, which isn't supported by Giovanni
+type purgeBlobOptions struct{}
+
+func (purgeBlobOptions) ToHeaders() *client.Headers {
+	return nil
+}
+
+func (purgeBlobOptions) ToOData() *odata.Query {
+	return nil
+}
+
+func (purgeBlobOptions) ToQuery() *client.QueryParams {
+	out := &client.QueryParams{}
+	out.Append("deletetype", "permanent")
+	return out
+}
@@ -4,6 +4,7 @@
 package storage
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"strings"
@@ -204,6 +205,30 @@ func resourceStorageContainerCreate(d *pluginsdk.ResourceData, meta interface{})
 				return tf.ImportAsExistsError("azurerm_storage_container", id.ID())
 			}
 
+			if meta.(*clients.Client).Features.SoftDelete.For(features.SoftDeleteServiceStorageContainer).RecoverSoftDeleted {
+				recovered, err := recoverSoftDeletedStorageContainer(ctx, storageClient, *account, containerName)
+				if err != nil {
+					return fmt.Errorf("recovering soft-deleted %s: %v", id, err)
+				}
+				if recovered {
+					// the Container is restored as it was when deleted, so the configuration needs to be applied in full
+					// NOTE: updating the access level doesn't work with AAD authentication
+					sharedKeyClient, err := storageClient.ContainersDataPlaneClient(ctx, *account, storageClient.DataPlaneOperationSupportingOnlySharedKeyAuth())
+					if err != nil {
+						return fmt.Errorf("building Containers Client: %v", err)
+					}
+					if err = sharedKeyClient.UpdateAccessLevel(ctx, containerName, accessLevel); err != nil {
+						return fmt.Errorf("updating Access Level for recovered %s: %v", id, err)
+					}
+					if err = containersDataPlaneClient.UpdateMetaData(ctx, containerName, metaData); err != nil {
+						return fmt.Errorf("updating Metadata for recovered %s: %v", id, err)
+					}
+
+					d.SetId(id.ID())
+					return resourceStorageContainerRead(d, meta)
+				}
+			}
+
 			log.Printf("[INFO] Creating %s", id)
 			input := containers.CreateInput{
 				AccessLevel: accessLevel,
@@ -245,6 +270,37 @@ func resourceStorageContainerCreate(d *pluginsdk.ResourceData, meta interface{})
 		return tf.ImportAsExistsError("azurerm_storage_container", id.ID())
 	}
 
+	if meta.(*clients.Client).Features.SoftDelete.For(features.SoftDeleteServiceStorageContainer).RecoverSoftDeleted {
+		storageClient := meta.(*clients.Client).Storage
+		account, err := storageClient.FindAccount(ctx, subscriptionId, id.StorageAccountName)
+		if err != nil {
+			return fmt.Errorf("retrieving Account %q for Container %q: %v", id.StorageAccountName, containerName, err)
+		}
+		if account == nil {
+			return fmt.Errorf("locating Storage Account %q", id.StorageAccountName)
+		}
+
+		recovered, err := recoverSoftDeletedStorageContainer(ctx, storageClient, *account, containerName)
+		if err != nil {
+			return fmt.Errorf("recovering soft-deleted %s: %v", id, err)
+		}
+		if recovered {
+			// the Container is restored as it was when deleted, so the configuration needs to be applied in full
+			update := blobcontainers.BlobContainer{
+				Properties: &blobcontainers.ContainerProperties{
+					PublicAccess: pointer.To(blobcontainers.PublicAccess(containerAccessTypeConversionMap[accessLevelRaw])),
+					Metadata:     pointer.To(metaData),
+				},
+			}
+			if _, err := containerClient.Update(ctx, id, update); err != nil {
+				return fmt.Errorf("updating recovered %s: %v", id, err)
+			}
+
+			d.SetId(id.ID())
+			return resourceStorageContainerRead(d, meta)
+		}
+	}
+
 	payload := blobcontainers.BlobContainer{
 		Properties: &blobcontainers.ContainerProperties{
 			PublicAccess: pointer.To(blobcontainers.PublicAccess(containerAccessTypeConversionMap[accessLevelRaw])),
@@ -496,6 +552,51 @@ func resourceStorageContainerDelete(d *pluginsdk.ResourceData, meta interface{})
 	return nil
 }
 
+// recoverSoftDeletedStorageContainer restores the most recently soft-deleted Container with the specified name within
+// the Storage Account, returning whether a Container was recovered
+func recoverSoftDeletedStorageContainer(ctx context.Context, storageClient *client.Client, account client.AccountDetails, containerName string) (bool, error) {
+	options := blobcontainers.ListOperationOptions{
+		Filter:  pointer.To(containerName),
+		Include: pointer.To(blobcontainers.ListContainersIncludeDeleted),
+	}
+	containerList, err := storageClient.ResourceManager.BlobContainers.ListComplete(ctx, account.StorageAccountId, options)
+	if err != nil {
+		return false, fmt.Errorf("listing soft-deleted Containers within %s: %v", account.StorageAccountId, err)
+	}
+
+	var deletedVersion *string
+	var deletedTime time.Time
+	for _, item := range containerList.Items {
+		if pointer.From(item.Name) != containerName || item.Properties == nil || !pointer.From(item.Properties.Deleted) {
+			continue
+		}
+
+		itemDeletedTime, err := item.Properties.GetDeletedTimeAsTime()
+		if err != nil {
+			return false, fmt.Errorf("parsing `deletedTime` for soft-deleted Container %q: %v", containerName, err)
+		}
+		if deletedVersion == nil || pointer.From(itemDeletedTime).After(deletedTime) {
+			deletedVersion = item.Properties.Version
+			deletedTime = pointer.From(itemDeletedTime)
+		}
+	}
+	if deletedVersion == nil {
+		return false, nil
+	}
+
+	containersClient, err := storageClient.ContainersDataPlaneClient(ctx, account, storageClient.DataPlaneOperationSupportingAnyAuthMethod())
+	if err != nil {
+		return false, fmt.Errorf("building Containers Client: %v", err)
+	}
+
+	log.Printf("[DEBUG] Recovering version %q of soft-deleted Container %q within %s", *deletedVersion, containerName, account.StorageAccountId)
+	if err = containersClient.Restore(ctx, containerName, *deletedVersion); err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
 func expandStorageContainerAccessLevel(input string) containers.AccessLevel {
 	// for historical reasons, "private" above is an empty string in the API
 	// so the enum doesn't 1:1 match. You could argue the SDK should handle this
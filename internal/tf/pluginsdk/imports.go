@@ -6,6 +6,7 @@ package pluginsdk
 import (
 	"context"
 	"log"
+	"sync"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
@@ -26,7 +27,7 @@ func ImporterValidatingResourceId(validateFunc IDValidationFunc) *schema.Resourc
 // ImporterValidatingResourceIdThen validates the ID provided at import time is valid
 // using the validateFunc then runs the 'thenFunc', allowing the import to be customised.
 func ImporterValidatingResourceIdThen(validateFunc IDValidationFunc, thenFunc ImporterFunc) *schema.ResourceImporter {
-	return &schema.ResourceImporter{
+	importer := &schema.ResourceImporter{
 		StateContext: func(ctx context.Context, d *ResourceData, meta interface{}) ([]*ResourceData, error) {
 			log.Printf("[DEBUG] Importing Resource - parsing %q", d.Id())
 
@@ -44,4 +45,26 @@ func ImporterValidatingResourceIdThen(validateFunc IDValidationFunc, thenFunc Im
 			return thenFunc(ctx, d, meta)
 		},
 	}
+	importerIdValidationFuncs.Store(importer, validateFunc)
+
+	return importer
+}
+
+// importerIdValidationFuncs tracks the IDValidationFunc used by each Importer built via
+// ImporterValidatingResourceIdThen, so that it can be looked up outside of an import.
+var importerIdValidationFuncs = &sync.Map{}
+
+// IDValidationFuncForImporter returns the IDValidationFunc used by the specified Importer, if
+// it was built using ImporterValidatingResourceId or ImporterValidatingResourceIdThen.
+func IDValidationFuncForImporter(importer *schema.ResourceImporter) (IDValidationFunc, bool) {
+	if importer == nil {
+		return nil, false
+	}
+
+	v, ok := importerIdValidationFuncs.Load(importer)
+	if !ok {
+		return nil, false
+	}
+
+	return v.(IDValidationFunc), true
 }
@@ -5,114 +5,167 @@ package differ
 
 import (
 	"fmt"
+	"sort"
+	"strconv"
 
 	"github.com/hashicorp/terraform-provider-azurerm/internal/tools/schema-api/providerjson"
 	schema_rules "github.com/hashicorp/terraform-provider-azurerm/internal/tools/schema-api/schema-rules"
 )
 
+const (
+	ruleResourceRemoved   = "resource_removed"
+	ruleDataSourceRemoved = "data_source_removed"
+)
+
 type Differ struct {
 	base    *providerjson.ProviderWrapper
 	current *providerjson.ProviderWrapper
 }
 
+// Diff compares the current provider schema against the named dump, returning any violations as human-readable strings
 func (d *Differ) Diff(fileName string, providerName string) []string {
-	if err := d.loadFromProvider(providerjson.LoadData(), providerName); err != nil {
+	violations, err := d.Detect(fileName, providerName)
+	if err != nil {
 		return []string{err.Error()}
 	}
 
+	result := make([]string, 0)
+	for _, v := range violations {
+		result = append(result, v.String())
+	}
+
+	return result
+}
+
+// Detect compares the current provider schema against the named dump, returning any breaking changes found
+func (d *Differ) Detect(fileName string, providerName string) ([]Violation, error) {
+	if err := d.loadFromProvider(providerjson.LoadData(), providerName); err != nil {
+		return nil, err
+	}
+
 	if err := d.loadFromFile(fileName); err != nil {
-		return []string{err.Error()}
+		return nil, err
 	}
 
 	if d.base.ProviderName != d.current.ProviderName {
-		return []string{fmt.Sprintf("provider name mismatch, expected %q, got %q", d.base.ProviderName, d.current.ProviderName)}
+		return nil, fmt.Errorf("provider name mismatch, expected %q, got %q", d.base.ProviderName, d.current.ProviderName)
+	}
+
+	// exports which predate the schema version (or where it can't be parsed) are treated as the initial version
+	baseSchemaVersion, err := strconv.Atoi(d.base.SchemaVersion)
+	if err != nil {
+		baseSchemaVersion = 1
 	}
+	resourceRules := schema_rules.RulesForSchemaVersion(schema_rules.BreakingChangeRules, baseSchemaVersion)
+	dataSourceRules := schema_rules.RulesForSchemaVersion(schema_rules.BreakingChangeRulesDataSource, baseSchemaVersion)
 
-	violations := make([]string, 0)
+	violations := make([]Violation, 0)
 
-	for resource, rs := range d.current.ProviderSchema.ResourcesMap {
-		_, ok := d.base.ProviderSchema.ResourcesMap[resource]
+	for resource, base := range d.base.ProviderSchema.ResourcesMap {
+		current, ok := d.current.ProviderSchema.ResourcesMap[resource]
 		if !ok {
-			// New resource, no breaking changes to worry about
+			violations = append(violations, Violation{
+				Rule:    ruleResourceRemoved,
+				Kind:    KindResource,
+				Name:    resource,
+				Message: fmt.Sprintf("resource %q has been removed", resource),
+			})
 			continue
 		}
-		for propertyName, propertySchema := range rs.Schema {
-			// Get the same from the base (released) json
-			baseItem, ok := d.base.ProviderSchema.ResourcesMap[resource].Schema[propertyName]
-			if !ok {
-				// New property, could be breaking - Required etc
-				baseItem = providerjson.SchemaJSON{}
-			}
-			if errs := compareNodeResource(baseItem, propertySchema, propertyName); errs != nil {
-				violations = append(violations, errs...)
-			}
+		for _, v := range compareSchemas(base.Schema, current.Schema, "", resourceRules) {
+			v.Kind = KindResource
+			v.Name = resource
+			violations = append(violations, v)
 		}
 	}
 
-	for dataSource, ds := range d.current.ProviderSchema.DataSourcesMap {
-		_, ok := d.base.ProviderSchema.DataSourcesMap[dataSource]
+	for dataSource, base := range d.base.ProviderSchema.DataSourcesMap {
+		current, ok := d.current.ProviderSchema.DataSourcesMap[dataSource]
 		if !ok {
-			// New data source, no breaking changes to worry about
+			violations = append(violations, Violation{
+				Rule:    ruleDataSourceRemoved,
+				Kind:    KindDataSource,
+				Name:    dataSource,
+				Message: fmt.Sprintf("data source %q has been removed", dataSource),
+			})
 			continue
 		}
-		for propertyName, propertySchema := range ds.Schema {
-			// Get the same from the base (released) json
-			baseItem, ok := d.base.ProviderSchema.DataSourcesMap[dataSource].Schema[propertyName]
-			if !ok {
-				// New property, could be breaking - Required etc
-				baseItem = providerjson.SchemaJSON{}
-			}
-			if errs := compareNodeDataSource(baseItem, propertySchema, propertyName); errs != nil {
-				violations = append(violations, errs...)
-			}
+		for _, v := range compareSchemas(base.Schema, current.Schema, "", dataSourceRules) {
+			v.Kind = KindDataSource
+			v.Name = dataSource
+			violations = append(violations, v)
 		}
 	}
 
-	return violations
+	sort.Slice(violations, func(i, j int) bool {
+		a, b := violations[i], violations[j]
+		if a.Kind != b.Kind {
+			return a.Kind < b.Kind
+		}
+		if a.Name != b.Name {
+			return a.Name < b.Name
+		}
+		if a.Property != b.Property {
+			return a.Property < b.Property
+		}
+		return a.Rule < b.Rule
+	})
+
+	return violations, nil
 }
 
-func compareNodeResource(base providerjson.SchemaJSON, current providerjson.SchemaJSON, nodeName string) (errs []string) {
-	if nodeIsBlock(base) {
-		newBaseRaw := base.Elem.(providerjson.ResourceJSON).Schema
-		newCurrent := current.Elem.(*providerjson.ResourceJSON).Schema
-		for k, newBase := range newBaseRaw {
-			errs = append(errs, compareNodeResource(newBase, newCurrent[k], k)...)
-		}
+// compareSchemas checks every property present in either the base or current schema, so that both new and removed properties are checked
+func compareSchemas(base map[string]providerjson.SchemaJSON, current map[string]providerjson.SchemaJSON, prefix string, rules []schema_rules.BreakingChangeRule) (violations []Violation) {
+	propertyNames := make(map[string]struct{})
+	for k := range base {
+		propertyNames[k] = struct{}{}
+	}
+	for k := range current {
+		propertyNames[k] = struct{}{}
 	}
 
-	for _, v := range schema_rules.BreakingChangeRules {
-		if err := v.Check(base, current, nodeName); err != nil {
-			errs = append(errs, *err)
-		}
+	for k := range propertyNames {
+		// a missing property is represented by an empty schema, which the rules treat as new or removed
+		violations = append(violations, compareNode(base[k], current[k], prefix+k, rules)...)
 	}
 
 	return
 }
 
-func compareNodeDataSource(base providerjson.SchemaJSON, current providerjson.SchemaJSON, nodeName string) (errs []string) {
-	if nodeIsBlock(base) {
-		newBaseRaw := base.Elem.(providerjson.ResourceJSON).Schema
-		newCurrent := current.Elem.(*providerjson.ResourceJSON).Schema
-		for k, newBase := range newBaseRaw {
-			errs = append(errs, compareNodeDataSource(newBase, newCurrent[k], k)...)
-		}
+func compareNode(base providerjson.SchemaJSON, current providerjson.SchemaJSON, nodeName string, rules []schema_rules.BreakingChangeRule) (violations []Violation) {
+	baseBlock, baseIsBlock := nodeBlockSchema(base)
+	currentBlock, currentIsBlock := nodeBlockSchema(current)
+	if baseIsBlock && currentIsBlock {
+		violations = append(violations, compareSchemas(baseBlock, currentBlock, nodeName+".", rules)...)
 	}
 
-	for _, v := range schema_rules.BreakingChangeRulesDataSource {
+	for _, v := range rules {
 		if err := v.Check(base, current, nodeName); err != nil {
-			errs = append(errs, *err)
+			violations = append(violations, Violation{
+				Rule:     v.Name(),
+				Property: nodeName,
+				Message:  *err,
+			})
 		}
 	}
 
 	return
 }
 
-func nodeIsBlock(input providerjson.SchemaJSON) bool {
-	if input.Type == providerjson.SchemaTypeList || input.Type == providerjson.SchemaTypeSet {
-		if _, ok := input.Elem.(providerjson.ResourceJSON); ok {
-			return true
+// nodeBlockSchema returns the nested schema for a block - which is a value when loaded from a file, but a pointer when loaded from the provider
+func nodeBlockSchema(input providerjson.SchemaJSON) (map[string]providerjson.SchemaJSON, bool) {
+	if input.Type != providerjson.SchemaTypeList && input.Type != providerjson.SchemaTypeSet {
+		return nil, false
+	}
+
+	switch elem := input.Elem.(type) {
+	case providerjson.ResourceJSON:
+		return elem.Schema, true
+	case *providerjson.ResourceJSON:
+		if elem != nil {
+			return elem.Schema, true
 		}
 	}
 
-	return false
+	return nil, false
 }
@@ -32,6 +32,7 @@ func (d *Differ) loadFromProvider(data *providerjson.ProviderJSON, providerName
 	} else {
 		d.current = &providerjson.ProviderWrapper{
 			ProviderName:   providerName,
+			SchemaVersion:  providerjson.SchemaVersion,
 			ProviderSchema: s,
 		}
 	}
@@ -0,0 +1,67 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package differ
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+)
+
+const (
+	KindResource   = "resource"
+	KindDataSource = "dataSource"
+)
+
+type Violation struct {
+	// Rule is the name of the breaking change rule which was violated
+	Rule string `json:"rule"`
+
+	// Kind is either `resource` or `dataSource`
+	Kind string `json:"kind"`
+
+	// Name is the name of the Resource or Data Source, e.g. `azurerm_resource_group`
+	Name string `json:"name"`
+
+	// Property is the path to the property within the Resource or Data Source, e.g. `identity.type`
+	Property string `json:"property,omitempty"`
+
+	Message string `json:"message"`
+}
+
+func (v Violation) String() string {
+	if v.Property == "" {
+		return fmt.Sprintf("[%s] %s", v.Rule, v.Message)
+	}
+	return fmt.Sprintf("[%s] %s %q: %s", v.Rule, v.Kind, v.Name, v.Message)
+}
+
+type Report struct {
+	ProviderName string      `json:"providerName"`
+	BaseSchema   string      `json:"baseSchema"`
+	Violations   []Violation `json:"violations"`
+}
+
+// WriteReport writes the violations found in `fileName` as a JSON report to `reportFileName`
+func WriteReport(reportFileName string, providerName string, fileName string, violations []Violation) error {
+	report := Report{
+		ProviderName: providerName,
+		BaseSchema:   fileName,
+		Violations:   violations,
+	}
+
+	f, err := os.Create(reportFileName)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	encoder := json.NewEncoder(f)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(report); err != nil {
+		return err
+	}
+
+	return nil
+}
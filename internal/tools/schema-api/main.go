@@ -26,12 +26,16 @@ func main() {
 	exportSchema := f.String("export", "", "export the schema to the given path/filename. Intended for use in the release process")
 	detectBreakingChanges := f.String("detect", "", "compare current schema to named dump.")
 	errorOnBreakingChange := f.Bool("error-on-violation", false, "should the detect mode exit with a non-zero error code. Defaults to `false`")
+	reportFile := f.String("report", "", "write the violations found in detect mode as a JSON report to the given path/filename")
 
 	if err := f.Parse(os.Args[1:]); err != nil {
 		fmt.Printf("error parsing args: %+v", err)
 		os.Exit(1)
 	}
 
+	// the possible values for each property are only available once patched, which needs to happen before the provider is loaded
+	providerjson.PatchPossibleValues()
+
 	data := providerjson.LoadData()
 
 	switch {
@@ -41,7 +45,7 @@ func main() {
 			log.Printf("dumping schema for '%s'", *providerName)
 			wrappedProvider := &providerjson.ProviderWrapper{
 				ProviderName:  *providerName,
-				SchemaVersion: "1",
+				SchemaVersion: providerjson.SchemaVersion,
 			}
 			if err := providerjson.DumpWithWrapper(wrappedProvider, data); err != nil {
 				log.Fatalf("error dumping provider: %+v", err)
@@ -53,14 +57,21 @@ func main() {
 	case pointer.From(detectBreakingChanges) != "":
 		{
 			d := differ.Differ{}
-			if violations := d.Diff(*detectBreakingChanges, *providerName); violations != nil {
-				for _, v := range violations {
-					log.Println(v)
-				}
-				if pointer.From(errorOnBreakingChange) {
-					os.Exit(1)
+			violations, err := d.Detect(*detectBreakingChanges, *providerName)
+			if err != nil {
+				log.Fatalf("error detecting breaking changes: %+v", err)
+			}
+			for _, v := range violations {
+				log.Println(v)
+			}
+			if report := pointer.From(reportFile); report != "" {
+				if err := differ.WriteReport(report, *providerName, *detectBreakingChanges, violations); err != nil {
+					log.Fatalf("error writing breaking change report to %q: %+v", report, err)
 				}
 			}
+			if len(violations) > 0 && pointer.From(errorOnBreakingChange) {
+				os.Exit(1)
+			}
 
 			os.Exit(0)
 		}
@@ -70,7 +81,7 @@ func main() {
 			log.Printf("dumping schema for '%s'", *providerName)
 			wrappedProvider := &providerjson.ProviderWrapper{
 				ProviderName:  *providerName,
-				SchemaVersion: "1",
+				SchemaVersion: providerjson.SchemaVersion,
 			}
 			if err := providerjson.WriteWithWrapper(wrappedProvider, data, *exportSchema); err != nil {
 				log.Fatalf("error writing provider schema for %q to %q: %+v", *providerName, *exportSchema, err)
@@ -17,3 +17,18 @@ func (p *ProviderJSON) Resources() []terraform.ResourceType {
 	s := schema.Provider(*p)
 	return s.Resources()
 }
+
+func stringSliceFromRaw(input interface{}) []string {
+	raw, ok := input.([]interface{})
+	if !ok || len(raw) == 0 {
+		return nil
+	}
+
+	result := make([]string, 0, len(raw))
+	for _, v := range raw {
+		if s, ok := v.(string); ok {
+			result = append(result, s)
+		}
+	}
+	return result
+}
@@ -33,6 +33,10 @@ type SchemaJSON struct {
 	Elem        interface{} `json:"elem,omitempty"`
 	MaxItems    int         `json:"maxItems,omitempty"`
 	MinItems    int         `json:"minItems,omitempty"`
+
+	ConflictsWith  []string `json:"conflictsWith,omitempty"`
+	ExactlyOneOf   []string `json:"exactlyOneOf,omitempty"`
+	PossibleValues []string `json:"possibleValues,omitempty"`
 }
 
 func (b *SchemaJSON) UnmarshalJSON(body []byte) error {
@@ -52,8 +56,11 @@ func (b *SchemaJSON) UnmarshalJSON(body []byte) error {
 		b.MaxItems = int(max)
 	}
 	if min, ok := m["minItems"].(float64); ok {
-		b.MaxItems = int(min)
+		b.MinItems = int(min)
 	}
+	b.ConflictsWith = stringSliceFromRaw(m["conflictsWith"])
+	b.ExactlyOneOf = stringSliceFromRaw(m["exactlyOneOf"])
+	b.PossibleValues = stringSliceFromRaw(m["possibleValues"])
 
 	if def, ok := m["default"]; ok && def != nil {
 		switch def.(type) {
@@ -106,6 +113,16 @@ type ProviderSchemaJSON struct {
 	DataSourcesMap map[string]ResourceJSON `json:"dataSources,omitempty"`
 }
 
+const (
+	// SchemaVersion is the version of the format used when exporting the schema, which is incremented when fields are
+	// added so that rules relying on these fields can be skipped when comparing against an export which predates them
+	SchemaVersion = "2"
+
+	// SchemaVersionValidationDetails is the version of the format which added the `conflictsWith`, `exactlyOneOf`
+	// and `possibleValues` fields
+	SchemaVersionValidationDetails = 2
+)
+
 type ProviderWrapper struct {
 	ProviderName   string              `json:"providerName"`
 	SchemaVersion  string              `json:"schemaVersion"`
@@ -0,0 +1,49 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package providerjson
+
+import (
+	"reflect"
+	"runtime"
+	"strings"
+
+	gomonkey "github.com/agiledragon/gomonkey/v2"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// PatchPossibleValues patches `validation.StringInSlice` to return the possible values as warnings, since these are
+// otherwise hidden within a closure - allowing these to be exported as part of the schema. This must be called before
+// the provider is loaded, and mirrors the approach used by the document-lint tool.
+func PatchPossibleValues() {
+	patchPossibleValuesFn()
+}
+
+func patchPossibleValuesFn() {
+	gomonkey.ApplyFunc(validation.StringInSlice,
+		func(valid []string, ignoreCase bool) schema.SchemaValidateFunc { //nolint:staticcheck
+			return func(i interface{}, k string) (warnings []string, errors []error) {
+				var res []string // must have a copy
+				res = append(res, valid...)
+				return res, nil
+			}
+		})
+}
+
+// possibleValuesFromValidateFunc returns the possible values for the ValidateFunc, if it's a `validation.StringInSlice`
+func possibleValuesFromValidateFunc(input schema.SchemaValidateFunc) []string { //nolint:staticcheck
+	if input == nil {
+		return nil
+	}
+
+	pc := reflect.ValueOf(input).Pointer()
+	fn := runtime.FuncForPC(pc).Name()
+	// ValidateFunc may directly use the patched `validation.StringInSlice`, in which case the function name is `patchPossibleValuesFn`
+	if !strings.Contains(fn, "patchPossibleValuesFn") && !strings.Contains(fn, "StringInSlice") {
+		return nil
+	}
+
+	values, _ := input(nil, "")
+	return values
+}
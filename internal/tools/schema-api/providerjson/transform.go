@@ -57,6 +57,10 @@ func schemaFromRaw(input *schema.Schema) SchemaJSON {
 		Elem:        decodeElem(input.Elem),
 		MaxItems:    input.MaxItems,
 		MinItems:    input.MinItems,
+
+		ConflictsWith:  input.ConflictsWith,
+		ExactlyOneOf:   input.ExactlyOneOf,
+		PossibleValues: possibleValuesFromValidateFunc(input.ValidateFunc), //nolint:staticcheck
 	}
 }
 
@@ -110,6 +114,10 @@ func SchemaFromMap(input map[string]interface{}) SchemaJSON {
 		result.MaxItems = int(t.(float64))
 	}
 
+	result.ConflictsWith = stringSliceFromRaw(input["conflictsWith"])
+	result.ExactlyOneOf = stringSliceFromRaw(input["exactlyOneOf"])
+	result.PossibleValues = stringSliceFromRaw(input["possibleValues"])
+
 	return result
 }
 
@@ -0,0 +1,37 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package schema_rules
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/go-azure-helpers/lang/pointer"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tools/schema-api/providerjson"
+)
+
+var _ BreakingChangeRuleWithMinimumSchemaVersion = conflictsWithAdded{}
+
+type conflictsWithAdded struct{}
+
+func (conflictsWithAdded) Name() string {
+	return "conflicts_with_added"
+}
+
+func (conflictsWithAdded) MinimumSchemaVersion() int {
+	return providerjson.SchemaVersionValidationDetails
+}
+
+// Check - Checks that no new ConflictsWith entries are added to an existing property, as user configs may already set both properties
+func (conflictsWithAdded) Check(base providerjson.SchemaJSON, current providerjson.SchemaJSON, propertyName string) *string {
+	if base.Type == "" {
+		return nil
+	}
+
+	if added := missingFrom(current.ConflictsWith, base.ConflictsWith); len(added) > 0 {
+		return pointer.To(fmt.Sprintf("cannot add %s to ConflictsWith for the existing property %q", strings.Join(added, ", "), propertyName))
+	}
+
+	return nil
+}
@@ -0,0 +1,71 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package schema_rules
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tools/schema-api/providerjson"
+)
+
+var conflictsWithAddedBaseNode = providerjson.SchemaJSON{
+	Type:        providerjson.SchemaTypeString,
+	ConfigMode:  "",
+	Optional:    true,
+	Required:    false,
+	Default:     nil,
+	Description: "",
+	Computed:    false,
+	ForceNew:    false,
+	Elem:        nil,
+	MaxItems:    0,
+	MinItems:    0,
+
+	ConflictsWith: []string{"foo", "bar"},
+}
+
+var conflictsWithAddedPasses = providerjson.SchemaJSON{
+	Type:        providerjson.SchemaTypeString,
+	ConfigMode:  "",
+	Optional:    true,
+	Required:    false,
+	Default:     nil,
+	Description: "",
+	Computed:    false,
+	ForceNew:    false,
+	Elem:        nil,
+	MaxItems:    0,
+	MinItems:    0,
+
+	ConflictsWith: []string{"bar"},
+}
+
+var conflictsWithAddedViolates = providerjson.SchemaJSON{
+	Type:        providerjson.SchemaTypeString,
+	ConfigMode:  "",
+	Optional:    true,
+	Required:    false,
+	Default:     nil,
+	Description: "",
+	Computed:    false,
+	ForceNew:    false,
+	Elem:        nil,
+	MaxItems:    0,
+	MinItems:    0,
+
+	ConflictsWith: []string{"foo", "bar", "baz"},
+}
+
+func TestConflictsWithAdded_Check(t *testing.T) {
+	data := conflictsWithAdded{}
+	if res := data.Check(conflictsWithAddedBaseNode, conflictsWithAddedBaseNode, ""); res != nil {
+		t.Errorf("expected no violation, got %+v", res)
+	}
+	if res := data.Check(conflictsWithAddedBaseNode, conflictsWithAddedPasses, ""); res != nil {
+		t.Errorf("expected no violation, got %+v", res)
+	}
+	if res := data.Check(conflictsWithAddedBaseNode, conflictsWithAddedViolates, ""); res == nil {
+		t.Errorf("expected violation, but didn't get one")
+	}
+}
@@ -0,0 +1,36 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package schema_rules
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/go-azure-helpers/lang/pointer"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tools/schema-api/providerjson"
+)
+
+var _ BreakingChangeRuleWithMinimumSchemaVersion = exactlyOneOfChanged{}
+
+type exactlyOneOfChanged struct{}
+
+func (exactlyOneOfChanged) Name() string {
+	return "exactly_one_of_changed"
+}
+
+func (exactlyOneOfChanged) MinimumSchemaVersion() int {
+	return providerjson.SchemaVersionValidationDetails
+}
+
+// Check - Checks that ExactlyOneOf is unchanged for an existing property, since adding or removing an entry can invalidate user configs in either direction
+func (exactlyOneOfChanged) Check(base providerjson.SchemaJSON, current providerjson.SchemaJSON, propertyName string) *string {
+	if base.Type == "" || current.Type == "" {
+		return nil
+	}
+
+	if len(missingFrom(base.ExactlyOneOf, current.ExactlyOneOf)) > 0 || len(missingFrom(current.ExactlyOneOf, base.ExactlyOneOf)) > 0 {
+		return pointer.To(fmt.Sprintf("ExactlyOneOf has changed for property %q (%q to %q)", propertyName, base.ExactlyOneOf, current.ExactlyOneOf))
+	}
+
+	return nil
+}
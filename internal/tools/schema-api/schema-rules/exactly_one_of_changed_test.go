@@ -0,0 +1,87 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package schema_rules
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tools/schema-api/providerjson"
+)
+
+var exactlyOneOfChangedBaseNode = providerjson.SchemaJSON{
+	Type:        providerjson.SchemaTypeString,
+	ConfigMode:  "",
+	Optional:    true,
+	Required:    false,
+	Default:     nil,
+	Description: "",
+	Computed:    false,
+	ForceNew:    false,
+	Elem:        nil,
+	MaxItems:    0,
+	MinItems:    0,
+
+	ExactlyOneOf: []string{"foo", "bar"},
+}
+
+var exactlyOneOfChangedPasses = providerjson.SchemaJSON{
+	Type:        providerjson.SchemaTypeString,
+	ConfigMode:  "",
+	Optional:    true,
+	Required:    false,
+	Default:     nil,
+	Description: "",
+	Computed:    false,
+	ForceNew:    false,
+	Elem:        nil,
+	MaxItems:    0,
+	MinItems:    0,
+
+	ExactlyOneOf: []string{"bar", "foo"},
+}
+
+var exactlyOneOfChangedAdded = providerjson.SchemaJSON{
+	Type:        providerjson.SchemaTypeString,
+	ConfigMode:  "",
+	Optional:    true,
+	Required:    false,
+	Default:     nil,
+	Description: "",
+	Computed:    false,
+	ForceNew:    false,
+	Elem:        nil,
+	MaxItems:    0,
+	MinItems:    0,
+
+	ExactlyOneOf: []string{"foo", "bar", "baz"},
+}
+
+var exactlyOneOfChangedRemoved = providerjson.SchemaJSON{
+	Type:        providerjson.SchemaTypeString,
+	ConfigMode:  "",
+	Optional:    true,
+	Required:    false,
+	Default:     nil,
+	Description: "",
+	Computed:    false,
+	ForceNew:    false,
+	Elem:        nil,
+	MaxItems:    0,
+	MinItems:    0,
+
+	ExactlyOneOf: []string{"foo"},
+}
+
+func TestExactlyOneOfChanged_Check(t *testing.T) {
+	data := exactlyOneOfChanged{}
+	if res := data.Check(exactlyOneOfChangedBaseNode, exactlyOneOfChangedPasses, ""); res != nil {
+		t.Errorf("expected no violation, got %+v", res)
+	}
+	if res := data.Check(exactlyOneOfChangedBaseNode, exactlyOneOfChangedAdded, ""); res == nil {
+		t.Errorf("expected violation, but didn't get one")
+	}
+	if res := data.Check(exactlyOneOfChangedBaseNode, exactlyOneOfChangedRemoved, ""); res == nil {
+		t.Errorf("expected violation, but didn't get one")
+	}
+}
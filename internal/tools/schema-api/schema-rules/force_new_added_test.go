@@ -0,0 +1,79 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package schema_rules
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tools/schema-api/providerjson"
+)
+
+var forceNewAddedBaseNode = providerjson.SchemaJSON{
+	Type:        providerjson.SchemaTypeString,
+	ConfigMode:  "",
+	Optional:    true,
+	Required:    false,
+	Default:     nil,
+	Description: "",
+	Computed:    false,
+	ForceNew:    false,
+	Elem:        nil,
+	MaxItems:    0,
+	MinItems:    0,
+}
+
+var forceNewAddedPasses = providerjson.SchemaJSON{
+	Type:        providerjson.SchemaTypeString,
+	ConfigMode:  "",
+	Optional:    true,
+	Required:    false,
+	Default:     nil,
+	Description: "",
+	Computed:    false,
+	ForceNew:    false,
+	Elem:        nil,
+	MaxItems:    0,
+	MinItems:    0,
+}
+
+var forceNewAddedViolates = providerjson.SchemaJSON{
+	Type:        providerjson.SchemaTypeString,
+	ConfigMode:  "",
+	Optional:    true,
+	Required:    false,
+	Default:     nil,
+	Description: "",
+	Computed:    false,
+	ForceNew:    true, // violation
+	Elem:        nil,
+	MaxItems:    0,
+	MinItems:    0,
+}
+
+var forceNewAddedNewProperty = providerjson.SchemaJSON{
+	Type:        "",
+	ConfigMode:  "",
+	Optional:    true,
+	Required:    false,
+	Default:     nil,
+	Description: "",
+	Computed:    false,
+	ForceNew:    true,
+	Elem:        nil,
+	MaxItems:    0,
+	MinItems:    0,
+}
+
+func TestForceNewAdded_Check(t *testing.T) {
+	data := forceNewAdded{}
+	if res := data.Check(forceNewAddedBaseNode, forceNewAddedPasses, ""); res != nil {
+		t.Errorf("expected no violation, got %+v", res)
+	}
+	if res := data.Check(forceNewAddedBaseNode, forceNewAddedViolates, ""); res == nil {
+		t.Errorf("expected violation, but didn't get one")
+	}
+	if res := data.Check(providerjson.SchemaJSON{}, forceNewAddedNewProperty, ""); res != nil {
+		t.Errorf("expected no violation, got %+v", res)
+	}
+}
@@ -0,0 +1,36 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package schema_rules
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/go-azure-helpers/lang/pointer"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tools/schema-api/providerjson"
+)
+
+var _ BreakingChangeRule = maxItemsReduced{}
+
+type maxItemsReduced struct{}
+
+func (maxItemsReduced) Name() string {
+	return "max_items_reduced"
+}
+
+// Check - Checks that MaxItems is not introduced or lowered on an existing property, as user configs may already contain more items
+func (maxItemsReduced) Check(base providerjson.SchemaJSON, current providerjson.SchemaJSON, propertyName string) *string {
+	if base.Type == "" || current.MaxItems == 0 {
+		return nil
+	}
+
+	if base.MaxItems == 0 {
+		return pointer.To(fmt.Sprintf("cannot introduce MaxItems (%d) on existing property %q", current.MaxItems, propertyName))
+	}
+
+	if current.MaxItems < base.MaxItems {
+		return pointer.To(fmt.Sprintf("cannot lower MaxItems for property %q (%d to %d)", propertyName, base.MaxItems, current.MaxItems))
+	}
+
+	return nil
+}
@@ -0,0 +1,82 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package schema_rules
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tools/schema-api/providerjson"
+)
+
+var maxItemsReducedBaseNode = providerjson.SchemaJSON{
+	Type:        providerjson.SchemaTypeList,
+	ConfigMode:  "",
+	Optional:    true,
+	Required:    false,
+	Default:     nil,
+	Description: "",
+	Computed:    false,
+	ForceNew:    false,
+	Elem:        nil,
+	MaxItems:    5,
+	MinItems:    0,
+}
+
+var maxItemsReducedPasses = providerjson.SchemaJSON{
+	Type:        providerjson.SchemaTypeList,
+	ConfigMode:  "",
+	Optional:    true,
+	Required:    false,
+	Default:     nil,
+	Description: "",
+	Computed:    false,
+	ForceNew:    false,
+	Elem:        nil,
+	MaxItems:    10,
+	MinItems:    0,
+}
+
+var maxItemsReducedViolates = providerjson.SchemaJSON{
+	Type:        providerjson.SchemaTypeList,
+	ConfigMode:  "",
+	Optional:    true,
+	Required:    false,
+	Default:     nil,
+	Description: "",
+	Computed:    false,
+	ForceNew:    false,
+	Elem:        nil,
+	MaxItems:    2, // violation
+	MinItems:    0,
+}
+
+var maxItemsReducedUnbounded = providerjson.SchemaJSON{
+	Type:        providerjson.SchemaTypeList,
+	ConfigMode:  "",
+	Optional:    true,
+	Required:    false,
+	Default:     nil,
+	Description: "",
+	Computed:    false,
+	ForceNew:    false,
+	Elem:        nil,
+	MaxItems:    0,
+	MinItems:    0,
+}
+
+func TestMaxItemsReduced_Check(t *testing.T) {
+	data := maxItemsReduced{}
+	if res := data.Check(maxItemsReducedBaseNode, maxItemsReducedPasses, ""); res != nil {
+		t.Errorf("expected no violation, got %+v", res)
+	}
+	if res := data.Check(maxItemsReducedBaseNode, maxItemsReducedUnbounded, ""); res != nil {
+		t.Errorf("expected no violation, got %+v", res)
+	}
+	if res := data.Check(maxItemsReducedBaseNode, maxItemsReducedViolates, "example"); res == nil || *res != `cannot lower MaxItems for property "example" (5 to 2)` {
+		t.Errorf("expected a violation lowering MaxItems, got %+v", res)
+	}
+	if res := data.Check(maxItemsReducedUnbounded, maxItemsReducedBaseNode, "example"); res == nil || *res != `cannot introduce MaxItems (5) on existing property "example"` {
+		t.Errorf("expected a violation introducing MaxItems, got %+v", res)
+	}
+}
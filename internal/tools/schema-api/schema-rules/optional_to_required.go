@@ -14,6 +14,10 @@ type optionalToRequired struct{}
 
 var _ BreakingChangeRule = optionalToRequired{}
 
+func (optionalToRequired) Name() string {
+	return "optional_to_required"
+}
+
 // Check - Checks that an Optional property is not update to become Required
 func (o optionalToRequired) Check(base providerjson.SchemaJSON, current providerjson.SchemaJSON, propertyName string) *string {
 	if base.Optional && current.Required {
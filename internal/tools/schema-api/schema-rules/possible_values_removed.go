@@ -0,0 +1,41 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package schema_rules
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/go-azure-helpers/lang/pointer"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tools/schema-api/providerjson"
+)
+
+var _ BreakingChangeRuleWithMinimumSchemaVersion = possibleValuesRemoved{}
+
+type possibleValuesRemoved struct{}
+
+func (possibleValuesRemoved) Name() string {
+	return "possible_values_removed"
+}
+
+func (possibleValuesRemoved) MinimumSchemaVersion() int {
+	return providerjson.SchemaVersionValidationDetails
+}
+
+// Check - Checks that the possible values of an existing property are not restricted, either by removing values or by introducing a `validation.StringInSlice`
+func (possibleValuesRemoved) Check(base providerjson.SchemaJSON, current providerjson.SchemaJSON, propertyName string) *string {
+	if base.Type == "" || len(current.PossibleValues) == 0 {
+		return nil
+	}
+
+	if len(base.PossibleValues) == 0 {
+		return pointer.To(fmt.Sprintf("cannot restrict the existing property %q to the possible values %q", propertyName, current.PossibleValues))
+	}
+
+	if removed := missingFrom(base.PossibleValues, current.PossibleValues); len(removed) > 0 {
+		return pointer.To(fmt.Sprintf("cannot remove the possible values %s from property %q", strings.Join(removed, ", "), propertyName))
+	}
+
+	return nil
+}
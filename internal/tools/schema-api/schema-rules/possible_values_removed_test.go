@@ -0,0 +1,88 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package schema_rules
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tools/schema-api/providerjson"
+)
+
+var possibleValuesRemovedBaseNode = providerjson.SchemaJSON{
+	Type:        providerjson.SchemaTypeString,
+	ConfigMode:  "",
+	Optional:    true,
+	Required:    false,
+	Default:     nil,
+	Description: "",
+	Computed:    false,
+	ForceNew:    false,
+	Elem:        nil,
+	MaxItems:    0,
+	MinItems:    0,
+
+	PossibleValues: []string{"Basic", "Standard"},
+}
+
+var possibleValuesRemovedPasses = providerjson.SchemaJSON{
+	Type:        providerjson.SchemaTypeString,
+	ConfigMode:  "",
+	Optional:    true,
+	Required:    false,
+	Default:     nil,
+	Description: "",
+	Computed:    false,
+	ForceNew:    false,
+	Elem:        nil,
+	MaxItems:    0,
+	MinItems:    0,
+
+	PossibleValues: []string{"Basic", "Premium", "Standard"},
+}
+
+var possibleValuesRemovedViolates = providerjson.SchemaJSON{
+	Type:        providerjson.SchemaTypeString,
+	ConfigMode:  "",
+	Optional:    true,
+	Required:    false,
+	Default:     nil,
+	Description: "",
+	Computed:    false,
+	ForceNew:    false,
+	Elem:        nil,
+	MaxItems:    0,
+	MinItems:    0,
+
+	PossibleValues: []string{"Standard"},
+}
+
+var possibleValuesRemovedUnrestricted = providerjson.SchemaJSON{
+	Type:        providerjson.SchemaTypeString,
+	ConfigMode:  "",
+	Optional:    true,
+	Required:    false,
+	Default:     nil,
+	Description: "",
+	Computed:    false,
+	ForceNew:    false,
+	Elem:        nil,
+	MaxItems:    0,
+	MinItems:    0,
+}
+
+func TestPossibleValuesRemoved_Check(t *testing.T) {
+	data := possibleValuesRemoved{}
+	if res := data.Check(possibleValuesRemovedBaseNode, possibleValuesRemovedPasses, ""); res != nil {
+		t.Errorf("expected no violation, got %+v", res)
+	}
+	if res := data.Check(possibleValuesRemovedBaseNode, possibleValuesRemovedUnrestricted, ""); res != nil {
+		t.Errorf("expected no violation, got %+v", res)
+	}
+	if res := data.Check(possibleValuesRemovedBaseNode, possibleValuesRemovedViolates, ""); res == nil {
+		t.Errorf("expected violation, but didn't get one")
+	}
+	if res := data.Check(possibleValuesRemovedUnrestricted, possibleValuesRemovedBaseNode, ""); res == nil {
+		t.Errorf("expected violation, but didn't get one")
+	}
+}
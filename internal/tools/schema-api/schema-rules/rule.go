@@ -6,17 +6,64 @@ package schema_rules
 import "github.com/hashicorp/terraform-provider-azurerm/internal/tools/schema-api/providerjson"
 
 type BreakingChangeRule interface {
+	// Name returns the identifier of this rule, used in the machine-readable report
+	Name() string
+
 	Check(base providerjson.SchemaJSON, current providerjson.SchemaJSON, propertyName string) *string
 }
 
+// BreakingChangeRuleWithMinimumSchemaVersion is implemented by rules which rely on fields that were added to the
+// exported schema in a later version - these rules are skipped when the base schema predates these fields, since
+// otherwise every existing property using these would be reported as changed
+type BreakingChangeRuleWithMinimumSchemaVersion interface {
+	BreakingChangeRule
+
+	MinimumSchemaVersion() int
+}
+
 var BreakingChangeRules = []BreakingChangeRule{
 	becomeComputedOnly{},
+	conflictsWithAdded{},
+	exactlyOneOfChanged{},
+	forceNewAdded{},
+	maxItemsReduced{},
 	newRequiredPropertyExistingResource{},
 	optionalRemoveComputed{},
 	optionalToRequired{},
+	possibleValuesRemoved{},
+	propertyRemoved{},
 	propertyType{},
 }
 
 var BreakingChangeRulesDataSource = []BreakingChangeRule{
+	propertyRemoved{},
 	propertyType{},
 }
+
+// RulesForSchemaVersion returns the rules which can be checked against a base schema exported using the specified version
+func RulesForSchemaVersion(rules []BreakingChangeRule, schemaVersion int) []BreakingChangeRule {
+	result := make([]BreakingChangeRule, 0)
+	for _, rule := range rules {
+		if v, ok := rule.(BreakingChangeRuleWithMinimumSchemaVersion); ok && schemaVersion < v.MinimumSchemaVersion() {
+			continue
+		}
+		result = append(result, rule)
+	}
+	return result
+}
+
+// missingFrom returns the values in `input` which are not present in `other`
+func missingFrom(input []string, other []string) []string {
+	existing := make(map[string]struct{}, len(other))
+	for _, v := range other {
+		existing[v] = struct{}{}
+	}
+
+	result := make([]string, 0)
+	for _, v := range input {
+		if _, ok := existing[v]; !ok {
+			result = append(result, v)
+		}
+	}
+	return result
+}
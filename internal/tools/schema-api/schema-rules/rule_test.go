@@ -0,0 +1,28 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package schema_rules
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tools/schema-api/providerjson"
+)
+
+func TestRulesForSchemaVersion(t *testing.T) {
+	validationDetailRules := map[string]struct{}{
+		conflictsWithAdded{}.Name():    {},
+		exactlyOneOfChanged{}.Name():   {},
+		possibleValuesRemoved{}.Name(): {},
+	}
+
+	for _, rule := range RulesForSchemaVersion(BreakingChangeRules, 1) {
+		if _, ok := validationDetailRules[rule.Name()]; ok {
+			t.Fatalf("expected the rule %q to be skipped for a base schema which predates the validation details", rule.Name())
+		}
+	}
+
+	if actual := RulesForSchemaVersion(BreakingChangeRules, providerjson.SchemaVersionValidationDetails); len(actual) != len(BreakingChangeRules) {
+		t.Fatalf("expected all %d rules to be checked for the current schema version but got %d", len(BreakingChangeRules), len(actual))
+	}
+}
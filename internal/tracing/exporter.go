@@ -0,0 +1,233 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package tracing
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/terraform-provider-azurerm/version"
+)
+
+const instrumentationScope = "github.com/hashicorp/terraform-provider-azurerm"
+
+// the types below are the subset of the OTLP JSON encoding (ExportTraceServiceRequest) which is used
+// see https://opentelemetry.io/docs/specs/otlp/#json-protobuf-encoding
+
+type otlpTraces struct {
+	ResourceSpans []otlpResourceSpans `json:"resourceSpans"`
+}
+
+type otlpResourceSpans struct {
+	Resource   otlpResource     `json:"resource"`
+	ScopeSpans []otlpScopeSpans `json:"scopeSpans"`
+}
+
+type otlpResource struct {
+	Attributes []otlpAttribute `json:"attributes"`
+}
+
+type otlpScopeSpans struct {
+	Scope otlpScope  `json:"scope"`
+	Spans []otlpSpan `json:"spans"`
+}
+
+type otlpScope struct {
+	Name    string `json:"name"`
+	Version string `json:"version"`
+}
+
+type otlpSpan struct {
+	TraceId           string          `json:"traceId"`
+	SpanId            string          `json:"spanId"`
+	ParentSpanId      string          `json:"parentSpanId,omitempty"`
+	Name              string          `json:"name"`
+	Kind              SpanKind        `json:"kind"`
+	StartTimeUnixNano string          `json:"startTimeUnixNano"`
+	EndTimeUnixNano   string          `json:"endTimeUnixNano"`
+	Attributes        []otlpAttribute `json:"attributes,omitempty"`
+	Status            otlpStatus      `json:"status"`
+}
+
+type otlpStatus struct {
+	Code    int    `json:"code,omitempty"`
+	Message string `json:"message,omitempty"`
+}
+
+type otlpAttribute struct {
+	Key   string       `json:"key"`
+	Value otlpAnyValue `json:"value"`
+}
+
+type otlpAnyValue struct {
+	StringValue *string `json:"stringValue,omitempty"`
+	BoolValue   *bool   `json:"boolValue,omitempty"`
+	IntValue    *string `json:"intValue,omitempty"`
+}
+
+const (
+	otlpStatusCodeOk    = 1
+	otlpStatusCodeError = 2
+)
+
+func encodeSpans(spans []*Span, serviceName string) otlpTraces {
+	encoded := make([]otlpSpan, 0, len(spans))
+	for _, span := range spans {
+		span.mu.Lock()
+		s := otlpSpan{
+			TraceId:           span.traceId,
+			SpanId:            span.spanId,
+			ParentSpanId:      span.parentSpanId,
+			Name:              span.name,
+			Kind:              span.kind,
+			StartTimeUnixNano: unixNano(span.start),
+			EndTimeUnixNano:   unixNano(span.end),
+			Attributes:        encodeAttributes(span.attributes),
+			Status: otlpStatus{
+				Code: otlpStatusCodeOk,
+			},
+		}
+		if span.err != nil {
+			s.Status = otlpStatus{
+				Code:    otlpStatusCodeError,
+				Message: span.err.Error(),
+			}
+		}
+		span.mu.Unlock()
+
+		encoded = append(encoded, s)
+	}
+
+	return otlpTraces{
+		ResourceSpans: []otlpResourceSpans{
+			{
+				Resource: otlpResource{
+					Attributes: encodeAttributes([]Attribute{
+						String("service.name", serviceName),
+						String("service.version", version.ProviderVersion),
+					}),
+				},
+				ScopeSpans: []otlpScopeSpans{
+					{
+						Scope: otlpScope{
+							Name:    instrumentationScope,
+							Version: version.ProviderVersion,
+						},
+						Spans: encoded,
+					},
+				},
+			},
+		},
+	}
+}
+
+func encodeAttributes(input []Attribute) []otlpAttribute {
+	output := make([]otlpAttribute, 0, len(input))
+	for _, attribute := range input {
+		value := otlpAnyValue{}
+		switch v := attribute.Value.(type) {
+		case string:
+			value.StringValue = &v
+		case bool:
+			value.BoolValue = &v
+		case int:
+			i := strconv.Itoa(v)
+			value.IntValue = &i
+		default:
+			s := fmt.Sprintf("%v", v)
+			value.StringValue = &s
+		}
+		output = append(output, otlpAttribute{
+			Key:   attribute.Key,
+			Value: value,
+		})
+	}
+	return output
+}
+
+func unixNano(t time.Time) string {
+	return strconv.FormatInt(t.UnixNano(), 10)
+}
+
+// otlpExporter sends spans to an OpenTelemetry Collector using OTLP/HTTP with the JSON encoding
+type otlpExporter struct {
+	endpoint string
+	headers  map[string]string
+	client   *http.Client
+}
+
+func newOtlpExporter(endpoint string, headers map[string]string) *otlpExporter {
+	return &otlpExporter{
+		endpoint: endpoint,
+		headers:  headers,
+		client: &http.Client{
+			Timeout: 10 * time.Second,
+		},
+	}
+}
+
+func (e *otlpExporter) export(spans []*Span, serviceName string) error {
+	body, err := json.Marshal(encodeSpans(spans, serviceName))
+	if err != nil {
+		return fmt.Errorf("encoding spans: %+v", err)
+	}
+
+	request, err := http.NewRequest(http.MethodPost, e.endpoint, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("building request: %+v", err)
+	}
+	request.Header.Set("Content-Type", "application/json")
+	for k, v := range e.headers {
+		request.Header.Set(k, v)
+	}
+
+	response, err := e.client.Do(request)
+	if err != nil {
+		return fmt.Errorf("sending spans to %q: %+v", e.endpoint, err)
+	}
+	defer response.Body.Close()
+	_, _ = io.Copy(io.Discard, response.Body)
+
+	if response.StatusCode < 200 || response.StatusCode > 299 {
+		return fmt.Errorf("sending spans to %q: unexpected status %d", e.endpoint, response.StatusCode)
+	}
+
+	return nil
+}
+
+// fileExporter appends spans to a file, with one OTLP JSON document per line
+type fileExporter struct {
+	path string
+
+	mu sync.Mutex
+}
+
+func (e *fileExporter) export(spans []*Span, serviceName string) error {
+	line, err := json.Marshal(encodeSpans(spans, serviceName))
+	if err != nil {
+		return fmt.Errorf("encoding spans: %+v", err)
+	}
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	f, err := os.OpenFile(e.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o600)
+	if err != nil {
+		return fmt.Errorf("opening %q: %+v", e.path, err)
+	}
+	defer f.Close()
+
+	if _, err := f.Write(append(line, '\n')); err != nil {
+		return fmt.Errorf("writing to %q: %+v", e.path, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,328 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package tracing
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"log"
+	"os"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// EnvTracesExporter specifies the exporter which traces are sent to, either `otlp` or `file` - tracing is
+	// disabled when this is unset (or `none`)
+	EnvTracesExporter = "OTEL_TRACES_EXPORTER"
+
+	// EnvOtlpEndpoint is the base URL of the OTLP/HTTP collector, to which `/v1/traces` is appended
+	EnvOtlpEndpoint = "OTEL_EXPORTER_OTLP_ENDPOINT"
+
+	// EnvOtlpTracesEndpoint is the full URL of the OTLP/HTTP traces endpoint, which takes precedence over EnvOtlpEndpoint
+	EnvOtlpTracesEndpoint = "OTEL_EXPORTER_OTLP_TRACES_ENDPOINT"
+
+	// EnvOtlpHeaders is a comma-separated list of `key=value` headers sent to the OTLP/HTTP collector
+	EnvOtlpHeaders = "OTEL_EXPORTER_OTLP_HEADERS"
+
+	// EnvServiceName overrides the `service.name` the traces are reported under
+	EnvServiceName = "OTEL_SERVICE_NAME"
+
+	// EnvTracesFile is the path of the file which traces are appended to when using the `file` exporter
+	EnvTracesFile = "ARM_OTEL_TRACES_FILE"
+
+	defaultOtlpEndpoint = "http://localhost:4318"
+	defaultServiceName  = "terraform-provider-azurerm"
+	defaultTracesFile   = "terraform-provider-azurerm-traces.jsonl"
+)
+
+type SpanKind int
+
+const (
+	SpanKindInternal SpanKind = 1
+	SpanKindClient   SpanKind = 3
+)
+
+// Attribute is a key/value pair which is recorded against a Span, the value must be a string, bool or int
+type Attribute struct {
+	Key   string
+	Value interface{}
+}
+
+func String(key, value string) Attribute {
+	return Attribute{Key: key, Value: value}
+}
+
+func Int(key string, value int) Attribute {
+	return Attribute{Key: key, Value: value}
+}
+
+func Bool(key string, value bool) Attribute {
+	return Attribute{Key: key, Value: value}
+}
+
+// Span is a single operation within a trace. All methods are safe to call on a nil Span, which is
+// returned when tracing is disabled.
+type Span struct {
+	tracer *tracer
+
+	name         string
+	kind         SpanKind
+	traceId      string
+	spanId       string
+	parentSpanId string
+	start        time.Time
+
+	mu         sync.Mutex
+	end        time.Time
+	attributes []Attribute
+	err        error
+	ended      bool
+}
+
+type spanKey struct{}
+
+// Start starts a new Span as a child of the Span within ctx (if any), returning a Context containing the new Span.
+// The Span must be ended by calling End.
+func Start(ctx context.Context, name string, kind SpanKind, attributes ...Attribute) (context.Context, *Span) {
+	t := defaultTracer()
+	if t == nil {
+		return ctx, nil
+	}
+
+	span := &Span{
+		tracer:     t,
+		name:       name,
+		kind:       kind,
+		spanId:     newId(8),
+		start:      time.Now(),
+		attributes: attributes,
+	}
+
+	if parent := FromContext(ctx); parent != nil {
+		span.traceId = parent.traceId
+		span.parentSpanId = parent.spanId
+	} else {
+		span.traceId = newId(16)
+	}
+
+	return context.WithValue(ctx, spanKey{}, span), span
+}
+
+// FromContext returns the Span within ctx, or nil if there isn't one
+func FromContext(ctx context.Context) *Span {
+	if ctx == nil {
+		return nil
+	}
+	span, _ := ctx.Value(spanKey{}).(*Span)
+	return span
+}
+
+// SetAttributes records the attributes against the Span, replacing any existing values with the same key
+func (s *Span) SetAttributes(attributes ...Attribute) {
+	if s == nil {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, attribute := range attributes {
+		replaced := false
+		for i, existing := range s.attributes {
+			if existing.Key == attribute.Key {
+				s.attributes[i] = attribute
+				replaced = true
+				break
+			}
+		}
+		if !replaced {
+			s.attributes = append(s.attributes, attribute)
+		}
+	}
+}
+
+// RecordError marks the Span as failed, when err is not nil
+func (s *Span) RecordError(err error) {
+	if s == nil || err == nil {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.err = err
+}
+
+// End completes the Span, root Spans queue all of the completed Spans within the trace for export
+func (s *Span) End() {
+	if s == nil {
+		return
+	}
+
+	s.mu.Lock()
+	if s.ended {
+		s.mu.Unlock()
+		return
+	}
+	s.ended = true
+	s.end = time.Now()
+	s.mu.Unlock()
+
+	s.tracer.finish(s)
+}
+
+type exporter interface {
+	export(spans []*Span, serviceName string) error
+}
+
+// tracerQueueSize is the number of traces which can be queued for export, beyond which traces are dropped
+// rather than delaying the operations being traced
+const tracerQueueSize = 100
+
+type tracer struct {
+	exporter    exporter
+	serviceName string
+
+	mu      sync.Mutex
+	pending []*Span
+	closed  bool
+
+	// queue contains the traces to be exported in the background, and done is closed once these have been exported
+	queue chan []*Span
+	done  chan struct{}
+}
+
+func newTracer(exporter exporter, serviceName string) *tracer {
+	t := &tracer{
+		exporter:    exporter,
+		serviceName: serviceName,
+		queue:       make(chan []*Span, tracerQueueSize),
+		done:        make(chan struct{}),
+	}
+	go t.run()
+
+	return t
+}
+
+// run exports the queued traces until the tracer is shut down
+func (t *tracer) run() {
+	defer close(t.done)
+
+	for spans := range t.queue {
+		if err := t.exporter.export(spans, t.serviceName); err != nil {
+			log.Printf("[DEBUG] Tracing: exporting %d spans: %+v", len(spans), err)
+		}
+	}
+}
+
+func (t *tracer) finish(span *Span) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.pending = append(t.pending, span)
+	if span.parentSpanId != "" {
+		return
+	}
+	spans := t.pending
+	t.pending = nil
+
+	if t.closed {
+		log.Printf("[DEBUG] Tracing: dropping %d spans since tracing has been shut down", len(spans))
+		return
+	}
+
+	// the trace is exported in the background, so that the operation isn't delayed by the exporter
+	select {
+	case t.queue <- spans:
+	default:
+		log.Printf("[DEBUG] Tracing: dropping %d spans since the export queue is full", len(spans))
+	}
+}
+
+// shutdown stops accepting traces and waits for those which are queued to be exported, or until ctx is done
+func (t *tracer) shutdown(ctx context.Context) {
+	t.mu.Lock()
+	if !t.closed {
+		t.closed = true
+		close(t.queue)
+	}
+	t.mu.Unlock()
+
+	select {
+	case <-t.done:
+	case <-ctx.Done():
+		log.Printf("[DEBUG] Tracing: timed out waiting for traces to be exported")
+	}
+}
+
+// Shutdown exports any traces which are queued for export, waiting until this completes or ctx is done. This should
+// be called once the Provider has finished serving requests.
+func Shutdown(ctx context.Context) {
+	if t := defaultTracer(); t != nil {
+		t.shutdown(ctx)
+	}
+}
+
+var (
+	configureOnce sync.Once
+	configured    *tracer
+)
+
+func defaultTracer() *tracer {
+	configureOnce.Do(func() {
+		configured = newTracerFromEnvironment()
+	})
+	return configured
+}
+
+func newTracerFromEnvironment() *tracer {
+	serviceName := os.Getenv(EnvServiceName)
+	if serviceName == "" {
+		serviceName = defaultServiceName
+	}
+
+	switch strings.ToLower(strings.TrimSpace(os.Getenv(EnvTracesExporter))) {
+	case "otlp":
+		endpoint := os.Getenv(EnvOtlpTracesEndpoint)
+		if endpoint == "" {
+			base := os.Getenv(EnvOtlpEndpoint)
+			if base == "" {
+				base = defaultOtlpEndpoint
+			}
+			endpoint = strings.TrimSuffix(base, "/") + "/v1/traces"
+		}
+		log.Printf("[DEBUG] Tracing: exporting traces to the OTLP endpoint %q", endpoint)
+		return newTracer(newOtlpExporter(endpoint, parseHeaders(os.Getenv(EnvOtlpHeaders))), serviceName)
+
+	case "file":
+		path := os.Getenv(EnvTracesFile)
+		if path == "" {
+			path = defaultTracesFile
+		}
+		log.Printf("[DEBUG] Tracing: exporting traces to the file %q", path)
+		return newTracer(&fileExporter{path: path}, serviceName)
+	}
+
+	return nil
+}
+
+func parseHeaders(input string) map[string]string {
+	headers := make(map[string]string)
+	for _, pair := range strings.Split(input, ",") {
+		key, value, ok := strings.Cut(pair, "=")
+		if !ok || strings.TrimSpace(key) == "" {
+			continue
+		}
+		headers[strings.TrimSpace(key)] = strings.TrimSpace(value)
+	}
+	return headers
+}
+
+func newId(length int) string {
+	b := make([]byte, length)
+	_, _ = rand.Read(b)
+	return hex.EncodeToString(b)
+}
@@ -0,0 +1,160 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package tracing
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+type fakeExporter struct {
+	exported [][]*Span
+}
+
+func (e *fakeExporter) export(spans []*Span, _ string) error {
+	e.exported = append(e.exported, spans)
+	return nil
+}
+
+func withTracer(t *testing.T, exp exporter) {
+	configureOnce.Do(func() {})
+	previous := configured
+	configured = newTracer(exp, defaultServiceName)
+	t.Cleanup(func() {
+		configured.shutdown(context.Background())
+		configured = previous
+	})
+}
+
+func TestStartDisabled(t *testing.T) {
+	configureOnce.Do(func() {})
+	previous := configured
+	configured = nil
+	defer func() {
+		configured = previous
+	}()
+
+	ctx, span := Start(context.Background(), "example", SpanKindInternal)
+	if span != nil {
+		t.Fatalf("expected no Span when tracing is disabled")
+	}
+	if FromContext(ctx) != nil {
+		t.Fatalf("expected no Span in the context when tracing is disabled")
+	}
+
+	// these must be safe to call on the nil Span
+	span.SetAttributes(String("key", "value"))
+	span.RecordError(errors.New("example"))
+	span.End()
+}
+
+func TestSpanParenting(t *testing.T) {
+	exp := &fakeExporter{}
+	withTracer(t, exp)
+
+	ctx, root := Start(context.Background(), "azurerm_resource_group Create", SpanKindInternal)
+	_, first := Start(ctx, "HTTP PUT", SpanKindClient)
+	_, second := Start(ctx, "LRO Poll GET", SpanKindClient)
+
+	first.End()
+	second.End()
+	if len(exp.exported) != 0 {
+		t.Fatalf("expected no Spans to be exported until the root Span has ended")
+	}
+
+	root.End()
+	root.End()
+	Shutdown(context.Background())
+	if len(exp.exported) != 1 {
+		t.Fatalf("expected the trace to be exported once but got %d", len(exp.exported))
+	}
+
+	spans := exp.exported[0]
+	if len(spans) != 3 {
+		t.Fatalf("expected 3 Spans but got %d", len(spans))
+	}
+	for _, span := range []*Span{first, second} {
+		if span.traceId != root.traceId {
+			t.Fatalf("expected Span %q to have the Trace ID %q but got %q", span.name, root.traceId, span.traceId)
+		}
+		if span.parentSpanId != root.spanId {
+			t.Fatalf("expected Span %q to have the parent %q but got %q", span.name, root.spanId, span.parentSpanId)
+		}
+	}
+}
+
+func TestSetAttributesReplacesExisting(t *testing.T) {
+	withTracer(t, &fakeExporter{})
+
+	_, span := Start(context.Background(), "example", SpanKindInternal, String("azurerm.resource_id", ""), Int("http.response.status_code", 200))
+	span.SetAttributes(String("azurerm.resource_id", "/subscriptions/00000000-0000-0000-0000-000000000000"), Bool("azurerm.lro_poll", true))
+
+	if len(span.attributes) != 3 {
+		t.Fatalf("expected 3 attributes but got %d: %+v", len(span.attributes), span.attributes)
+	}
+	if v := span.attributes[0].Value; v != "/subscriptions/00000000-0000-0000-0000-000000000000" {
+		t.Fatalf("expected the attribute to be replaced but got %q", v)
+	}
+}
+
+func TestFileExporter(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "traces.jsonl")
+	withTracer(t, &fileExporter{path: path})
+
+	ctx, root := Start(context.Background(), "azurerm_resource_group Read", SpanKindInternal, String("azurerm.resource_type", "azurerm_resource_group"))
+	_, child := Start(ctx, "HTTP GET", SpanKindClient, Int("http.response.status_code", 404), Bool("azurerm.lro_poll", false))
+	child.RecordError(errors.New("unexpected status 404"))
+	child.End()
+	root.End()
+	Shutdown(context.Background())
+
+	contents, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("reading %q: %+v", path, err)
+	}
+	lines := strings.Split(strings.TrimSpace(string(contents)), "\n")
+	if len(lines) != 1 {
+		t.Fatalf("expected 1 line but got %d", len(lines))
+	}
+
+	var traces otlpTraces
+	if err := json.Unmarshal([]byte(lines[0]), &traces); err != nil {
+		t.Fatalf("parsing %q: %+v", lines[0], err)
+	}
+	if len(traces.ResourceSpans) != 1 || len(traces.ResourceSpans[0].ScopeSpans) != 1 {
+		t.Fatalf("expected a single Resource and Scope but got %s", lines[0])
+	}
+
+	spans := traces.ResourceSpans[0].ScopeSpans[0].Spans
+	if len(spans) != 2 {
+		t.Fatalf("expected 2 Spans but got %d", len(spans))
+	}
+	if spans[0].Name != "HTTP GET" || spans[0].Kind != SpanKindClient || spans[0].ParentSpanId != spans[1].SpanId {
+		t.Fatalf("unexpected child Span %+v", spans[0])
+	}
+	if spans[0].Status.Code != otlpStatusCodeError || spans[1].Status.Code != otlpStatusCodeOk {
+		t.Fatalf("expected the child Span to have failed and the root Span to have succeeded")
+	}
+	if v := spans[0].Attributes[0].Value.IntValue; v == nil || *v != "404" {
+		t.Fatalf("expected the status code to be encoded as an intValue but got %+v", spans[0].Attributes[0])
+	}
+	if v := spans[0].Attributes[1].Value.BoolValue; v == nil || *v {
+		t.Fatalf("expected the LRO poll attribute to be encoded as a boolValue but got %+v", spans[0].Attributes[1])
+	}
+}
+
+func TestParseHeaders(t *testing.T) {
+	headers := parseHeaders("Authorization=Bearer abc=, x-example = value ,invalid,=empty")
+	if len(headers) != 2 {
+		t.Fatalf("expected 2 headers but got %+v", headers)
+	}
+	if headers["Authorization"] != "Bearer abc=" || headers["x-example"] != "value" {
+		t.Fatalf("unexpected headers %+v", headers)
+	}
+}
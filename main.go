@@ -7,9 +7,12 @@ import (
 	"context"
 	"flag"
 	"log"
+	"time"
 
 	"github.com/hashicorp/terraform-plugin-go/tfprotov5/tf5server"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/locks"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/provider/framework"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tracing"
 )
 
 func main() {
@@ -32,7 +35,18 @@ func main() {
 		serveOpts = append(serveOpts, tf5server.WithManagedDebug())
 	}
 
+	// Terraform stops reading the logs from the provider before Serve returns, so the summary is logged whilst running
+	ctx, cancel := context.WithCancel(context.Background())
+	go locks.LogContentionSummaryPeriodically(ctx, 30*time.Second)
+
 	err = tf5server.Serve("registry.terraform.io/hashicorp/azurerm", providerServer, serveOpts...)
+	cancel()
+
+	// traces are exported in the background, so any which are queued are exported before exiting
+	shutdownCtx, shutdownCancel := context.WithTimeout(context.Background(), 10*time.Second)
+	tracing.Shutdown(shutdownCtx)
+	shutdownCancel()
+
 	if err != nil {
 		log.Fatal(err)
 	}